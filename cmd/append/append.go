@@ -7,6 +7,9 @@ import (
 )
 
 var configPath string
+var replication int
+var records bool
+var compression string
 
 var appendCmd = &cobra.Command{
 	Use:     "append [localfilename] [sdfsfilename]",
@@ -18,11 +21,16 @@ var appendCmd = &cobra.Command{
 }
 
 func append(cmd *cobra.Command, args []string) {
+	options := client.AppendOptions{Replication: replication, Compression: compression}
 	client, err := client.NewClient(configPath)
 	if err != nil {
 		logrus.Fatal(err)
 	}
-	err = client.AppendFile(args[0], args[1])
+	if records {
+		err = client.AppendRecordsWithRetry(args[0], args[1])
+	} else {
+		err = client.AppendFileWithOptions(args[0], args[1], options)
+	}
 	if err != nil {
 		logrus.Fatal(err)
 	}
@@ -33,5 +41,8 @@ func New() *cobra.Command {
 }
 
 func init() {
+	appendCmd.Flags().IntVar(&replication, "replication", 0, "number of replicas of each block of the file, unchanged or the default in config if 0")
+	appendCmd.Flags().BoolVar(&records, "records", false, "append the file as records of whole lines without the write lock, other clients may append at the same time")
+	appendCmd.Flags().StringVar(&compression, "compression", "", "codec of the appended blocks and the ones appended later, none, snappy, zstd or gzip, unchanged if empty")
 	appendCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
 }
@@ -0,0 +1,42 @@
+package balance
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+var threshold float64
+
+var balanceCmd = &cobra.Command{
+	Use:     "balance",
+	Short:   "trim excess replicas and move blocks from the fullest to the emptiest data servers",
+	Long:    `remove the replicas beyond the replication factor and move blocks between the data servers until the disk usage of each one is within the threshold of the average`,
+	Example: `  sdfs balance --threshold 0.05`,
+	Args:    cobra.NoArgs,
+	Run:     balance,
+}
+
+func balance(cmd *cobra.Command, args []string) {
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	re, err := client.Balance(threshold)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	fmt.Printf("balance:\n%s", re)
+}
+
+func New() *cobra.Command {
+	return balanceCmd
+}
+
+func init() {
+	balanceCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+	balanceCmd.PersistentFlags().Float64VarP(&threshold, "threshold", "t", 0.1, "max difference between the disk usage of a data server and the average (0-1)")
+}
@@ -0,0 +1,44 @@
+package cat
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+var offset int64
+var length int64
+
+var catCmd = &cobra.Command{
+	Use:     "cat [sdfsfilename]",
+	Short:   "print a byte range of a file in SDFS",
+	Long:    `print length bytes at offset of a file in SDFS, only the blocks in the range are fetched from the data servers`,
+	Example: `  sdfs cat sdfs_test --offset 1024 --length 512`,
+	Args:    cobra.ExactArgs(1),
+	Run:     cat,
+}
+
+func cat(cmd *cobra.Command, args []string) {
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	data, err := client.ReadRange(args[0], offset, length)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	os.Stdout.Write(data)
+}
+
+func New() *cobra.Command {
+	return catCmd
+}
+
+func init() {
+	catCmd.Flags().Int64Var(&offset, "offset", 0, "offset to read from, from the end of the file if negative")
+	catCmd.Flags().Int64Var(&length, "length", -1, "number of bytes to read, to the end of the file if negative")
+	catCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+}
@@ -0,0 +1,19 @@
+package certs
+
+import "github.com/spf13/cobra"
+
+var configPath string
+var certsCmd = &cobra.Command{
+	Use:   "certs",
+	Short: "Manage certificates",
+	Long:  "Manage the CA and the certificates of the nodes and the users for mutual TLS",
+}
+
+func New() *cobra.Command {
+	return certsCmd
+}
+
+func init() {
+	certsCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+	certsCmd.AddCommand(initCmd)
+}
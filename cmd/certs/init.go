@@ -0,0 +1,52 @@
+package certs
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
+)
+
+var users []string
+var days int
+var initCmd = &cobra.Command{
+	Use:     "init",
+	Short:   "Create the CA and the certificates",
+	Long:    "Create the CA in the tls dir of config unless it exists, and sign a certificate for each machine in config and each user",
+	Example: `  sdfs certs init --users alice,bob`,
+	Args:    cobra.NoArgs,
+	Run:     initCerts,
+}
+
+func initCerts(cmd *cobra.Command, args []string) {
+	conf, err := config.NewConfig(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	if conf.TLS.Dir == "" {
+		logrus.Fatal("tls dir is not set in config")
+	}
+	nodes := []string{}
+	for _, machine := range conf.Machines {
+		nodes = append(nodes, machine.Hostname)
+	}
+	if len(users) == 0 {
+		user, err := auth.UserName(conf.TLS)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		users = []string{user}
+	}
+	err = auth.InitCerts(conf.TLS.Dir, nodes, users, time.Duration(days)*24*time.Hour)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	logrus.Infof("Created certificates of %d nodes and %d users in %s, copy the directory except ca.key to every machine", len(nodes), len(users), conf.TLS.Dir)
+}
+
+func init() {
+	initCmd.Flags().StringSliceVar(&users, "users", nil, "users to create certificates for, the user of the commands if empty")
+	initCmd.Flags().IntVar(&days, "days", 365, "days the certificates are valid for")
+}
@@ -7,12 +7,13 @@ import (
 )
 
 var configPath string
+var recursive bool
 
 var deleteCmd = &cobra.Command{
 	Use:     "delete sdfsfilename",
-	Short:   "delete a file from SDFS",
-	Long:    `delete a file from SDFS`,
-	Example: `  delete sdfs_test`,
+	Short:   "delete a file or directory from SDFS",
+	Long:    `delete a file from SDFS, or a directory and everything in it with -r`,
+	Example: `  delete -r /outputs`,
 	Args:    cobra.ExactArgs(1),
 	Run:     delete,
 }
@@ -22,7 +23,11 @@ func delete(cmd *cobra.Command, args []string) {
 	if err != nil {
 		logrus.Fatal(err)
 	}
-	err = client.DelFile(args[0])
+	if recursive {
+		err = client.DelRecursive(args[0])
+	} else {
+		err = client.DelFile(args[0])
+	}
 	if err != nil {
 		logrus.Fatal(err)
 	}
@@ -34,4 +39,5 @@ func New() *cobra.Command {
 
 func init() {
 	deleteCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+	deleteCmd.PersistentFlags().BoolVarP(&recursive, "recursive", "r", false, "delete a directory and everything in it")
 }
@@ -0,0 +1,40 @@
+package gateway
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/gateway"
+)
+
+var configPath string
+var address string
+var bucketPrefix string
+
+var gatewayCmd = &cobra.Command{
+	Use:     "gateway",
+	Short:   "serve an S3-compatible HTTP API of SDFS",
+	Long:    `serve an S3-compatible HTTP API of SDFS, each bucket is a top-level directory and each object is the file at its key in the directory`,
+	Example: `  sdfs gateway --address localhost:9000 --bucket-prefix s3-`,
+	Args:    cobra.NoArgs,
+	Run:     serveGateway,
+}
+
+func serveGateway(cmd *cobra.Command, args []string) {
+	g, err := gateway.NewGateway(configPath, address, bucketPrefix)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	if err := g.Run(); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func New() *cobra.Command {
+	return gatewayCmd
+}
+
+func init() {
+	gatewayCmd.Flags().StringVarP(&address, "address", "a", "localhost:9000", "address to listen on, the requests are not authenticated")
+	gatewayCmd.Flags().StringVarP(&bucketPrefix, "bucket-prefix", "b", "", "serve only the buckets whose names start with the prefix, all top-level directories if empty")
+	gatewayCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+}
@@ -7,6 +7,7 @@ import (
 )
 
 var configPath string
+var version int64
 
 var getCmd = &cobra.Command{
 	Use:     "get [sdfsfilename] [localfilename]",
@@ -22,7 +23,7 @@ func get(cmd *cobra.Command, args []string) {
 	if err != nil {
 		logrus.Fatal(err)
 	}
-	err = client.GetFile(args[0], args[1])
+	err = client.GetFileVersion(args[0], args[1], version)
 	if err != nil {
 		logrus.Fatal(err)
 	}
@@ -33,5 +34,6 @@ func New() *cobra.Command {
 }
 
 func init() {
+	getCmd.Flags().Int64VarP(&version, "version", "v", 0, "version of the file, the current version if 0")
 	getCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
 }
@@ -0,0 +1,43 @@
+package get_versions
+
+import (
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+
+var getVersionsCmd = &cobra.Command{
+	Use:     "get-versions [sdfsfilename] [numversions] [localfilename]",
+	Short:   "get the last versions of a file from SDFS",
+	Long:    `get the last numversions versions of a file from SDFS into a local file, the newest first and each one starts with a delimiter line`,
+	Example: `  sdfs get-versions sdfs_test 3 local_test`,
+	Args:    cobra.ExactArgs(3),
+	Run:     getVersions,
+}
+
+func getVersions(cmd *cobra.Command, args []string) {
+	num, err := strconv.Atoi(args[1])
+	if err != nil {
+		logrus.Fatalf("invalid number of versions %s: %v", args[1], err)
+	}
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	err = client.GetVersions(args[0], num, args[2])
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func New() *cobra.Command {
+	return getVersionsCmd
+}
+
+func init() {
+	getVersionsCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+}
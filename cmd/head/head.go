@@ -0,0 +1,49 @@
+package head
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+var lines int
+var bytes int64
+
+var headCmd = &cobra.Command{
+	Use:     "head [sdfsfilename]",
+	Short:   "print the first lines of a file in SDFS",
+	Long:    `print the first lines or bytes of a file in SDFS, only the blocks holding them are fetched from the data servers`,
+	Example: `  sdfs head sdfs_test.csv -n 1`,
+	Args:    cobra.ExactArgs(1),
+	Run:     head,
+}
+
+func head(cmd *cobra.Command, args []string) {
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	var data []byte
+	if bytes > 0 {
+		data, err = client.ReadRange(args[0], 0, bytes)
+	} else {
+		data, err = client.Head(args[0], lines)
+	}
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	os.Stdout.Write(data)
+}
+
+func New() *cobra.Command {
+	return headCmd
+}
+
+func init() {
+	headCmd.Flags().IntVarP(&lines, "lines", "n", 10, "number of lines to print")
+	headCmd.Flags().Int64VarP(&bytes, "bytes", "b", 0, "print the first <bytes> bytes instead of lines if positive")
+	headCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+}
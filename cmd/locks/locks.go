@@ -0,0 +1,40 @@
+package locks
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+
+var locksCmd = &cobra.Command{
+	Use:     "locks",
+	Short:   "list the locks held on files and the clients holding them",
+	Long:    `list the locks held on files and the clients holding them`,
+	Example: `  sdfs locks`,
+	Args:    cobra.NoArgs,
+	Run:     locks,
+}
+
+func locks(cmd *cobra.Command, args []string) {
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	re, err := client.ListLocks()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	fmt.Printf("locks:\n%s", re)
+}
+
+func New() *cobra.Command {
+	return locksCmd
+}
+
+func init() {
+	locksCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+}
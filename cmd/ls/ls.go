@@ -11,11 +11,11 @@ import (
 var configPath string
 
 var lsCmd = &cobra.Command{
-	Use:     "ls [sdfsfilename]",
-	Short:   "list all machine (VM) addresses where this file is currently being stored",
-	Long:    `list all machine (VM) addresses where this file is currently being stored`,
-	Example: `  sdfs ls sdfs_test`,
-	Args:    cobra.ExactArgs(1),
+	Use:     "ls [sdfsfilename or directory]",
+	Short:   "list all machine (VM) addresses where this file is currently being stored, or the files in a directory",
+	Long:    `list all machine (VM) addresses where this file is currently being stored, or the files and directories in a directory (the root directory by default)`,
+	Example: `  sdfs ls /outputs`,
+	Args:    cobra.MaximumNArgs(1),
 	Run:     ls,
 }
 
@@ -24,11 +24,15 @@ func ls(cmd *cobra.Command, args []string) {
 	if err != nil {
 		logrus.Fatal(err)
 	}
-	re, err := client.LsFile(args[0])
+	name := "/"
+	if len(args) == 1 {
+		name = args[0]
+	}
+	re, err := client.Ls(name)
 	if err != nil {
 		logrus.Fatal(err)
 	}
-	fmt.Printf("file %s's block location:\n%s\n", args[0], re)
+	fmt.Printf("%s\n", re)
 }
 
 func New() *cobra.Command {
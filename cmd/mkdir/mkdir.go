@@ -0,0 +1,37 @@
+package mkdir
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+
+var mkdirCmd = &cobra.Command{
+	Use:     "mkdir [directory]",
+	Short:   "create a directory in SDFS",
+	Long:    `create a directory and its parents in SDFS`,
+	Example: `  sdfs mkdir /outputs/wordcount`,
+	Args:    cobra.ExactArgs(1),
+	Run:     mkdir,
+}
+
+func mkdir(cmd *cobra.Command, args []string) {
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	err = client.Mkdir(args[0])
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func New() *cobra.Command {
+	return mkdirCmd
+}
+
+func init() {
+	mkdirCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+}
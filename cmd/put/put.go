@@ -8,6 +8,10 @@ import (
 
 var configPath string
 var retry int
+var maxVersions int
+var replication int
+var policy string
+var compression string
 
 var putCmd = &cobra.Command{
 	Use:     "put [localfilename] [sdfsfilename]",
@@ -19,15 +23,16 @@ var putCmd = &cobra.Command{
 }
 
 func put(cmd *cobra.Command, args []string) {
+	options := client.PutOptions{MaxVersions: maxVersions, Replication: replication, Policy: policy, Compression: compression}
 	client, err := client.NewClient(configPath)
 	if err != nil {
 		logrus.Fatal(err)
 	}
 	switch retry {
 	case 0:
-		err = client.PutFile(args[0], args[1])
+		err = client.PutFileWithOptions(args[0], args[1], options)
 	default:
-		err = client.PutFileWithRetry(args[0], args[1])
+		err = client.PutFileWithOptionsAndRetry(args[0], args[1], options)
 	}
 	if err != nil {
 		logrus.Fatal(err)
@@ -40,5 +45,9 @@ func New() *cobra.Command {
 
 func init() {
 	putCmd.Flags().IntVarP(&retry, "retry", "r", 1, "retry or not")
+	putCmd.Flags().IntVar(&maxVersions, "versions", 0, "number of versions kept of the file, unchanged or the default in config if 0")
+	putCmd.Flags().IntVar(&replication, "replication", 0, "number of replicas of each block of the file, unchanged or the default in config if 0")
+	putCmd.Flags().StringVar(&policy, "policy", "", "storage policy of the file, replication or RS-<data shards>-<parity shards> e.g. RS-6-3, unchanged if empty")
+	putCmd.Flags().StringVar(&compression, "compression", "", "codec of the blocks of the file, none, snappy, zstd or gzip, unchanged if empty")
 	putCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
 }
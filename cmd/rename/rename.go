@@ -0,0 +1,37 @@
+package rename
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+
+var renameCmd = &cobra.Command{
+	Use:     "rename [sdfsname] [newsdfsname]",
+	Short:   "atomically move a file or directory in SDFS",
+	Long:    `atomically move a file or directory in SDFS, an existing file or empty directory at the new path is replaced`,
+	Example: `  sdfs rename /outputs/wordcount.temp /outputs/wordcount`,
+	Args:    cobra.ExactArgs(2),
+	Run:     rename,
+}
+
+func rename(cmd *cobra.Command, args []string) {
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	err = client.Rename(args[0], args[1])
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func New() *cobra.Command {
+	return renameCmd
+}
+
+func init() {
+	renameCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+}
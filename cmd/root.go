@@ -3,25 +3,38 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/append"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/balance"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/cat"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/certs"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/config"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/delete"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/disable"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/enable"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/fail"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/gateway"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/get"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/get_versions"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/head"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/join"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/juice"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/leave"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/list_mem"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/list_self"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/locks"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/ls"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/maple"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/metadata"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/mkdir"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/multiread"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/multiwrite"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/put"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/rename"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/scrub"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/serve"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/setpolicy"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/setrep"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/store"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/cmd/tail"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/logger"
 )
 
@@ -40,7 +53,7 @@ func Execute() error {
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&logPath, "log", "l", "logs/sdfs.log", "path to log file")
 
-	rootCmd.AddCommand(serve.New(), get.New(), put.New(), ls.New(), store.New(), metadata.New(), delete.New(), multiread.New(), multiwrite.New(), append.New())
-	rootCmd.AddCommand(join.New(), leave.New(), fail.New(), config.New(), list_mem.New(), list_self.New(), enable.New(), disable.New())
+	rootCmd.AddCommand(serve.New(), get.New(), put.New(), ls.New(), store.New(), metadata.New(), delete.New(), multiread.New(), multiwrite.New(), append.New(), locks.New(), scrub.New(), balance.New(), mkdir.New(), rename.New(), get_versions.New(), setrep.New(), setpolicy.New(), cat.New(), head.New(), tail.New(), gateway.New())
+	rootCmd.AddCommand(join.New(), leave.New(), fail.New(), config.New(), list_mem.New(), list_self.New(), enable.New(), disable.New(), certs.New())
 	rootCmd.AddCommand(maple.New(), juice.New())
 }
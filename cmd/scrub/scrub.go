@@ -0,0 +1,55 @@
+package scrub
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+var machineRegex string
+
+var scrubCmd = &cobra.Command{
+	Use:     "scrub",
+	Short:   "show the block scrub progress and findings of the data servers",
+	Long:    `show the progress of verifying block checksums in the background and the corrupted or unexpected blocks found on the data servers`,
+	Example: `  sdfs scrub -m "0[1-3]"`,
+	Args:    cobra.NoArgs,
+	Run:     scrub,
+}
+
+func scrub(cmd *cobra.Command, args []string) {
+	conf, err := config.NewConfig(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	machines, err := conf.FilterMachines(machineRegex)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	hostnames := []string{}
+	for _, machine := range machines {
+		hostnames = append(hostnames, machine.Hostname)
+	}
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	re, err := client.ScrubStatus(hostnames)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	fmt.Printf("scrub status:\n%s", re)
+}
+
+func New() *cobra.Command {
+	return scrubCmd
+}
+
+func init() {
+	scrubCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+	scrubCmd.PersistentFlags().StringVarP(&machineRegex, "machine-regex", "m", ".*", "regex for machines to show (e.g. \"0[1-9]\")")
+}
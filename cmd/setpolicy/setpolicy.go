@@ -0,0 +1,37 @@
+package setpolicy
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+
+var setpolicyCmd = &cobra.Command{
+	Use:     "setpolicy [sdfsfilename] [policy]",
+	Short:   "set the storage policy of a file in SDFS",
+	Long:    `set the storage policy of a file in SDFS, replication or RS-<data shards>-<parity shards>, the file is written again as a new version with the policy`,
+	Example: `  sdfs setpolicy sdfs_test RS-6-3`,
+	Args:    cobra.ExactArgs(2),
+	Run:     setpolicy,
+}
+
+func setpolicy(cmd *cobra.Command, args []string) {
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	err = client.SetPolicy(args[0], args[1])
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func New() *cobra.Command {
+	return setpolicyCmd
+}
+
+func init() {
+	setpolicyCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+}
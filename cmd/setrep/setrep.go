@@ -0,0 +1,43 @@
+package setrep
+
+import (
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+
+var setrepCmd = &cobra.Command{
+	Use:     "setrep [sdfsfilename] [replication]",
+	Short:   "set the number of replicas of a file in SDFS",
+	Long:    `set the number of replicas of each block of a file in SDFS, the leader adds or removes the replicas in the background`,
+	Example: `  sdfs setrep sdfs_test 2`,
+	Args:    cobra.ExactArgs(2),
+	Run:     setrep,
+}
+
+func setrep(cmd *cobra.Command, args []string) {
+	replication, err := strconv.Atoi(args[1])
+	if err != nil {
+		logrus.Fatalf("invalid replication %s: %v", args[1], err)
+	}
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	err = client.SetReplication(args[0], replication)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func New() *cobra.Command {
+	return setrepCmd
+}
+
+func init() {
+	setrepCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+}
@@ -0,0 +1,49 @@
+package tail
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+var configPath string
+var lines int
+var bytes int64
+
+var tailCmd = &cobra.Command{
+	Use:     "tail [sdfsfilename]",
+	Short:   "print the last lines of a file in SDFS",
+	Long:    `print the last lines or bytes of a file in SDFS, only the blocks holding them are fetched from the data servers`,
+	Example: `  sdfs tail sdfs_test.log -n 20`,
+	Args:    cobra.ExactArgs(1),
+	Run:     tail,
+}
+
+func tail(cmd *cobra.Command, args []string) {
+	client, err := client.NewClient(configPath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	var data []byte
+	if bytes > 0 {
+		data, err = client.ReadRange(args[0], -bytes, -1)
+	} else {
+		data, err = client.Tail(args[0], lines)
+	}
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	os.Stdout.Write(data)
+}
+
+func New() *cobra.Command {
+	return tailCmd
+}
+
+func init() {
+	tailCmd.Flags().IntVarP(&lines, "lines", "n", 10, "number of lines to print")
+	tailCmd.Flags().Int64VarP(&bytes, "bytes", "b", 0, "print the last <bytes> bytes instead of lines if positive")
+	tailCmd.PersistentFlags().StringVarP(&configPath, "config", "c", ".sdfs/config.yml", "path to config file")
+}
@@ -0,0 +1,260 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// NodeUnit and UserUnit are the organizational units of the certificates of the nodes and the users.
+const (
+	NodeUnit = "node"
+	UserUnit = "user"
+)
+
+// callerKey is the gRPC metadata key of the identity a node calls for.
+const callerKey = "sdfs-caller"
+
+var (
+	mu        sync.Mutex
+	loaded    bool
+	clientTLS *tls.Config // nil if TLS is disabled
+	serverTLS *tls.Config // nil if TLS is disabled
+)
+
+// Init loads the CA and the certificate of name for the gRPC servers and clients of this process. A node loads the
+// certificate of its hostname before creating any client, otherwise the one of the user in config is loaded on the
+// first Dial. Only the first call loads the certificate.
+func Init(conf config.TLS, name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	return load(conf, name)
+}
+
+// load loads the certificate of name unless one is loaded, the caller must hold the lock.
+func load(conf config.TLS, name string) error {
+	if loaded {
+		return nil
+	}
+	if !conf.Enabled {
+		loaded = true
+		return nil
+	}
+	caPEM, err := os.ReadFile(filepath.Join(conf.Dir, CAFile))
+	if err != nil {
+		return fmt.Errorf("failed to read CA certificate: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("invalid CA certificate in %s", conf.Dir)
+	}
+	certFile, keyFile := CertFiles(conf.Dir, name)
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate of %s: %v", name, err)
+	}
+	clientTLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}
+	serverTLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	loaded = true
+	logrus.Infof("Loaded certificate of %s from %s", name, conf.Dir)
+	return nil
+}
+
+// UserName returns the identity of the commands, the user in config or the login user.
+func UserName(conf config.TLS) (string, error) {
+	if conf.User != "" {
+		return conf.User, nil
+	}
+	u, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current user: %v", err)
+	}
+	return u.Username, nil
+}
+
+// Dial connects to a gRPC server with the certificate of this process, or without TLS if it is disabled.
+func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	creds, err := clientCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return grpc.Dial(target, append(opts, grpc.WithTransportCredentials(creds))...)
+}
+
+// clientCredentials returns the credentials to dial, the certificate of the user in config is loaded if none is.
+func clientCredentials() (credentials.TransportCredentials, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !loaded {
+		conf, err := config.GetInstance()
+		if err != nil {
+			return nil, err
+		}
+		name, err := UserName(conf.TLS)
+		if err != nil {
+			return nil, err
+		}
+		if err := load(conf.TLS, name); err != nil {
+			return nil, err
+		}
+	}
+	if clientTLS == nil {
+		return insecure.NewCredentials(), nil
+	}
+	return credentials.NewTLS(clientTLS), nil
+}
+
+// keepalivePolicy permits the keepalive pings of the pooled connections, which are sent while no call is in flight.
+var keepalivePolicy = keepalive.EnforcementPolicy{
+	MinTime:             time.Second * 10,
+	PermitWithoutStream: true,
+}
+
+// NewServer creates a gRPC server which requires a certificate signed by the CA of the cluster from each caller if TLS
+// is enabled. Each call is logged with the identity of the caller, and the methods in nodeOnly are denied to users.
+func NewServer(nodeOnly ...string) (*grpc.Server, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !loaded {
+		return nil, fmt.Errorf("certificate is not loaded")
+	}
+	if serverTLS == nil {
+		return grpc.NewServer(grpc.KeepaliveEnforcementPolicy(keepalivePolicy)), nil
+	}
+	restricted := map[string]bool{}
+	for _, method := range nodeOnly {
+		restricted[method] = true
+	}
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, info.FullMethod, restricted); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), info.FullMethod, restricted); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+	return grpc.NewServer(
+		grpc.KeepaliveEnforcementPolicy(keepalivePolicy),
+		grpc.Creds(credentials.NewTLS(serverTLS)),
+		grpc.UnaryInterceptor(unary),
+		grpc.StreamInterceptor(stream),
+	), nil
+}
+
+// Method returns the full name of a method of a gRPC service.
+func Method(service grpc.ServiceDesc, name string) string {
+	return "/" + service.ServiceName + "/" + name
+}
+
+// authorize records the caller of a method and denies the methods restricted to the nodes to the users.
+func authorize(ctx context.Context, method string, restricted map[string]bool) error {
+	unit, name := Identity(ctx)
+	if forwarded := forwardedCaller(ctx); unit == NodeUnit && forwarded != "" {
+		logrus.Infof("%s %s called %s for %s", unit, name, method, forwarded)
+	} else if unit != NodeUnit {
+		logrus.Infof("%s %s called %s", unit, name, method)
+	} else {
+		logrus.Debugf("%s %s called %s", unit, name, method)
+	}
+	if restricted[method] && unit != NodeUnit {
+		return status.Errorf(codes.PermissionDenied, "%s %s is not allowed to call %s", unit, name, method)
+	}
+	return nil
+}
+
+// Identity returns the organizational unit and the common name of the certificate of the caller of a gRPC call, or
+// its address if TLS is disabled.
+func Identity(ctx context.Context) (string, string) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", "unknown"
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", p.Addr.String()
+	}
+	cert := info.State.VerifiedChains[0][0]
+	unit := ""
+	if len(cert.Subject.OrganizationalUnit) > 0 {
+		unit = cert.Subject.OrganizationalUnit[0]
+	}
+	return unit, cert.Subject.CommonName
+}
+
+// Caller returns the identity a gRPC call is made for, "<unit>/<name>" of the certificate of the caller, or the address
+// of the caller if TLS is disabled. A node calling for a user forwards the identity of the user, which is trusted from
+// the nodes only.
+func Caller(ctx context.Context) string {
+	unit, name := Identity(ctx)
+	if forwarded := forwardedCaller(ctx); forwarded != "" && (unit == NodeUnit || !secure(ctx)) {
+		return forwarded
+	}
+	if unit == "" {
+		return name
+	}
+	return unit + "/" + name
+}
+
+// IsNode returns whether a gRPC call is made by a node for itself rather than for a user. No caller is known to be a
+// node if TLS is disabled.
+func IsNode(ctx context.Context) bool {
+	unit, _ := Identity(ctx)
+	return secure(ctx) && unit == NodeUnit && forwardedCaller(ctx) == ""
+}
+
+// WithCaller returns a context whose gRPC calls are made for caller, nothing is forwarded if caller is empty.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	if caller == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, callerKey, caller)
+}
+
+// forwardedCaller returns the identity forwarded with a gRPC call, or an empty string.
+func forwardedCaller(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(callerKey)) == 0 {
+		return ""
+	}
+	return md.Get(callerKey)[0]
+}
+
+// secure returns whether a gRPC call is made over TLS.
+func secure(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	_, ok = p.AuthInfo.(credentials.TLSInfo)
+	return ok
+}
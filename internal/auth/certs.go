@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CAFile is the certificate of the CA of the cluster, its key is in ca.key.
+const CAFile = "ca.crt"
+
+const caKeyFile = "ca.key"
+
+// CertFiles returns the paths of the certificate and the key of a node or a user in dir.
+func CertFiles(dir, name string) (string, string) {
+	return filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+}
+
+// InitCerts creates the CA of the cluster in dir unless it exists, and signs a certificate valid for validity for each
+// node and user. The certificates of the nodes are also valid for localhost, where the commands find the leader.
+func InitCerts(dir string, nodes []string, users []string, validity time.Duration) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create certs dir %s: %v", dir, err)
+	}
+	ca, caKey, err := loadOrCreateCA(dir, validity)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		template := newTemplate(node, NodeUnit, validity)
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+		template.DNSNames = []string{node, "localhost"}
+		template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+		if err := issue(dir, node, template, ca, caKey); err != nil {
+			return err
+		}
+	}
+	for _, user := range users {
+		template := newTemplate(user, UserUnit, validity)
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+		if err := issue(dir, user, template, ca, caKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadOrCreateCA loads the CA in dir, or creates one if there is none.
+func loadOrCreateCA(dir string, validity time.Duration) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certFile, keyFile := filepath.Join(dir, CAFile), filepath.Join(dir, caKeyFile)
+	if _, err := os.Stat(certFile); err == nil {
+		ca, err := readCert(certFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		key, err := readKey(keyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		logrus.Infof("Using CA in %s", certFile)
+		return ca, key, nil
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA key: %v", err)
+	}
+	template := newTemplate("sdfs-ca", "ca", validity)
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create CA certificate: %v", err)
+	}
+	if err := writeCertAndKey(certFile, keyFile, der, key); err != nil {
+		return nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	logrus.Infof("Created CA in %s", certFile)
+	return ca, key, nil
+}
+
+// issue signs a certificate of name with the CA and writes it with its key to dir.
+func issue(dir, name string, template *x509.Certificate, ca *x509.Certificate, caKey *ecdsa.PrivateKey) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate key of %s: %v", name, err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return fmt.Errorf("failed to create certificate of %s: %v", name, err)
+	}
+	certFile, keyFile := CertFiles(dir, name)
+	if err := writeCertAndKey(certFile, keyFile, der, key); err != nil {
+		return err
+	}
+	logrus.Infof("Created certificate of %s %s in %s", template.Subject.OrganizationalUnit[0], name, certFile)
+	return nil
+}
+
+// newTemplate returns a certificate template of a common name in an organizational unit.
+func newTemplate(commonName, unit string, validity time.Duration) *x509.Certificate {
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization:       []string{"sdfs"},
+			OrganizationalUnit: []string{unit},
+			CommonName:         commonName,
+		},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(validity),
+		KeyUsage:  x509.KeyUsageDigitalSignature,
+	}
+}
+
+// writeCertAndKey writes a certificate and its key in PEM, the key is only readable by the owner.
+func writeCertAndKey(certFile, keyFile string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		return fmt.Errorf("failed to write certificate %s: %v", certFile, err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return fmt.Errorf("failed to write key %s: %v", keyFile, err)
+	}
+	return nil
+}
+
+// readCert reads a certificate in PEM.
+func readCert(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no certificate in %s", path)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// readKey reads an EC private key in PEM.
+func readKey(path string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no key in %s", path)
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
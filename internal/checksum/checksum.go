@@ -0,0 +1,72 @@
+package checksum
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// BytesPerChecksum is the size of the data covered by one checksum, the last piece of a block may be shorter.
+const BytesPerChecksum = 64 * 1024
+
+// ErrMismatch is returned if the data does not match its checksums.
+var ErrMismatch = errors.New("checksum mismatch")
+
+var table = crc32.MakeTable(crc32.Castagnoli)
+
+// Count returns the number of checksums of size bytes of data.
+func Count(size int64) int {
+	return int((size + BytesPerChecksum - 1) / BytesPerChecksum)
+}
+
+// Compute returns the CRC32C checksum of every BytesPerChecksum bytes of data.
+func Compute(data []byte) []uint32 {
+	checksums := make([]uint32, 0, Count(int64(len(data))))
+	for offset := 0; offset < len(data); offset += BytesPerChecksum {
+		end := offset + BytesPerChecksum
+		if end > len(data) {
+			end = len(data)
+		}
+		checksums = append(checksums, crc32.Checksum(data[offset:end], table))
+	}
+	return checksums
+}
+
+// Verify checks data against its checksums, the first checksum covers the beginning of data.
+func Verify(data []byte, checksums []uint32) error {
+	if len(checksums) != Count(int64(len(data))) {
+		return fmt.Errorf("%w: %d checksums for %d bytes", ErrMismatch, len(checksums), len(data))
+	}
+	for i, sum := range Compute(data) {
+		if sum != checksums[i] {
+			return fmt.Errorf("%w at offset %d", ErrMismatch, i*BytesPerChecksum)
+		}
+	}
+	return nil
+}
+
+// Encode returns the checksums in the format of the checksum file stored next to a block.
+func Encode(checksums []uint32) []byte {
+	data := make([]byte, 4+4*len(checksums))
+	binary.BigEndian.PutUint32(data, BytesPerChecksum)
+	for i, sum := range checksums {
+		binary.BigEndian.PutUint32(data[4+4*i:], sum)
+	}
+	return data
+}
+
+// Decode parses the checksums from a checksum file.
+func Decode(data []byte) ([]uint32, error) {
+	if len(data) < 4 || len(data)%4 != 0 {
+		return nil, fmt.Errorf("invalid checksum file of size %d", len(data))
+	}
+	if bytesPerChecksum := binary.BigEndian.Uint32(data); bytesPerChecksum != BytesPerChecksum {
+		return nil, fmt.Errorf("unsupported bytes per checksum %d", bytesPerChecksum)
+	}
+	checksums := make([]uint32, 0, len(data)/4-1)
+	for offset := 4; offset < len(data); offset += 4 {
+		checksums = append(checksums, binary.BigEndian.Uint32(data[offset:]))
+	}
+	return checksums, nil
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"path/filepath"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/command/proto"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // CommandClient handles commands to sdfsclient.
@@ -28,7 +31,7 @@ func NewCommandClient(hostname, port string) *CommandClient {
 
 // ExecuteCommand executes a command through gRPC.
 func (c *CommandClient) ExecuteCommand(command string, args []string) (string, error) {
-	conn, err := grpc.Dial(c.hostname+":"+c.port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := connpool.Get(c.hostname + ":" + c.port)
 	if err != nil {
 		logrus.Fatal(fmt.Errorf("cannot dial command server %s: %v", c.hostname, err))
 	}
@@ -69,7 +72,11 @@ func (s *CommandServer) Run() {
 		return
 	}
 	defer listen.Close()
-	grpcServer := grpc.NewServer()
+	grpcServer, err := auth.NewServer()
+	if err != nil {
+		logrus.Fatalf("failed to create gRPC server: %v", err)
+		return
+	}
 	pb.RegisterCommandServer(grpcServer, s)
 	logrus.Infof("CommandServer listening on port %s", s.port)
 	if err := grpcServer.Serve(listen); err != nil {
@@ -79,9 +86,15 @@ func (s *CommandServer) Run() {
 	return
 }
 
-// ExecuteCommand executes a command though gRPC.
+// ExecuteCommand executes a command though gRPC. The command is run for the caller, and a caller other than a node can
+// only read and write the local files under the working directory of the server.
 func (s *CommandServer) ExecuteCommand(ctx context.Context, in *pb.ExecuteCommandRequest) (*pb.ExecuteCommandReply, error) {
-	output, err := s.executeCommand(in.Command, in.Args)
+	if !auth.IsNode(ctx) {
+		if err := checkLocalPaths(in.Command, in.Args); err != nil {
+			return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed to run %s: %v", auth.Caller(ctx), in.Command, err)
+		}
+	}
+	output, err := s.executeCommand(auth.Caller(ctx), in.Command, in.Args)
 	if err != nil {
 		return nil, err
 	}
@@ -90,9 +103,22 @@ func (s *CommandServer) ExecuteCommand(ctx context.Context, in *pb.ExecuteComman
 	}, nil
 }
 
-// ExecuteCommand executes a command.
-func (s *CommandServer) executeCommand(command string, args []string) (string, error) {
-	sdfsClient, err := client.NewClient(s.configPath)
+// checkLocalPaths checks that the local files of a command are under the working directory.
+func checkLocalPaths(command string, args []string) error {
+	localArg := map[string]int{"put": 0, "get": 1}
+	i, ok := localArg[command]
+	if !ok || i >= len(args) {
+		return nil
+	}
+	if !filepath.IsLocal(args[i]) {
+		return fmt.Errorf("local file %s is not under the working directory", args[i])
+	}
+	return nil
+}
+
+// executeCommand executes a command for caller.
+func (s *CommandServer) executeCommand(caller string, command string, args []string) (string, error) {
+	sdfsClient, err := client.NewClientAs(s.configPath, caller)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,205 @@
+package compress
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec is the compression of the blocks of a file.
+type Codec string
+
+const (
+	None   Codec = "none"
+	Snappy Codec = "snappy"
+	Zstd   Codec = "zstd"
+	Gzip   Codec = "gzip"
+)
+
+// FrameSize is the max size of the data compressed into one frame, a range of a block is read by its frames.
+const FrameSize = 256 * 1024
+
+// HeaderSize is the size of the header of a frame, the size of the compressed data and the size of the data as uint32.
+const HeaderSize = 8
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// Parse parses the name of a codec, one of none, snappy, zstd or gzip. An empty codec is none.
+func Parse(name string) (Codec, error) {
+	switch Codec(name) {
+	case "", None:
+		return None, nil
+	case Snappy, Zstd, Gzip:
+		return Codec(name), nil
+	}
+	return None, fmt.Errorf("invalid compression %s, expected none, snappy, zstd or gzip", name)
+}
+
+// String returns the name of the codec, "" for none so that it is omitted in metadata.
+func (c Codec) String() string {
+	if !c.IsCompressed() {
+		return ""
+	}
+	return string(c)
+}
+
+// IsCompressed returns whether the blocks are stored as compressed frames.
+func (c Codec) IsCompressed() bool {
+	return c != None && c != ""
+}
+
+// Frame is a frame of a compressed block.
+type Frame struct {
+	Offset     int64 // offset of the header in the block
+	StoredSize int64 // size of the frame in the block including the header
+	Start      int64 // offset of the data of the frame in the data of the block
+	Size       int64 // size of the data of the frame
+}
+
+// Encode compresses data into frames of at most FrameSize bytes of data each, which are stored as the block.
+func (c Codec) Encode(data []byte) ([]byte, error) {
+	if !c.IsCompressed() {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	for offset := 0; offset < len(data); offset += FrameSize {
+		end := offset + FrameSize
+		if end > len(data) {
+			end = len(data)
+		}
+		compressed, err := c.compress(data[offset:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress with %s: %v", c, err)
+		}
+		header := make([]byte, HeaderSize)
+		binary.BigEndian.PutUint32(header, uint32(len(compressed)))
+		binary.BigEndian.PutUint32(header[4:], uint32(end-offset))
+		buf.Write(header)
+		buf.Write(compressed)
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode decompresses whole frames of a block.
+func (c Codec) Decode(frames []byte) ([]byte, error) {
+	if !c.IsCompressed() {
+		return frames, nil
+	}
+	data := []byte{}
+	for len(frames) > 0 {
+		storedSize, size, err := parseHeader(frames)
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(frames)) < storedSize {
+			return nil, fmt.Errorf("truncated frame of %d bytes, only %d bytes left", storedSize, len(frames))
+		}
+		decompressed, err := c.decompress(frames[HeaderSize:storedSize], int(size))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress with %s: %v", c, err)
+		}
+		if int64(len(decompressed)) != size {
+			return nil, fmt.Errorf("frame is decompressed to %d bytes, expected %d", len(decompressed), size)
+		}
+		data = append(data, decompressed...)
+		frames = frames[storedSize:]
+	}
+	return data, nil
+}
+
+// ReadFrames returns the frames of a compressed block of size bytes by their headers.
+func ReadFrames(r io.ReaderAt, size int64) ([]Frame, error) {
+	frames := []Frame{}
+	header := make([]byte, HeaderSize)
+	start := int64(0)
+	for offset := int64(0); offset < size; {
+		if _, err := r.ReadAt(header, offset); err != nil {
+			return nil, fmt.Errorf("failed to read header of frame at %d: %v", offset, err)
+		}
+		storedSize, frameSize, err := parseHeader(header)
+		if err != nil {
+			return nil, fmt.Errorf("invalid frame at %d: %v", offset, err)
+		}
+		if offset+storedSize > size {
+			return nil, fmt.Errorf("frame at %d of %d bytes is beyond the block of %d bytes", offset, storedSize, size)
+		}
+		frames = append(frames, Frame{Offset: offset, StoredSize: storedSize, Start: start, Size: frameSize})
+		offset += storedSize
+		start += frameSize
+	}
+	return frames, nil
+}
+
+// Span returns the frames which hold length bytes at offset of the data of a block, to the end of the block if length
+// is 0.
+func Span(frames []Frame, offset, length int64) []Frame {
+	span := []Frame{}
+	for _, frame := range frames {
+		if frame.Start+frame.Size <= offset {
+			continue
+		}
+		if length > 0 && frame.Start >= offset+length {
+			break
+		}
+		span = append(span, frame)
+	}
+	return span
+}
+
+// parseHeader returns the size of a frame including its header and the size of its data.
+func parseHeader(frame []byte) (int64, int64, error) {
+	if len(frame) < HeaderSize {
+		return 0, 0, fmt.Errorf("truncated header of %d bytes", len(frame))
+	}
+	storedSize := int64(binary.BigEndian.Uint32(frame)) + HeaderSize
+	size := int64(binary.BigEndian.Uint32(frame[4:]))
+	if size > FrameSize {
+		return 0, 0, fmt.Errorf("frame of %d bytes is larger than %d bytes", size, FrameSize)
+	}
+	return storedSize, size, nil
+}
+
+func (c Codec) compress(data []byte) ([]byte, error) {
+	switch c {
+	case Snappy:
+		return snappy.Encode(nil, data), nil
+	case Zstd:
+		return zstdEncoder.EncodeAll(data, nil), nil
+	case Gzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unknown compression %s", c)
+}
+
+func (c Codec) decompress(data []byte, size int) ([]byte, error) {
+	switch c {
+	case Snappy:
+		return snappy.Decode(nil, data)
+	case Zstd:
+		return zstdDecoder.DecodeAll(data, make([]byte, 0, size))
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	}
+	return nil, fmt.Errorf("unknown compression %s", c)
+}
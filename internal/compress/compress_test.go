@@ -0,0 +1,182 @@
+package compress
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+var codecs = []Codec{Snappy, Zstd, Gzip}
+
+// testData returns size bytes of half random and half repeated data, so that the frames are compressed.
+func testData(size int) []byte {
+	r := rand.New(rand.NewSource(int64(size)))
+	data := make([]byte, size)
+	r.Read(data[:size/2])
+	for i := size / 2; i < size; i++ {
+		data[i] = byte(i % 7)
+	}
+	return data
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Codec
+		wantErr bool
+	}{
+		{"", None, false},
+		{"none", None, false},
+		{"snappy", Snappy, false},
+		{"zstd", Zstd, false},
+		{"gzip", Gzip, false},
+		{"lz4", None, true},
+	}
+	for _, test := range tests {
+		got, err := Parse(test.name)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("Parse(%q) = %v, %v, want %v with error %v", test.name, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	sizes := []int{0, 1, FrameSize - 1, FrameSize, FrameSize + 1, FrameSize*2 + FrameSize/2}
+	for _, codec := range append(codecs, None) {
+		for _, size := range sizes {
+			data := testData(size)
+			encoded, err := codec.Encode(data)
+			if err != nil {
+				t.Fatalf("%s: Encode of %d bytes failed: %v", codec, size, err)
+			}
+			decoded, err := codec.Decode(encoded)
+			if err != nil {
+				t.Fatalf("%s: Decode of %d bytes failed: %v", codec, size, err)
+			}
+			if !bytes.Equal(decoded, data) {
+				t.Errorf("%s: Decode of %d bytes does not match the data", codec, size)
+			}
+		}
+	}
+}
+
+func TestReadFrames(t *testing.T) {
+	data := testData(FrameSize*2 + 100)
+	for _, codec := range codecs {
+		encoded, err := codec.Encode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames, err := ReadFrames(bytes.NewReader(encoded), int64(len(encoded)))
+		if err != nil {
+			t.Fatalf("%s: ReadFrames failed: %v", codec, err)
+		}
+		wantSizes := []int64{FrameSize, FrameSize, 100}
+		if len(frames) != len(wantSizes) {
+			t.Fatalf("%s: ReadFrames returned %d frames, want %d", codec, len(frames), len(wantSizes))
+		}
+		var offset, start int64
+		for i, frame := range frames {
+			if frame.Offset != offset || frame.Start != start || frame.Size != wantSizes[i] {
+				t.Errorf("%s: frame %d = %+v, want offset %d, start %d and size %d", codec, i, frame, offset, start, wantSizes[i])
+			}
+			offset += frame.StoredSize
+			start += frame.Size
+		}
+		if offset != int64(len(encoded)) {
+			t.Errorf("%s: frames end at %d, want %d", codec, offset, len(encoded))
+		}
+	}
+}
+
+func TestSpan(t *testing.T) {
+	data := testData(FrameSize*3 + 100)
+	tests := []struct {
+		offset, length int64
+		want           []int // indexes of the frames
+	}{
+		{0, 0, []int{0, 1, 2, 3}},
+		{0, 1, []int{0}},
+		{0, FrameSize, []int{0}},
+		{FrameSize - 1, 2, []int{0, 1}},
+		{FrameSize, 1, []int{1}},
+		{FrameSize, FrameSize, []int{1}},
+		{FrameSize + 1, FrameSize, []int{1, 2}},
+		{FrameSize / 2, FrameSize * 2, []int{0, 1, 2}},
+		{FrameSize * 2, 0, []int{2, 3}},
+		{FrameSize*3 + 99, 1, []int{3}},
+		{FrameSize*3 + 100, 0, []int{}},
+	}
+	for _, codec := range codecs {
+		encoded, err := codec.Encode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames, err := ReadFrames(bytes.NewReader(encoded), int64(len(encoded)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, test := range tests {
+			span := Span(frames, test.offset, test.length)
+			got := []int{}
+			for _, frame := range span {
+				for i := range frames {
+					if frames[i] == frame {
+						got = append(got, i)
+					}
+				}
+			}
+			if len(got) != len(test.want) {
+				t.Errorf("%s: Span(%d, %d) = frames %v, want %v", codec, test.offset, test.length, got, test.want)
+				continue
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("%s: Span(%d, %d) = frames %v, want %v", codec, test.offset, test.length, got, test.want)
+					break
+				}
+			}
+			if len(span) == 0 {
+				continue
+			}
+			// the range is in the data of the frames of the span
+			first, last := span[0], span[len(span)-1]
+			decoded, err := codec.Decode(encoded[first.Offset : last.Offset+last.StoredSize])
+			if err != nil {
+				t.Fatalf("%s: Decode of Span(%d, %d) failed: %v", codec, test.offset, test.length, err)
+			}
+			end := int64(len(data))
+			if test.length > 0 {
+				end = test.offset + test.length
+			}
+			if !bytes.Equal(decoded[test.offset-first.Start:end-first.Start], data[test.offset:end]) {
+				t.Errorf("%s: range %d+%d read from Span does not match the data", codec, test.offset, test.length)
+			}
+		}
+	}
+}
+
+func TestTruncatedFrame(t *testing.T) {
+	data := testData(FrameSize + 100)
+	for _, codec := range codecs {
+		encoded, err := codec.Encode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames, err := ReadFrames(bytes.NewReader(encoded), int64(len(encoded)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		second := frames[1].Offset
+		// in the header of the second frame, in its data, and at the header of the first frame
+		for _, size := range []int64{second + HeaderSize/2, second + HeaderSize + 1, HeaderSize - 1} {
+			truncated := encoded[:size]
+			if _, err := codec.Decode(truncated); err == nil {
+				t.Errorf("%s: Decode of %d of %d bytes succeeded, want an error", codec, size, len(encoded))
+			}
+			if _, err := ReadFrames(bytes.NewReader(truncated), size); err == nil {
+				t.Errorf("%s: ReadFrames of %d of %d bytes succeeded, want an error", codec, size, len(encoded))
+			}
+		}
+	}
+}
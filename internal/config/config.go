@@ -20,17 +20,30 @@ type Config struct {
 	BlocksDir         string        `yaml:"blocks_dir"`
 	BlockSize         int64         `yaml:"block_size"`
 	RelicationFactor  int           `yaml:"replication_factor"`
+	PipelineWrite     bool          `yaml:"pipeline_write"` // the client sends a block to the first replica, which forwards it to the next replica
+	MaxVersions       int           `yaml:"max_versions"`   // keep the last <max_versions> versions of a file unless set for the file
+	Metadata          Metadata      `yaml:"metadata"`
+	Raft              Raft          `yaml:"raft"`
+	BlockReport       BlockReport   `yaml:"block_report"`
+	GC                GC            `yaml:"gc"`
+	Lock              Lock          `yaml:"lock"`
+	Scrub             Scrub         `yaml:"scrub"`
+	Placement         Placement     `yaml:"placement"`
+	Balancer          Balancer      `yaml:"balancer"`
+	HedgedRead        HedgedRead    `yaml:"hedged_read"`
 	Heartbeat         Heartbeat     `yaml:"heartbeat"`
 	FailureDetect     FailureDetect `yaml:"failure_detect"`
 	Cleanup           Cleanup       `yaml:"cleanup"`
 	Scheduler         Scheduler     `yaml:"scheduler"`
 	TaskManager       TaskManager   `yaml:"task_manager"`
+	TLS               TLS           `yaml:"tls"`
 }
 
 // Machine is the configuration for a single server
 type Machine struct {
 	Hostname string `yaml:"hostname"`
 	ID       string `yaml:"id"`
+	Zone     string `yaml:"zone"` // rack or zone of the machine, replicas of a block are spread across zones
 }
 
 type Heartbeat struct {
@@ -56,6 +69,55 @@ type Cleanup struct {
 	Timeout  time.Duration `yaml:"timeout"`  // remove from membership if left or failed for <timeout> millisecond
 }
 
+type Metadata struct {
+	Dir              string        `yaml:"dir"`               // directory of the metadata write-ahead log and snapshot
+	SnapshotInterval time.Duration `yaml:"snapshot_interval"` // compact the write-ahead log into a snapshot every <interval> millisecond
+}
+
+type Raft struct {
+	ElectionTimeout   time.Duration `yaml:"election_timeout"`   // start an election if no heartbeat from the leader for <timeout> to 2*<timeout> millisecond
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"` // leader sends heartbeat every <interval> millisecond
+}
+
+type BlockReport struct {
+	Interval time.Duration `yaml:"interval"` // send block report to the leader every <interval> millisecond
+}
+
+type GC struct {
+	Interval          time.Duration `yaml:"interval"`            // remove deleted blocks from the data servers every <interval> millisecond
+	OrphanGracePeriod time.Duration `yaml:"orphan_grace_period"` // remove orphaned blocks not modified for <period> millisecond
+}
+
+type Lock struct {
+	LeaseDuration time.Duration `yaml:"lease_duration"` // a file lock expires if not renewed by the client for <duration> millisecond
+}
+
+type Scrub struct {
+	Interval  time.Duration `yaml:"interval"`  // start verifying the checksums of all blocks every <interval> millisecond
+	Bandwidth int64         `yaml:"bandwidth"` // read at most <bandwidth> bytes per second while verifying
+}
+
+type Placement struct {
+	Policy       string  `yaml:"policy"`         // policy to choose the data servers of the replicas, "zone" (default) or "random"
+	MaxDiskUsage float64 `yaml:"max_disk_usage"` // do not place replicas on data servers with more than <max_disk_usage> of the disk used
+}
+
+type Balancer struct {
+	Bandwidth int64 `yaml:"bandwidth"` // move at most <bandwidth> bytes of blocks per second while balancing
+}
+
+type HedgedRead struct {
+	Enabled    bool          `yaml:"enabled"`    // request a block from another replica if it has not started arriving in time
+	Percentile float64       `yaml:"percentile"` // wait for the <percentile> of the recent latencies to the first chunk of a block, 95 if 0
+	MinDelay   time.Duration `yaml:"min_delay"`  // wait at least <delay> millisecond before requesting another replica
+}
+
+type TLS struct {
+	Enabled bool   `yaml:"enabled"` // require mutual TLS with certificates signed by the CA of the cluster on every gRPC service
+	Dir     string `yaml:"dir"`     // directory of ca.crt and the <name>.crt and <name>.key of each node and user
+	User    string `yaml:"user"`    // the commands use the certificate of <user>, the login user if empty
+}
+
 type Scheduler struct {
 	Hostname string `yaml:"hostname"`
 	Port     string `yaml:"port"`
@@ -0,0 +1,215 @@
+package connpool
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
+)
+
+// idleTimeout is how long a connection which is not used by any caller is kept open.
+const idleTimeout = time.Minute * 2
+
+// evictInterval is how often the idle connections are closed.
+const evictInterval = time.Second * 30
+
+// keepaliveParams pings the server over an idle connection so that a broken one is found before it is used, the servers
+// created by auth.NewServer permit the pings.
+var keepaliveParams = keepalive.ClientParameters{
+	Time:                time.Second * 30,
+	Timeout:             time.Second * 10,
+	PermitWithoutStream: true,
+}
+
+// windowSize is the initial flow control window of each stream, a few chunks of a block are in flight so that a block
+// is streamed without waiting for each window update, while a slow reader buffers at most the window.
+const windowSize = 4 * 1024 * 1024
+
+// connWindowSize is the initial flow control window of each connection, shared by the streams on it.
+const connWindowSize = 16 * 1024 * 1024
+
+// Conn is a connection shared by the callers in this process, Close returns it to the pool instead of closing it.
+type Conn struct {
+	*grpc.ClientConn
+	entry *entry
+	once  sync.Once
+}
+
+// Close releases the connection, it is closed by the pool once it is idle.
+func (c *Conn) Close() error {
+	c.once.Do(func() {
+		defaultPool.release(c.entry)
+	})
+	return nil
+}
+
+type entry struct {
+	conn     *grpc.ClientConn
+	target   string
+	host     string    // canonical host of target
+	refs     int       // number of callers using the connection
+	lastUsed time.Time // time the connection is last released
+	removed  bool      // whether the connection is removed from the pool, it is closed once the last caller releases it
+}
+
+// pool keeps one connection to each server by host and port.
+type pool struct {
+	mu      sync.Mutex
+	conns   map[string]*entry
+	evictor sync.Once
+}
+
+var defaultPool = &pool{conns: map[string]*entry{}}
+
+// Get returns the shared connection to target, which is dialed with the certificate of this process unless a usable one
+// is open. The caller must Close the connection once its calls are done.
+func Get(target string) (*Conn, error) {
+	return defaultPool.get(target)
+}
+
+// CloseHost closes the connections to every server on a host, e.g. a member which failed. The calls in flight on them
+// are cancelled.
+func CloseHost(host string) {
+	defaultPool.closeHost(host)
+}
+
+func (p *pool) get(target string) (*Conn, error) {
+	p.evictor.Do(func() {
+		go p.evictIdle()
+	})
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e, ok := p.conns[target]
+	if ok && e.conn.GetState() == connectivity.TransientFailure {
+		// the connection waits for its backoff before it reconnects, a new one is dialed in case the server is back
+		p.remove(e)
+		ok = false
+	}
+	if !ok {
+		conn, err := auth.Dial(target,
+			grpc.WithKeepaliveParams(keepaliveParams),
+			grpc.WithInitialWindowSize(windowSize),
+			grpc.WithInitialConnWindowSize(connWindowSize),
+		)
+		if err != nil {
+			return nil, err
+		}
+		e = p.add(target, conn)
+		logrus.Debugf("Dialed connection to %s", target)
+	}
+	e.refs++
+	return &Conn{ClientConn: e.conn, entry: e}, nil
+}
+
+// add adds a connection dialed to target to the pool, the caller must hold the lock.
+func (p *pool) add(target string, conn *grpc.ClientConn) *entry {
+	e := &entry{conn: conn, target: target, host: targetHost(target)}
+	p.conns[target] = e
+	return e
+}
+
+func (p *pool) release(e *entry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e.refs--
+	e.lastUsed = time.Now()
+	if e.removed && e.refs == 0 {
+		e.conn.Close()
+	}
+}
+
+// remove removes a connection from the pool and closes it unless it is in use, the caller must hold the lock.
+func (p *pool) remove(e *entry) {
+	if p.conns[e.target] == e {
+		delete(p.conns, e.target)
+	}
+	e.removed = true
+	if e.refs == 0 {
+		e.conn.Close()
+	}
+}
+
+// targetHost returns the canonical host of a target.
+func targetHost(target string) string {
+	host, _, err := net.SplitHostPort(target)
+	if err != nil {
+		host = target
+	}
+	return canonicalHost(host)
+}
+
+// canonicalHost returns a host as members are named, the local host in any form is named by its hostname.
+func canonicalHost(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" || host == "localhost" || net.ParseIP(host).IsLoopback() {
+		if hostname, err := os.Hostname(); err == nil {
+			return strings.ToLower(hostname)
+		}
+	}
+	return host
+}
+
+// lookupTimeout bounds resolving the addresses of a host whose connections are closed.
+const lookupTimeout = time.Second
+
+func (p *pool) closeHost(host string) {
+	hosts := map[string]bool{canonicalHost(host): true}
+	// the connections dialed to an address of the host are matched by the addresses it resolves to
+	if p.hasAddress() {
+		ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+		addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+		cancel()
+		if err != nil {
+			logrus.Debugf("Cannot resolve %s: %v", host, err)
+		}
+		for _, addr := range addrs {
+			hosts[canonicalHost(addr)] = true
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, e := range p.conns {
+		if !hosts[e.host] {
+			continue
+		}
+		delete(p.conns, e.target)
+		e.removed = true
+		e.conn.Close()
+		logrus.Infof("Closed connection to %s", e.target)
+	}
+}
+
+// hasAddress returns whether a connection in the pool is dialed to an IP address.
+func (p *pool) hasAddress() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, e := range p.conns {
+		if net.ParseIP(e.host) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// evictIdle closes the connections which are not used for idleTimeout every evictInterval.
+func (p *pool) evictIdle() {
+	ticker := time.NewTicker(evictInterval)
+	for range ticker.C {
+		p.mu.Lock()
+		for _, e := range p.conns {
+			if e.refs == 0 && time.Since(e.lastUsed) > idleTimeout {
+				p.remove(e)
+				logrus.Debugf("Closed idle connection to %s", e.target)
+			}
+		}
+		p.mu.Unlock()
+	}
+}
@@ -0,0 +1,72 @@
+package connpool
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestCloseHost(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := []string{hostname + ":" + port, "localhost:" + port, "LOCALHOST.:" + port, "127.0.0.1:" + port, "[::1]:" + port, ":" + port}
+	// a connection dialed to an address of the host is closed too
+	if addrs, err := net.LookupHost(hostname); err == nil {
+		for _, addr := range addrs {
+			if !net.ParseIP(addr).IsLoopback() {
+				closed = append(closed, net.JoinHostPort(addr, port))
+				break
+			}
+		}
+	}
+	kept := []string{"other-" + hostname + ":" + port, "192.0.2.1:" + port}
+
+	p := &pool{conns: map[string]*entry{}}
+	conns := map[string]*grpc.ClientConn{}
+	for _, target := range append(closed, kept...) {
+		conn, err := grpc.Dial(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("Dial %s failed: %v", target, err)
+		}
+		defer conn.Close()
+		p.add(target, conn)
+		conns[target] = conn
+	}
+	p.closeHost(hostname)
+	for _, target := range closed {
+		if state := conns[target].GetState(); state != connectivity.Shutdown {
+			t.Errorf("connection to %s is %v after closeHost(%q), want %v", target, state, hostname, connectivity.Shutdown)
+		}
+		if _, ok := p.conns[target]; ok {
+			t.Errorf("connection to %s is still pooled after closeHost(%q)", target, hostname)
+		}
+	}
+	for _, target := range kept {
+		if state := conns[target].GetState(); state == connectivity.Shutdown {
+			t.Errorf("connection to %s is closed by closeHost(%q)", target, hostname)
+		}
+		if _, ok := p.conns[target]; !ok {
+			t.Errorf("connection to %s is removed by closeHost(%q)", target, hostname)
+		}
+	}
+	// a host named by its address
+	p.closeHost("192.0.2.1")
+	if state := conns[kept[1]].GetState(); state != connectivity.Shutdown {
+		t.Errorf("connection to %s is %v after closeHost(%q), want %v", kept[1], state, "192.0.2.1", connectivity.Shutdown)
+	}
+}
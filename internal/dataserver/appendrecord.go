@@ -0,0 +1,153 @@
+package dataserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/checksum"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordWaitTimeout is how long a record waits for the records before it in the block to be appended.
+const recordWaitTimeout = time.Second * 30
+
+// AppendRecord receives a record and appends it to a block in place once the records before it are appended. The offsets
+// are assigned by the leader, so every replica of the block has the same records at the same offsets.
+func (ds *DataServer) AppendRecord(stream pb.DataServer_AppendRecordServer) error {
+	var header *pb.AppendRecordRequest
+	record := []byte{}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive record from client: %v", err)
+		}
+		if header == nil {
+			header = req
+		}
+		record = append(record, req.GetChunk()...)
+	}
+	if header == nil {
+		return status.Errorf(codes.InvalidArgument, "no chunk received")
+	}
+	ctx, cancel := context.WithTimeout(stream.Context(), recordWaitTimeout)
+	defer cancel()
+	if err := ds.appendRecord(ctx, header, record); err != nil {
+		logrus.Errorf("failed to append record at offset %d of file %s block %d: %v", header.GetOffset(), header.GetFileName(), header.GetBlockID(), err)
+		return err
+	}
+	logrus.Infof("appended record with size %d at offset %d of file %s block %d", len(record), header.GetOffset(), header.GetFileName(), header.GetBlockID())
+	return stream.SendAndClose(&pb.AppendRecordReply{})
+}
+
+// appendRecord waits until the block ends at the offset of the record and appends the record to it.
+func (ds *DataServer) appendRecord(ctx context.Context, in *pb.AppendRecordRequest, record []byte) error {
+	for {
+		ds.blockMu.Lock()
+		size, err := ds.recordBlockSize(in)
+		if err == nil && size == in.GetOffset() {
+			err = ds.writeRecord(in.GetFileName(), in.GetBlockID(), in.GetGeneration(), size, record)
+			close(ds.recordAppended)
+			ds.recordAppended = make(chan struct{})
+		}
+		appended := ds.recordAppended
+		ds.blockMu.Unlock()
+		if err != nil || size == in.GetOffset() {
+			return err
+		}
+		if size > in.GetOffset() {
+			return status.Errorf(codes.FailedPrecondition, "file %s block %d has %d bytes beyond the record at offset %d", in.GetFileName(), in.GetBlockID(), size, in.GetOffset())
+		}
+		select {
+		case <-appended:
+		case <-ctx.Done():
+			return status.Errorf(codes.DeadlineExceeded, "file %s block %d ends at %d before the record at offset %d: %v", in.GetFileName(), in.GetBlockID(), size, in.GetOffset(), ctx.Err())
+		}
+	}
+}
+
+// recordBlockSize returns the size of the generation of a block the record is appended to, the generation is created
+// from the first baseSize bytes of the base generation on this data server if it does not exist. The caller must hold
+// blockMu.
+func (ds *DataServer) recordBlockSize(in *pb.AppendRecordRequest) (int64, error) {
+	info, err := os.Stat(ds.GetFilePath(in.GetFileName(), in.GetBlockID(), in.GetGeneration()))
+	if err == nil {
+		return info.Size(), nil
+	}
+	if !os.IsNotExist(err) {
+		return 0, err
+	}
+	writer, err := ds.newBlockWriter(in.GetFileName(), in.GetBlockID(), in.GetGeneration())
+	if err != nil {
+		return 0, err
+	}
+	if baseSize := in.GetBaseSize(); baseSize > 0 {
+		file, checksums, size, err := ds.openBlock(in.GetFileName(), in.GetBlockID(), in.GetBaseGeneration())
+		if err != nil {
+			writer.abort()
+			return 0, err
+		}
+		defer file.Close()
+		if size < baseSize {
+			writer.abort()
+			return 0, status.Errorf(codes.FailedPrecondition, "file %s block %d generation %d has %d bytes, expected %d", in.GetFileName(), in.GetBlockID(), in.GetBaseGeneration(), size, baseSize)
+		}
+		// the bytes after baseSize are records which are not committed
+		_, err = readBlock(file, checksums, size, in.GetFileName(), in.GetBlockID(), 0, baseSize, func(chunk []byte, offset int64, _ []uint32) error {
+			if offset+int64(len(chunk)) > baseSize {
+				chunk = chunk[:baseSize-offset]
+			}
+			return writer.write(chunk, nil)
+		})
+		if err != nil {
+			writer.abort()
+			return 0, err
+		}
+	}
+	if err := writer.commit(); err != nil {
+		return 0, err
+	}
+	return writer.size, nil
+}
+
+// writeRecord appends a record to a block of size bytes and updates the checksums, the checksum of the last partial
+// chunk is computed again with the record. The caller must hold blockMu.
+func (ds *DataServer) writeRecord(fileName string, blockID int64, generation int64, size int64, record []byte) error {
+	checksums, err := ds.readChecksums(fileName, blockID, generation)
+	if err != nil {
+		return err
+	}
+	if checksums == nil {
+		return status.Errorf(codes.DataLoss, "no checksums of file %s block %d", fileName, blockID)
+	}
+	filePath := ds.GetFilePath(fileName, blockID, generation)
+	file, err := os.OpenFile(filePath, os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %v", filePath, err)
+	}
+	defer file.Close()
+	lastChunk := size - size%checksum.BytesPerChecksum
+	tail := make([]byte, size-lastChunk)
+	if _, err := file.ReadAt(tail, lastChunk); err != nil {
+		return fmt.Errorf("failed to read %s: %v", filePath, err)
+	}
+	if _, err := verifyChunk(fileName, blockID, tail, lastChunk, checksums); err != nil {
+		return err
+	}
+	if _, err := file.WriteAt(record, size); err != nil {
+		return fmt.Errorf("failed to write %s: %v", filePath, err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync %s: %v", filePath, err)
+	}
+	checksums = append(checksums[:lastChunk/checksum.BytesPerChecksum], checksum.Compute(append(tail, record...))...)
+	return writeFileAtomic(ds.GetChecksumPath(fileName, blockID, generation), checksum.Encode(checksums))
+}
@@ -0,0 +1,287 @@
+package dataserver
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/checksum"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/compress"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TEMP_FILE_EXT is the extension of a block or checksum file being written.
+const TEMP_FILE_EXT = ".temp"
+
+// blockWriter streams a block into a temp file, the block replaces the old one only after it is complete and synced.
+type blockWriter struct {
+	ds         *DataServer
+	fileName   string
+	blockID    int64
+	generation int64
+	file       *os.File
+	checksums  []uint32
+	size       int64
+}
+
+// newBlockWriter creates the temp file of a block in blocksDir.
+func (ds *DataServer) newBlockWriter(fileName string, blockID int64, generation int64) (*blockWriter, error) {
+	filePath := ds.GetFilePath(fileName, blockID, generation)
+	file, err := os.CreateTemp(ds.blocksDir, filepath.Base(filePath)+".*"+TEMP_FILE_EXT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file of %s: %v", filePath, err)
+	}
+	return &blockWriter{
+		ds:         ds,
+		fileName:   fileName,
+		blockID:    blockID,
+		generation: generation,
+		file:       file,
+		checksums:  []uint32{},
+	}, nil
+}
+
+// write verifies a chunk against the checksums computed by the sender and appends it to the temp file.
+// The checksums are computed here if the sender does not send them.
+func (w *blockWriter) write(chunk []byte, checksums []uint32) error {
+	if w.size%checksum.BytesPerChecksum != 0 {
+		return status.Errorf(codes.InvalidArgument, "chunk at offset %d of file %s block %d is not aligned to %d bytes", w.size, w.fileName, w.blockID, checksum.BytesPerChecksum)
+	}
+	if len(checksums) == 0 {
+		checksums = checksum.Compute(chunk)
+	} else if err := checksum.Verify(chunk, checksums); err != nil {
+		logrus.Errorf("received corrupted chunk of file %s block %d at offset %d: %v", w.fileName, w.blockID, w.size, err)
+		return status.Errorf(codes.DataLoss, "chunk at offset %d of file %s block %d is corrupted: %v", w.size, w.fileName, w.blockID, err)
+	}
+	if _, err := w.file.Write(chunk); err != nil {
+		return fmt.Errorf("failed to write %s: %v", w.file.Name(), err)
+	}
+	w.checksums = append(w.checksums, checksums...)
+	w.size += int64(len(chunk))
+	return nil
+}
+
+// commit syncs the temp file and renames it and its checksum file to the block.
+func (w *blockWriter) commit() error {
+	if err := w.file.Sync(); err != nil {
+		w.abort()
+		return fmt.Errorf("failed to sync %s: %v", w.file.Name(), err)
+	}
+	if err := w.file.Close(); err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("failed to close %s: %v", w.file.Name(), err)
+	}
+	ds := w.ds
+	if err := writeFileAtomic(ds.GetChecksumPath(w.fileName, w.blockID, w.generation), checksum.Encode(w.checksums)); err != nil {
+		os.Remove(w.file.Name())
+		return err
+	}
+	filePath := ds.GetFilePath(w.fileName, w.blockID, w.generation)
+	if err := os.Rename(w.file.Name(), filePath); err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("failed to rename %s: %v", w.file.Name(), err)
+	}
+	if err := syncDir(ds.blocksDir); err != nil {
+		return fmt.Errorf("failed to sync blocksDir %s: %v", ds.blocksDir, err)
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat file %s: %v", filePath, err)
+	}
+	ds.recordBlockAdded(w.fileName, w.blockID, w.generation, info.Size(), info.ModTime().UnixNano())
+	ds.addGeneration(w.fileName, w.blockID, w.generation)
+	ds.removeScrubFinding(w.fileName, w.blockID, w.generation)
+	return nil
+}
+
+// abort removes the temp file, the old block is kept.
+func (w *blockWriter) abort() {
+	w.file.Close()
+	if err := os.Remove(w.file.Name()); err != nil && !os.IsNotExist(err) {
+		logrus.Errorf("failed to remove temp file %s: %v", w.file.Name(), err)
+	}
+}
+
+// readFileBlock streams a block from disk in chunks of CHUNK_SIZE and returns the size of the block.
+// Each chunk is verified against the stored checksums before it is passed to send, send is called at least once.
+func (ds *DataServer) readFileBlock(fileName string, blockID int64, generation int64, send func(chunk []byte, checksums []uint32) error) (int64, error) {
+	return ds.readFileBlockRange(fileName, blockID, generation, 0, 0, func(chunk []byte, _ int64, checksums []uint32) error {
+		return send(chunk, checksums)
+	})
+}
+
+// readFileBlockRange streams length bytes from offset of a block, to the end of the block if length is 0, and returns
+// the number of bytes read. The range is widened to the checksums it overlaps so that each chunk passed to send with its
+// offset in the block can be verified, send is called at least once.
+func (ds *DataServer) readFileBlockRange(fileName string, blockID int64, generation int64, offset int64, length int64, send func(chunk []byte, offset int64, checksums []uint32) error) (int64, error) {
+	ds.blockMu.RLock()
+	file, checksums, size, err := ds.openBlock(fileName, blockID, generation)
+	ds.blockMu.RUnlock()
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	return readBlock(file, checksums, size, fileName, blockID, offset, length, send)
+}
+
+// frameSpan returns the range in a compressed block of the frames which hold length bytes at offset of its data, to the
+// end of the block if length is 0, and the offset in the data of the first of them.
+func (ds *DataServer) frameSpan(fileName string, blockID int64, generation int64, offset int64, length int64) (int64, int64, int64, error) {
+	file, err := ds.openFile(fileName, blockID, generation)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	frames, err := compress.ReadFrames(file, info.Size())
+	if err != nil {
+		return 0, 0, 0, status.Errorf(codes.DataLoss, "file %s block %d is corrupted: %v", fileName, blockID, err)
+	}
+	dataSize := int64(0)
+	if len(frames) > 0 {
+		last := frames[len(frames)-1]
+		dataSize = last.Start + last.Size
+	}
+	if offset < 0 || length < 0 || offset > dataSize {
+		return 0, 0, 0, status.Errorf(codes.OutOfRange, "range %d+%d is beyond the data of file %s block %d of size %d", offset, length, fileName, blockID, dataSize)
+	}
+	span := compress.Span(frames, offset, length)
+	if len(span) == 0 {
+		return info.Size(), info.Size(), dataSize, nil
+	}
+	last := span[len(span)-1]
+	return span[0].Offset, last.Offset + last.StoredSize, span[0].Start, nil
+}
+
+// openBlock opens a block with its checksums and size, the caller must hold blockMu so that they are not changed by a
+// record being appended. The bytes appended after the size are not read.
+func (ds *DataServer) openBlock(fileName string, blockID int64, generation int64) (*os.File, []uint32, int64, error) {
+	file, err := ds.openFile(fileName, blockID, generation)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	checksums, err := ds.readChecksums(fileName, blockID, generation)
+	if err != nil {
+		file.Close()
+		return nil, nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, 0, err
+	}
+	return file, checksums, info.Size(), nil
+}
+
+// readBlock streams a range of the first size bytes of an opened block, see readFileBlockRange.
+func readBlock(file *os.File, checksums []uint32, size int64, fileName string, blockID int64, offset int64, length int64, send func(chunk []byte, offset int64, checksums []uint32) error) (int64, error) {
+	wholeBlock := offset == 0 && length == 0
+	if offset < 0 || length < 0 || offset > size {
+		return 0, status.Errorf(codes.OutOfRange, "range %d+%d is beyond file %s block %d of size %d", offset, length, fileName, blockID, size)
+	}
+	start := offset - offset%checksum.BytesPerChecksum
+	end := size
+	if length > 0 && offset+length < end {
+		end = start + int64(checksum.Count(offset+length-start))*checksum.BytesPerChecksum
+		if end > size {
+			end = size
+		}
+	}
+	if _, err := file.Seek(start, io.SeekStart); err != nil {
+		return 0, err
+	}
+	buf := make([]byte, CHUNK_SIZE)
+	position := start
+	for {
+		toRead := buf
+		if end-position < int64(len(buf)) {
+			toRead = buf[:end-position]
+		}
+		num, err := io.ReadFull(file, toRead)
+		if err == io.EOF && position > start {
+			break
+		}
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return position - start, err
+		}
+		chunk := toRead[:num]
+		chunkChecksums, err := verifyChunk(fileName, blockID, chunk, position, checksums)
+		if err != nil {
+			return position - start, err
+		}
+		if err := send(chunk, position, chunkChecksums); err != nil {
+			return position - start, err
+		}
+		position += int64(num)
+		if num < len(toRead) || position == end {
+			break
+		}
+	}
+	if wholeBlock && checksums != nil && checksum.Count(position) != len(checksums) {
+		return position, status.Errorf(codes.DataLoss, "file %s block %d is corrupted: size %d does not match %d checksums", fileName, blockID, position, len(checksums))
+	}
+	return position - start, nil
+}
+
+// removeTempFiles removes the temp files left by the writes interrupted by a crash.
+func (ds *DataServer) removeTempFiles() {
+	entries, err := os.ReadDir(ds.blocksDir)
+	if err != nil {
+		logrus.Errorf("failed to read blocksDir %s: %v", ds.blocksDir, err)
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), TEMP_FILE_EXT) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(ds.blocksDir, entry.Name())); err != nil {
+			logrus.Errorf("failed to remove temp file %s: %v", entry.Name(), err)
+			continue
+		}
+		logrus.Infof("Removed temp file %s", entry.Name())
+	}
+}
+
+// writeFileAtomic replaces the file at path with data through a fsync'd temp file.
+func writeFileAtomic(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*"+TEMP_FILE_EXT)
+	if err != nil {
+		return fmt.Errorf("failed to create temp file of %s: %v", path, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return fmt.Errorf("failed to write %s: %v", file.Name(), err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return fmt.Errorf("failed to sync %s: %v", file.Name(), err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("failed to rename %s: %v", file.Name(), err)
+	}
+	return nil
+}
+
+// syncDir fsyncs a directory so that a rename in it is durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,72 @@
+package dataserver
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/compress"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFrameSpan(t *testing.T) {
+	ds := &DataServer{blocksDir: t.TempDir()}
+	data := make([]byte, compress.FrameSize*2+100)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	encoded, err := compress.Zstd.Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ds.GetFilePath("file", 0, 1), encoded, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		offset, length int64
+	}{
+		{0, 0},
+		{0, 10},
+		{compress.FrameSize - 5, 10},
+		{compress.FrameSize, compress.FrameSize},
+		{compress.FrameSize + 1, compress.FrameSize},
+		{compress.FrameSize*2 + 99, 1},
+		{compress.FrameSize * 2, 0},
+	}
+	for _, test := range tests {
+		frameOffset, frameEnd, frameStart, err := ds.frameSpan("file", 0, 1, test.offset, test.length)
+		if err != nil {
+			t.Fatalf("frameSpan(%d, %d) failed: %v", test.offset, test.length, err)
+		}
+		decoded, err := compress.Zstd.Decode(encoded[frameOffset:frameEnd])
+		if err != nil {
+			t.Fatalf("Decode of frameSpan(%d, %d) failed: %v", test.offset, test.length, err)
+		}
+		end := int64(len(data))
+		if test.length > 0 {
+			end = test.offset + test.length
+		}
+		if frameStart > test.offset || frameStart+int64(len(decoded)) < end {
+			t.Errorf("frameSpan(%d, %d) holds %d+%d of the data", test.offset, test.length, frameStart, len(decoded))
+			continue
+		}
+		if !bytes.Equal(decoded[test.offset-frameStart:end-frameStart], data[test.offset:end]) {
+			t.Errorf("range %d+%d read from frameSpan does not match the data", test.offset, test.length)
+		}
+	}
+	if _, _, _, err := ds.frameSpan("file", 0, 1, int64(len(data))+1, 0); status.Code(err) != codes.OutOfRange {
+		t.Errorf("frameSpan beyond the data returned %v, want OutOfRange", err)
+	}
+	// a block cut in the header of its last frame is corrupted
+	frames, err := compress.ReadFrames(bytes.NewReader(encoded), int64(len(encoded)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ds.GetFilePath("file", 0, 2), encoded[:frames[2].Offset+compress.HeaderSize/2], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := ds.frameSpan("file", 0, 2, 0, 0); status.Code(err) != codes.DataLoss {
+		t.Errorf("frameSpan of a truncated block returned %v, want DataLoss", err)
+	}
+}
@@ -0,0 +1,228 @@
+package dataserver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
+	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+func (ds *DataServer) startReportingBlocks() {
+	if ds.blockReportInterval <= 0 {
+		logrus.Warn("Block report interval is not set, blocks will not be reported to the leader")
+		return
+	}
+	logrus.Info("Start reporting blocks")
+	ds.blockReportTicker = time.NewTicker(ds.blockReportInterval)
+	defer ds.blockReportTicker.Stop()
+	for {
+		select {
+		case <-ds.blockReportTickerDone:
+			return
+		case <-ds.blockReportTicker.C:
+			ds.reportBlocks()
+		}
+	}
+}
+
+func (ds *DataServer) stopReportingBlocks() {
+	ds.blockReportTickerDone <- true
+}
+
+// reportBlocks sends the full block inventory to a new leader, or the delta since the last report otherwise.
+func (ds *DataServer) reportBlocks() {
+	leader, err := ds.getLeader()
+	if err != nil {
+		logrus.Errorf("failed to get leader: %v", err)
+		return
+	}
+	if leader == "" {
+		logrus.Debugf("No leader, skip reporting blocks")
+		return
+	}
+	ds.blockReportMu.Lock()
+	full := leader != ds.reportedLeader
+	var added, removed []*leaderServerProto.ReportedBlock
+	if full {
+		// changes after the walk are sent as the next delta
+		ds.addedBlocks = map[string]*leaderServerProto.ReportedBlock{}
+		ds.removedBlocks = map[string]*leaderServerProto.ReportedBlock{}
+		ds.blockReportMu.Unlock()
+		added, err = ds.listBlocks()
+		if err != nil {
+			logrus.Errorf("failed to list blocks: %v", err)
+			return
+		}
+	} else {
+		for _, block := range ds.addedBlocks {
+			added = append(added, block)
+		}
+		for _, block := range ds.removedBlocks {
+			removed = append(removed, block)
+		}
+		ds.addedBlocks = map[string]*leaderServerProto.ReportedBlock{}
+		ds.removedBlocks = map[string]*leaderServerProto.ReportedBlock{}
+		ds.blockReportMu.Unlock()
+	}
+
+	r, err := ds.blockReport(leader, full, added, removed)
+	ds.blockReportMu.Lock()
+	defer ds.blockReportMu.Unlock()
+	if err != nil {
+		// the lost delta is covered by a full report to the leader
+		logrus.Errorf("failed to report blocks to leader %s: %v", leader, err)
+		ds.reportedLeader = ""
+		return
+	}
+	if r.GetNeedFullReport() {
+		logrus.Infof("Leader %s asks for a full block report", leader)
+		ds.reportedLeader = ""
+		return
+	}
+	if full {
+		logrus.Infof("Sent full block report with %d blocks to leader %s", len(added), leader)
+		ds.reportedLeader = leader
+	}
+}
+
+// recordBlockAdded records a block written since the last block report.
+func (ds *DataServer) recordBlockAdded(fileName string, blockID int64, generation int64, blockSize int64, modTime int64) {
+	ds.blockReportMu.Lock()
+	defer ds.blockReportMu.Unlock()
+	key := blockKey(fileName, blockID, generation)
+	delete(ds.removedBlocks, key)
+	ds.addedBlocks[key] = &leaderServerProto.ReportedBlock{
+		FileName:   fileName,
+		BlockID:    blockID,
+		BlockSize:  blockSize,
+		ModTime:    modTime,
+		Generation: generation,
+	}
+}
+
+// recordBlockRemoved records a block removed since the last block report.
+func (ds *DataServer) recordBlockRemoved(fileName string, blockID int64, generation int64) {
+	ds.blockReportMu.Lock()
+	defer ds.blockReportMu.Unlock()
+	key := blockKey(fileName, blockID, generation)
+	delete(ds.addedBlocks, key)
+	ds.removedBlocks[key] = &leaderServerProto.ReportedBlock{
+		FileName:   fileName,
+		BlockID:    blockID,
+		Generation: generation,
+	}
+}
+
+// listBlocks walks blocksDir and returns all blocks stored in this data server.
+func (ds *DataServer) listBlocks() ([]*leaderServerProto.ReportedBlock, error) {
+	entries, err := os.ReadDir(ds.blocksDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blocksDir %s: %v", ds.blocksDir, err)
+	}
+	blocks := []*leaderServerProto.ReportedBlock{}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), CHECKSUM_FILE_EXT) || strings.HasSuffix(entry.Name(), TEMP_FILE_EXT) {
+			continue
+		}
+		fileName, blockID, generation, err := ParseFilePath(entry.Name())
+		if err != nil {
+			logrus.Warnf("Skip unexpected file %s in blocksDir: %v", entry.Name(), err)
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// removed during the walk
+			continue
+		}
+		blocks = append(blocks, &leaderServerProto.ReportedBlock{
+			FileName:   fileName,
+			BlockID:    blockID,
+			BlockSize:  info.Size(),
+			ModTime:    info.ModTime().UnixNano(),
+			Generation: generation,
+		})
+	}
+	return blocks, nil
+}
+
+// ParseFilePath parses the fileName, blockID and generation from the name of a block file, the reverse of GetFilePath.
+func ParseFilePath(name string) (string, int64, int64, error) {
+	name = filepath.Base(name)
+	var generation int64 = 0
+	if index := strings.LastIndex(name, "_g"); index > 0 {
+		if g, err := strconv.ParseInt(name[index+2:], 10, 64); err == nil {
+			generation = g
+			name = name[:index]
+		}
+	}
+	index := strings.LastIndex(name, "_")
+	if index <= 0 {
+		return "", 0, 0, fmt.Errorf("invalid block file name %s", name)
+	}
+	blockID, err := strconv.ParseInt(name[index+1:], 10, 64)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid block id of block file %s", name)
+	}
+	return name[:index], blockID, generation, nil
+}
+
+// blockKey returns the name of a block file in blocksDir, "<fileName>_<blockID>_g<generation>".
+// A block written before generations were assigned has generation 0 and no suffix.
+func blockKey(fileName string, blockID int64, generation int64) string {
+	if generation == 0 {
+		return fmt.Sprintf("%s_%d", fileName, blockID)
+	}
+	return fmt.Sprintf("%s_%d_g%d", fileName, blockID, generation)
+}
+
+// getLeader from local leader server through gRPC.
+func (ds *DataServer) getLeader() (string, error) {
+	conn, err := connpool.Get("localhost:" + ds.leaderServerPort)
+	if err != nil {
+		return "", fmt.Errorf("cannot connect to %s leaderServer: %v", "localhost", err)
+	}
+	defer conn.Close()
+
+	client := leaderServerProto.NewLeaderServerClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	r, err := client.GetLeader(ctx, &leaderServerProto.GetLeaderRequest{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get leader: %v", err)
+	}
+	return r.GetLeader(), nil
+}
+
+// blockReport sends the block report with the disk usage to the leader through gRPC.
+func (ds *DataServer) blockReport(leader string, full bool, added, removed []*leaderServerProto.ReportedBlock) (*leaderServerProto.BlockReportReply, error) {
+	conn, err := connpool.Get(leader + ":" + ds.leaderServerPort)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
+	}
+	defer conn.Close()
+
+	// the leader places replicas by the disk usage
+	capacity, used, err := ds.diskUsage()
+	if err != nil {
+		logrus.Errorf("failed to get disk usage: %v", err)
+	}
+
+	client := leaderServerProto.NewLeaderServerClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	return client.BlockReport(ctx, &leaderServerProto.BlockReportRequest{
+		HostName:      ds.hostname,
+		Full:          full,
+		AddedBlocks:   added,
+		RemovedBlocks: removed,
+		DiskCapacity:  capacity,
+		DiskUsed:      used,
+	})
+}
@@ -0,0 +1,46 @@
+package dataserver
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/checksum"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readChecksums reads the checksums of a block, a block written before checksums were stored has none.
+func (ds *DataServer) readChecksums(fileName string, blockID int64, generation int64) ([]uint32, error) {
+	checksumPath := ds.GetChecksumPath(fileName, blockID, generation)
+	data, err := os.ReadFile(checksumPath)
+	if os.IsNotExist(err) {
+		logrus.Debugf("No checksums of file %s block %d", fileName, blockID)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checksum file %s: %v", checksumPath, err)
+	}
+	checksums, err := checksum.Decode(data)
+	if err != nil {
+		return nil, status.Errorf(codes.DataLoss, "file %s block %d is corrupted: %v", fileName, blockID, err)
+	}
+	return checksums, nil
+}
+
+// verifyChunk verifies the chunk at offset of a block against the stored checksums and returns the checksums of the chunk.
+// The offset must be a multiple of checksum.BytesPerChecksum.
+func verifyChunk(fileName string, blockID int64, chunk []byte, offset int64, checksums []uint32) ([]uint32, error) {
+	if checksums == nil {
+		return checksum.Compute(chunk), nil
+	}
+	first := int(offset / checksum.BytesPerChecksum)
+	last := first + checksum.Count(int64(len(chunk)))
+	if last > len(checksums) {
+		return nil, status.Errorf(codes.DataLoss, "file %s block %d is corrupted: %v beyond offset %d", fileName, blockID, checksum.ErrMismatch, offset)
+	}
+	if err := checksum.Verify(chunk, checksums[first:last]); err != nil {
+		return nil, status.Errorf(codes.DataLoss, "file %s block %d is corrupted at chunk offset %d: %v", fileName, blockID, offset, err)
+	}
+	return checksums[first:last], nil
+}
@@ -5,18 +5,47 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
-	"google.golang.org/grpc"
+	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
 )
 
 var CHUNK_SIZE = 3 * 1024 * 1024
 
+// CHECKSUM_FILE_EXT is the extension of the checksum file of a block.
+const CHECKSUM_FILE_EXT = ".crc"
+
 // DataServer handle data blocks and metadata.
 type DataServer struct {
-	port      string
-	blocksDir string
+	port             string
+	blocksDir        string
+	hostname         string
+	leaderServerPort string
+
+	blockReportInterval   time.Duration
+	blockReportTicker     *time.Ticker
+	blockReportTickerDone chan bool
+	reportedLeader        string                                      // leader which received the last full block report
+	addedBlocks           map[string]*leaderServerProto.ReportedBlock // blocks added since the last block report
+	removedBlocks         map[string]*leaderServerProto.ReportedBlock // blocks removed since the last block report
+	blockReportMu         sync.Mutex
+
+	scrubInterval   time.Duration
+	scrubBandwidth  int64 // bytes per second
+	scrubTicker     *time.Ticker
+	scrubTickerDone chan bool
+	scrubber        *Scrubber
+
+	blockMu        sync.RWMutex  // held to append a record to a block, or to open a block with its size and checksums
+	recordAppended chan struct{} // closed when a record is appended, guarded by blockMu
+
+	generations   map[string]int64 // latest generation of each block stored, by blockKey with generation 0
+	generationsMu sync.RWMutex
 
 	pb.UnimplementedDataServerServer
 }
@@ -32,29 +61,36 @@ type DataBlock struct {
 }
 
 // NewDataServer creates a new dataserver.
-func NewDataServer(port, blocksDir string) *DataServer {
-	// clean up blocksDir
-	if err := filepath.Walk(blocksDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			logrus.Errorf("Failed to walk blocksDir %s: %v", blocksDir, err)
-			return err
-		}
-		if !info.IsDir() {
-			if err := os.Remove(path); err != nil {
-				logrus.Errorf("Failed to remove file %s: %v", path, err)
-				return err
-			}
-		}
+func NewDataServer(config *config.Config) *DataServer {
+	hostname, err := os.Hostname()
+	if err != nil {
+		logrus.Fatalf("failed to get hostname: %v\n", err)
 		return nil
-	}); err != nil {
-		logrus.Errorf("Failed to clean up blocksDir %s: %v", blocksDir, err)
+	}
+	blocksDir := config.BlocksDir
+	// keep the blocks in blocksDir, they are still referenced by the persisted metadata
+	if err := os.MkdirAll(blocksDir, 0755); err != nil {
+		logrus.Errorf("Failed to create blocksDir %s: %v", blocksDir, err)
 		return nil
 	}
 
-	return &DataServer{
-		blocksDir: blocksDir,
-		port:      port,
+	ds := &DataServer{
+		blocksDir:           blocksDir,
+		port:                config.DataServerPort,
+		hostname:            hostname,
+		leaderServerPort:    config.LeaderServerPort,
+		blockReportInterval: config.BlockReport.Interval,
+		addedBlocks:         map[string]*leaderServerProto.ReportedBlock{},
+		removedBlocks:       map[string]*leaderServerProto.ReportedBlock{},
+		scrubInterval:       config.Scrub.Interval,
+		scrubBandwidth:      config.Scrub.Bandwidth,
+		scrubber:            NewScrubber(),
+		recordAppended:      make(chan struct{}),
+		generations:         map[string]int64{},
 	}
+	ds.removeTempFiles()
+	ds.loadGenerations()
+	return ds
 }
 
 // RunDataServer run the dataserver
@@ -65,7 +101,18 @@ func (ds *DataServer) Run() {
 		return
 	}
 	defer listen.Close()
-	grpcServer := grpc.NewServer()
+	go ds.startReportingBlocks()
+	go ds.startScrubbing()
+	// only the nodes may call the methods of the cluster itself
+	grpcServer, err := auth.NewServer(
+		auth.Method(pb.DataServer_ServiceDesc, "ReplicateFileBlock"),
+		auth.Method(pb.DataServer_ServiceDesc, "ReconstructFileBlock"),
+		auth.Method(pb.DataServer_ServiceDesc, "DeleteFileBlock"),
+	)
+	if err != nil {
+		logrus.Fatalf("failed to create gRPC server: %v", err)
+		return
+	}
 	pb.RegisterDataServerServer(grpcServer, ds)
 	logrus.Infof("DataServer listening on port %s", ds.port)
 	if err := grpcServer.Serve(listen); err != nil {
@@ -74,6 +121,13 @@ func (ds *DataServer) Run() {
 	}
 }
 
-func (ds *DataServer) GetFilePath(fileName string, blockID int64) string {
-	return filepath.Join(ds.blocksDir, fmt.Sprintf("%s_%d", fileName, blockID))
+// GetFilePath returns the path of a generation of a block, each write of the block is stored in a new file so that
+// the readers of the old one are not affected.
+func (ds *DataServer) GetFilePath(fileName string, blockID int64, generation int64) string {
+	return filepath.Join(ds.blocksDir, blockKey(fileName, blockID, generation))
+}
+
+// GetChecksumPath returns the path of the checksum file stored next to a block.
+func (ds *DataServer) GetChecksumPath(fileName string, blockID int64, generation int64) string {
+	return ds.GetFilePath(fileName, blockID, generation) + CHECKSUM_FILE_EXT
 }
@@ -0,0 +1,34 @@
+package dataserver
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+)
+
+// DeleteFileBlock deletes a file block from the data server
+func (ds *DataServer) DeleteFileBlock(ctx context.Context, in *pb.DeleteFileBlockRequest) (*pb.DeleteFileBlockReply, error) {
+	return &pb.DeleteFileBlockReply{}, ds.deleteFileBlock(in.GetFileName(), in.GetBlockID(), in.GetGeneration())
+}
+
+// deleteFileBlock removes the block file and its checksum file, deleting a block which does not exist is not an error.
+func (ds *DataServer) deleteFileBlock(fileName string, blockID int64, generation int64) error {
+	filePath := ds.GetFilePath(fileName, blockID, generation)
+	err := os.Remove(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file %s: %v", filePath, err)
+	}
+	checksumPath := ds.GetChecksumPath(fileName, blockID, generation)
+	err = os.Remove(checksumPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete checksum file %s: %v", checksumPath, err)
+	}
+	ds.recordBlockRemoved(fileName, blockID, generation)
+	ds.removeGeneration(fileName, blockID, generation)
+	ds.removeScrubFinding(fileName, blockID, generation)
+	logrus.Infof("deleted file %s block %d generation %d", fileName, blockID, generation)
+	return nil
+}
@@ -0,0 +1,17 @@
+package dataserver
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// diskUsage returns the capacity and the used bytes of the disk of blocksDir.
+func (ds *DataServer) diskUsage() (int64, int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(ds.blocksDir, &stat); err != nil {
+		return 0, 0, fmt.Errorf("failed to stat disk of blocksDir %s: %v", ds.blocksDir, err)
+	}
+	capacity := int64(stat.Blocks) * int64(stat.Bsize)
+	free := int64(stat.Bavail) * int64(stat.Bsize)
+	return capacity, capacity - free, nil
+}
@@ -2,49 +2,106 @@ package dataserver
 
 import (
 	"fmt"
-	"io"
 	"os"
 
 	"github.com/sirupsen/logrus"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// GetFileBlock streams a file block or a range of it from disk with the checksums of each chunk, a corrupted block is
+// not sent. A range of a compressed block is in its data, the frames holding it are sent and the client decompresses
+// them. A read of a generation which is removed after a newer one is stored is rejected with FailedPrecondition, so
+// that the client gets the blocks of the file from the leader again. A generation which is still stored is served, the
+// newer one may be written by a record append which is not committed yet.
 func (ds *DataServer) GetFileBlock(in *pb.GetFileBlockRequest, stream pb.DataServer_GetFileBlockServer) error {
 	fileName := in.GetFileName()
 	blockID := in.GetBlockID()
-	file, err := ds.openFile(fileName, blockID)
-	if err != nil {
-		return err
+	offset, length := in.GetOffset(), in.GetLength()
+	first := &pb.GetFileBlockReply{}
+	var fileSize int64
+	var err error
+	if in.GetFramed() {
+		first.FrameOffset, first.FrameEnd, first.FrameStart, err = ds.frameSpan(fileName, blockID, in.GetGeneration(), offset, length)
+		offset, length = first.FrameOffset, first.FrameEnd-first.FrameOffset
 	}
-	buf := make([]byte, CHUNK_SIZE)
-	fileSize := 0
-	for {
-		num, err := file.Read(buf)
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return err
-		}
-		chunk := buf[:num]
-		if err := stream.Send(&pb.GetFileBlockReply{Chunk: chunk}); err != nil {
-			return err
+	if err == nil {
+		fileSize, err = ds.readFileBlockRange(fileName, blockID, in.GetGeneration(), offset, length, func(chunk []byte, offset int64, checksums []uint32) error {
+			logrus.Debugf("sent a chunk with size %v", len(chunk))
+			reply := &pb.GetFileBlockReply{Chunk: chunk, Checksums: checksums, Offset: offset}
+			if first != nil {
+				reply.FrameOffset, reply.FrameEnd, reply.FrameStart = first.FrameOffset, first.FrameEnd, first.FrameStart
+				first = nil
+			}
+			return stream.Send(reply)
+		})
+	}
+	if err != nil {
+		if _, statErr := os.Stat(ds.GetFilePath(fileName, blockID, in.GetGeneration())); os.IsNotExist(statErr) {
+			// the generation is removed after a newer one is committed
+			if staleErr := ds.checkGeneration(fileName, blockID, in.GetGeneration()); staleErr != nil {
+				err = staleErr
+			}
 		}
-		fileSize += len(chunk)
-		logrus.Debugf("sent a chunk with size %v", len(chunk))
+		logrus.Errorf("failed to send file %s block %d: %v", fileName, blockID, err)
+		return err
 	}
 	logrus.Infof("sent file %s block %d with size %d", fileName, blockID, fileSize)
 	return nil
 }
 
-func (ds *DataServer) openFile(fileName string, blockID int64) (*os.File, error) {
+func (ds *DataServer) openFile(fileName string, blockID int64, generation int64) (*os.File, error) {
 	// get fileBlock from metadata using filename and blockID
 	// read data from filepath
 	// return dataBlock
-	filePath := ds.GetFilePath(fileName, blockID)
+	filePath := ds.GetFilePath(fileName, blockID, generation)
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file %s: %v", filePath, err)
 	}
 	return file, nil
 }
+
+// checkGeneration returns a FailedPrecondition error if a generation newer than generation of a block is stored.
+func (ds *DataServer) checkGeneration(fileName string, blockID int64, generation int64) error {
+	ds.generationsMu.RLock()
+	latest, ok := ds.generations[blockKey(fileName, blockID, 0)]
+	ds.generationsMu.RUnlock()
+	if ok && latest > generation {
+		return status.Errorf(codes.FailedPrecondition, "generation %d of file %s block %d is stale, the latest is %d", generation, fileName, blockID, latest)
+	}
+	return nil
+}
+
+// addGeneration records a generation of a block written to this data server.
+func (ds *DataServer) addGeneration(fileName string, blockID int64, generation int64) {
+	ds.generationsMu.Lock()
+	defer ds.generationsMu.Unlock()
+	key := blockKey(fileName, blockID, 0)
+	if latest, ok := ds.generations[key]; !ok || generation > latest {
+		ds.generations[key] = generation
+	}
+}
+
+// removeGeneration forgets a block once its latest generation is removed, the older ones are garbage by then.
+func (ds *DataServer) removeGeneration(fileName string, blockID int64, generation int64) {
+	ds.generationsMu.Lock()
+	defer ds.generationsMu.Unlock()
+	key := blockKey(fileName, blockID, 0)
+	if ds.generations[key] == generation {
+		delete(ds.generations, key)
+	}
+}
+
+// loadGenerations records the latest generation of each block in blocksDir.
+func (ds *DataServer) loadGenerations() {
+	blocks, err := ds.listBlocks()
+	if err != nil {
+		logrus.Errorf("failed to load generations: %v", err)
+		return
+	}
+	for _, block := range blocks {
+		ds.addGeneration(block.GetFileName(), block.GetBlockID(), block.GetGeneration())
+	}
+}
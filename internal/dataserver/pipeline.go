@@ -0,0 +1,96 @@
+package dataserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+)
+
+// pipeline forwards the chunks of a block to the next data server of the write pipeline.
+type pipeline struct {
+	to         string   // next data server
+	next       []string // data servers after the next one
+	fileName   string
+	blockID    int64
+	generation int64
+	conn       *connpool.Conn
+	stream     pb.DataServer_PutFileBlockClient
+	cancel     context.CancelFunc
+	sent       bool
+	err        error // the pipeline is broken at the next data server
+}
+
+// openPipeline connects to the first data server of downstream, the rest of downstream is forwarded with the first chunk.
+// A pipeline which fails to connect is returned as broken.
+func (ds *DataServer) openPipeline(ctx context.Context, fileName string, blockID int64, generation int64, downstream []string) *pipeline {
+	p := &pipeline{
+		to:         downstream[0],
+		next:       downstream[1:],
+		fileName:   fileName,
+		blockID:    blockID,
+		generation: generation,
+	}
+	conn, err := connpool.Get(p.to + ":" + ds.port)
+	if err != nil {
+		p.err = fmt.Errorf("failed to connect to %s: %v", p.to, err)
+		return p
+	}
+	p.conn = conn
+	ctx, p.cancel = context.WithTimeout(ctx, time.Second*60)
+	stream, err := pb.NewDataServerClient(conn).PutFileBlock(ctx)
+	if err != nil {
+		p.err = err
+		return p
+	}
+	p.stream = stream
+	return p
+}
+
+// send forwards a chunk to the next data server unless the pipeline is broken.
+func (p *pipeline) send(chunk []byte, checksums []uint32) {
+	if p.err != nil {
+		return
+	}
+	req := &pb.PutFileBlockRequest{
+		FileName:   p.fileName,
+		BlockID:    p.blockID,
+		Chunk:      chunk,
+		Checksums:  checksums,
+		Generation: p.generation,
+	}
+	if !p.sent {
+		req.Pipeline = p.next
+		p.sent = true
+	}
+	if err := p.stream.Send(req); err != nil {
+		p.err = err
+	}
+}
+
+// close waits for the ack of the next data server and returns the number of data servers after this one which stored the block.
+func (p *pipeline) close() (int32, error) {
+	defer p.abort()
+	// io.EOF means the next data server closed the stream, its error is returned by CloseAndRecv
+	if p.err != nil && p.err != io.EOF {
+		return 0, p.err
+	}
+	r, err := p.stream.CloseAndRecv()
+	if err != nil {
+		return 0, err
+	}
+	return r.GetReplicas(), nil
+}
+
+// abort cancels the stream so that the data servers after this one drop the partial block.
+func (p *pipeline) abort() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	if p.conn != nil {
+		p.conn.Close()
+	}
+}
@@ -25,8 +25,12 @@ type GetFileBlockRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FileName string `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
-	BlockID  int64  `protobuf:"varint,2,opt,name=blockID,proto3" json:"blockID,omitempty"`
+	FileName   string `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	BlockID    int64  `protobuf:"varint,2,opt,name=blockID,proto3" json:"blockID,omitempty"`
+	Generation int64  `protobuf:"varint,3,opt,name=generation,proto3" json:"generation,omitempty"`
+	Offset     int64  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"` // read from offset of the block
+	Length     int64  `protobuf:"varint,5,opt,name=length,proto3" json:"length,omitempty"` // read length bytes, to the end of the block if 0
+	Framed     bool   `protobuf:"varint,6,opt,name=framed,proto3" json:"framed,omitempty"` // the block is stored as compressed frames, offset and length are in the data of the frames
 }
 
 func (x *GetFileBlockRequest) Reset() {
@@ -75,31 +79,678 @@ func (x *GetFileBlockRequest) GetBlockID() int64 {
 	return 0
 }
 
+func (x *GetFileBlockRequest) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *GetFileBlockRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetFileBlockRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *GetFileBlockRequest) GetFramed() bool {
+	if x != nil {
+		return x.Framed
+	}
+	return false
+}
+
 type GetFileBlockReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Chunk       []byte   `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Checksums   []uint32 `protobuf:"fixed32,2,rep,packed,name=checksums,proto3" json:"checksums,omitempty"`
+	Offset      int64    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`           // offset of the chunk in the block, the chunks are aligned to the checksums
+	FrameOffset int64    `protobuf:"varint,4,opt,name=frameOffset,proto3" json:"frameOffset,omitempty"` // offset in the block of the first frame holding the range if framed, set in the first chunk
+	FrameEnd    int64    `protobuf:"varint,5,opt,name=frameEnd,proto3" json:"frameEnd,omitempty"`       // end in the block of the last frame holding the range
+	FrameStart  int64    `protobuf:"varint,6,opt,name=frameStart,proto3" json:"frameStart,omitempty"`   // offset of the data of the first frame in the data of the block
+}
+
+func (x *GetFileBlockReply) Reset() {
+	*x = GetFileBlockReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataserver_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFileBlockReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileBlockReply) ProtoMessage() {}
+
+func (x *GetFileBlockReply) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileBlockReply.ProtoReflect.Descriptor instead.
+func (*GetFileBlockReply) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetFileBlockReply) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *GetFileBlockReply) GetChecksums() []uint32 {
+	if x != nil {
+		return x.Checksums
+	}
+	return nil
+}
+
+func (x *GetFileBlockReply) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetFileBlockReply) GetFrameOffset() int64 {
+	if x != nil {
+		return x.FrameOffset
+	}
+	return 0
+}
+
+func (x *GetFileBlockReply) GetFrameEnd() int64 {
+	if x != nil {
+		return x.FrameEnd
+	}
+	return 0
+}
+
+func (x *GetFileBlockReply) GetFrameStart() int64 {
+	if x != nil {
+		return x.FrameStart
+	}
+	return 0
+}
+
+type PutFileBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileName   string   `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	BlockID    int64    `protobuf:"varint,2,opt,name=blockID,proto3" json:"blockID,omitempty"`
+	Chunk      []byte   `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Checksums  []uint32 `protobuf:"fixed32,4,rep,packed,name=checksums,proto3" json:"checksums,omitempty"`
+	Pipeline   []string `protobuf:"bytes,5,rep,name=pipeline,proto3" json:"pipeline,omitempty"` // data servers to forward the block to in order, set in the first chunk
+	Generation int64    `protobuf:"varint,6,opt,name=generation,proto3" json:"generation,omitempty"`
+}
+
+func (x *PutFileBlockRequest) Reset() {
+	*x = PutFileBlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataserver_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutFileBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutFileBlockRequest) ProtoMessage() {}
+
+func (x *PutFileBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutFileBlockRequest.ProtoReflect.Descriptor instead.
+func (*PutFileBlockRequest) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PutFileBlockRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *PutFileBlockRequest) GetBlockID() int64 {
+	if x != nil {
+		return x.BlockID
+	}
+	return 0
+}
+
+func (x *PutFileBlockRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *PutFileBlockRequest) GetChecksums() []uint32 {
+	if x != nil {
+		return x.Checksums
+	}
+	return nil
+}
+
+func (x *PutFileBlockRequest) GetPipeline() []string {
+	if x != nil {
+		return x.Pipeline
+	}
+	return nil
+}
+
+func (x *PutFileBlockRequest) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+type PutFileBlockReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool  `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Replicas int32 `protobuf:"varint,2,opt,name=replicas,proto3" json:"replicas,omitempty"` // number of data servers in the pipeline which stored the block, starting from the receiver
+}
+
+func (x *PutFileBlockReply) Reset() {
+	*x = PutFileBlockReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataserver_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutFileBlockReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutFileBlockReply) ProtoMessage() {}
+
+func (x *PutFileBlockReply) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutFileBlockReply.ProtoReflect.Descriptor instead.
+func (*PutFileBlockReply) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PutFileBlockReply) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *PutFileBlockReply) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+type AppendRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileName       string `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	BlockID        int64  `protobuf:"varint,2,opt,name=blockID,proto3" json:"blockID,omitempty"`
+	Generation     int64  `protobuf:"varint,3,opt,name=generation,proto3" json:"generation,omitempty"`
+	Offset         int64  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"` // offset of the record in the block, the records before it are appended first
+	Chunk          []byte `protobuf:"bytes,5,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	BaseGeneration int64  `protobuf:"varint,6,opt,name=baseGeneration,proto3" json:"baseGeneration,omitempty"` // the block is created from the first baseSize bytes of this generation if it does not exist
+	BaseSize       int64  `protobuf:"varint,7,opt,name=baseSize,proto3" json:"baseSize,omitempty"`
+}
+
+func (x *AppendRecordRequest) Reset() {
+	*x = AppendRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataserver_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppendRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendRecordRequest) ProtoMessage() {}
+
+func (x *AppendRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendRecordRequest.ProtoReflect.Descriptor instead.
+func (*AppendRecordRequest) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AppendRecordRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *AppendRecordRequest) GetBlockID() int64 {
+	if x != nil {
+		return x.BlockID
+	}
+	return 0
+}
+
+func (x *AppendRecordRequest) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *AppendRecordRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *AppendRecordRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *AppendRecordRequest) GetBaseGeneration() int64 {
+	if x != nil {
+		return x.BaseGeneration
+	}
+	return 0
+}
+
+func (x *AppendRecordRequest) GetBaseSize() int64 {
+	if x != nil {
+		return x.BaseSize
+	}
+	return 0
+}
+
+type AppendRecordReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AppendRecordReply) Reset() {
+	*x = AppendRecordReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataserver_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppendRecordReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendRecordReply) ProtoMessage() {}
+
+func (x *AppendRecordReply) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendRecordReply.ProtoReflect.Descriptor instead.
+func (*AppendRecordReply) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{5}
+}
+
+type ReplicateFileBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileName   string `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	BlockID    int64  `protobuf:"varint,2,opt,name=blockID,proto3" json:"blockID,omitempty"`
+	To         string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Generation int64  `protobuf:"varint,4,opt,name=generation,proto3" json:"generation,omitempty"`
+}
+
+func (x *ReplicateFileBlockRequest) Reset() {
+	*x = ReplicateFileBlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataserver_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicateFileBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicateFileBlockRequest) ProtoMessage() {}
+
+func (x *ReplicateFileBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicateFileBlockRequest.ProtoReflect.Descriptor instead.
+func (*ReplicateFileBlockRequest) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReplicateFileBlockRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ReplicateFileBlockRequest) GetBlockID() int64 {
+	if x != nil {
+		return x.BlockID
+	}
+	return 0
+}
+
+func (x *ReplicateFileBlockRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ReplicateFileBlockRequest) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+type ReplicateFileBlockReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReplicateFileBlockReply) Reset() {
+	*x = ReplicateFileBlockReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataserver_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicateFileBlockReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicateFileBlockReply) ProtoMessage() {}
+
+func (x *ReplicateFileBlockReply) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicateFileBlockReply.ProtoReflect.Descriptor instead.
+func (*ReplicateFileBlockReply) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{7}
+}
+
+type StripeBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileName   string   `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	BlockID    int64    `protobuf:"varint,2,opt,name=blockID,proto3" json:"blockID,omitempty"`
+	Generation int64    `protobuf:"varint,3,opt,name=generation,proto3" json:"generation,omitempty"`
+	BlockSize  int64    `protobuf:"varint,4,opt,name=blockSize,proto3" json:"blockSize,omitempty"`
+	HostNames  []string `protobuf:"bytes,5,rep,name=hostNames,proto3" json:"hostNames,omitempty"` // empty if the block is lost, or beyond the end of the file if blockSize is 0
+}
+
+func (x *StripeBlock) Reset() {
+	*x = StripeBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataserver_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StripeBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StripeBlock) ProtoMessage() {}
+
+func (x *StripeBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StripeBlock.ProtoReflect.Descriptor instead.
+func (*StripeBlock) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StripeBlock) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *StripeBlock) GetBlockID() int64 {
+	if x != nil {
+		return x.BlockID
+	}
+	return 0
+}
+
+func (x *StripeBlock) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *StripeBlock) GetBlockSize() int64 {
+	if x != nil {
+		return x.BlockSize
+	}
+	return 0
+}
+
+func (x *StripeBlock) GetHostNames() []string {
+	if x != nil {
+		return x.HostNames
+	}
+	return nil
+}
+
+type ReconstructFileBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DataShards   int32          `protobuf:"varint,1,opt,name=dataShards,proto3" json:"dataShards,omitempty"`
+	ParityShards int32          `protobuf:"varint,2,opt,name=parityShards,proto3" json:"parityShards,omitempty"`
+	Blocks       []*StripeBlock `protobuf:"bytes,3,rep,name=blocks,proto3" json:"blocks,omitempty"` // data blocks followed by parity blocks of the stripe
+	Index        int32          `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`  // index of the block to be reconstructed and stored on the receiver
+}
+
+func (x *ReconstructFileBlockRequest) Reset() {
+	*x = ReconstructFileBlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataserver_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconstructFileBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconstructFileBlockRequest) ProtoMessage() {}
+
+func (x *ReconstructFileBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconstructFileBlockRequest.ProtoReflect.Descriptor instead.
+func (*ReconstructFileBlockRequest) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReconstructFileBlockRequest) GetDataShards() int32 {
+	if x != nil {
+		return x.DataShards
+	}
+	return 0
+}
+
+func (x *ReconstructFileBlockRequest) GetParityShards() int32 {
+	if x != nil {
+		return x.ParityShards
+	}
+	return 0
+}
+
+func (x *ReconstructFileBlockRequest) GetBlocks() []*StripeBlock {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *ReconstructFileBlockRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type ReconstructFileBlockReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (x *GetFileBlockReply) Reset() {
-	*x = GetFileBlockReply{}
+func (x *ReconstructFileBlockReply) Reset() {
+	*x = ReconstructFileBlockReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dataserver_proto_msgTypes[1]
+		mi := &file_dataserver_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetFileBlockReply) String() string {
+func (x *ReconstructFileBlockReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFileBlockReply) ProtoMessage() {}
+func (*ReconstructFileBlockReply) ProtoMessage() {}
 
-func (x *GetFileBlockReply) ProtoReflect() protoreflect.Message {
-	mi := &file_dataserver_proto_msgTypes[1]
+func (x *ReconstructFileBlockReply) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -110,45 +761,38 @@ func (x *GetFileBlockReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFileBlockReply.ProtoReflect.Descriptor instead.
-func (*GetFileBlockReply) Descriptor() ([]byte, []int) {
-	return file_dataserver_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *GetFileBlockReply) GetChunk() []byte {
-	if x != nil {
-		return x.Chunk
-	}
-	return nil
+// Deprecated: Use ReconstructFileBlockReply.ProtoReflect.Descriptor instead.
+func (*ReconstructFileBlockReply) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{10}
 }
 
-type PutFileBlockRequest struct {
+type DeleteFileBlockRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FileName string `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
-	BlockID  int64  `protobuf:"varint,2,opt,name=blockID,proto3" json:"blockID,omitempty"`
-	Chunk    []byte `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	FileName   string `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	BlockID    int64  `protobuf:"varint,2,opt,name=blockID,proto3" json:"blockID,omitempty"`
+	Generation int64  `protobuf:"varint,3,opt,name=generation,proto3" json:"generation,omitempty"`
 }
 
-func (x *PutFileBlockRequest) Reset() {
-	*x = PutFileBlockRequest{}
+func (x *DeleteFileBlockRequest) Reset() {
+	*x = DeleteFileBlockRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dataserver_proto_msgTypes[2]
+		mi := &file_dataserver_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PutFileBlockRequest) String() string {
+func (x *DeleteFileBlockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutFileBlockRequest) ProtoMessage() {}
+func (*DeleteFileBlockRequest) ProtoMessage() {}
 
-func (x *PutFileBlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dataserver_proto_msgTypes[2]
+func (x *DeleteFileBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,57 +803,55 @@ func (x *PutFileBlockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutFileBlockRequest.ProtoReflect.Descriptor instead.
-func (*PutFileBlockRequest) Descriptor() ([]byte, []int) {
-	return file_dataserver_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use DeleteFileBlockRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFileBlockRequest) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *PutFileBlockRequest) GetFileName() string {
+func (x *DeleteFileBlockRequest) GetFileName() string {
 	if x != nil {
 		return x.FileName
 	}
 	return ""
 }
 
-func (x *PutFileBlockRequest) GetBlockID() int64 {
+func (x *DeleteFileBlockRequest) GetBlockID() int64 {
 	if x != nil {
 		return x.BlockID
 	}
 	return 0
 }
 
-func (x *PutFileBlockRequest) GetChunk() []byte {
+func (x *DeleteFileBlockRequest) GetGeneration() int64 {
 	if x != nil {
-		return x.Chunk
+		return x.Generation
 	}
-	return nil
+	return 0
 }
 
-type PutFileBlockReply struct {
+type DeleteFileBlockReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Ok bool `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
 }
 
-func (x *PutFileBlockReply) Reset() {
-	*x = PutFileBlockReply{}
+func (x *DeleteFileBlockReply) Reset() {
+	*x = DeleteFileBlockReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dataserver_proto_msgTypes[3]
+		mi := &file_dataserver_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PutFileBlockReply) String() string {
+func (x *DeleteFileBlockReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutFileBlockReply) ProtoMessage() {}
+func (*DeleteFileBlockReply) ProtoMessage() {}
 
-func (x *PutFileBlockReply) ProtoReflect() protoreflect.Message {
-	mi := &file_dataserver_proto_msgTypes[3]
+func (x *DeleteFileBlockReply) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -220,45 +862,78 @@ func (x *PutFileBlockReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutFileBlockReply.ProtoReflect.Descriptor instead.
-func (*PutFileBlockReply) Descriptor() ([]byte, []int) {
-	return file_dataserver_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use DeleteFileBlockReply.ProtoReflect.Descriptor instead.
+func (*DeleteFileBlockReply) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *PutFileBlockReply) GetOk() bool {
-	if x != nil {
-		return x.Ok
+type GetScrubStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetScrubStatusRequest) Reset() {
+	*x = GetScrubStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataserver_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-type ReplicateFileBlockRequest struct {
+func (x *GetScrubStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScrubStatusRequest) ProtoMessage() {}
+
+func (x *GetScrubStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScrubStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetScrubStatusRequest) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{13}
+}
+
+type ScrubFinding struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FileName string `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
-	BlockID  int64  `protobuf:"varint,2,opt,name=blockID,proto3" json:"blockID,omitempty"`
-	To       string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	FileName   string `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	BlockID    int64  `protobuf:"varint,2,opt,name=blockID,proto3" json:"blockID,omitempty"` // -1 if the file is not a block
+	Reason     string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	DetectedAt int64  `protobuf:"varint,4,opt,name=detectedAt,proto3" json:"detectedAt,omitempty"` // unix milliseconds
+	Reported   bool   `protobuf:"varint,5,opt,name=reported,proto3" json:"reported,omitempty"`     // reported to the leader
 }
 
-func (x *ReplicateFileBlockRequest) Reset() {
-	*x = ReplicateFileBlockRequest{}
+func (x *ScrubFinding) Reset() {
+	*x = ScrubFinding{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dataserver_proto_msgTypes[4]
+		mi := &file_dataserver_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReplicateFileBlockRequest) String() string {
+func (x *ScrubFinding) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReplicateFileBlockRequest) ProtoMessage() {}
+func (*ScrubFinding) ProtoMessage() {}
 
-func (x *ReplicateFileBlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dataserver_proto_msgTypes[4]
+func (x *ScrubFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,55 +944,78 @@ func (x *ReplicateFileBlockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReplicateFileBlockRequest.ProtoReflect.Descriptor instead.
-func (*ReplicateFileBlockRequest) Descriptor() ([]byte, []int) {
-	return file_dataserver_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ScrubFinding.ProtoReflect.Descriptor instead.
+func (*ScrubFinding) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ReplicateFileBlockRequest) GetFileName() string {
+func (x *ScrubFinding) GetFileName() string {
 	if x != nil {
 		return x.FileName
 	}
 	return ""
 }
 
-func (x *ReplicateFileBlockRequest) GetBlockID() int64 {
+func (x *ScrubFinding) GetBlockID() int64 {
 	if x != nil {
 		return x.BlockID
 	}
 	return 0
 }
 
-func (x *ReplicateFileBlockRequest) GetTo() string {
+func (x *ScrubFinding) GetReason() string {
 	if x != nil {
-		return x.To
+		return x.Reason
 	}
 	return ""
 }
 
-type ReplicateFileBlockReply struct {
+func (x *ScrubFinding) GetDetectedAt() int64 {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return 0
+}
+
+func (x *ScrubFinding) GetReported() bool {
+	if x != nil {
+		return x.Reported
+	}
+	return false
+}
+
+type GetScrubStatusReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Running       bool            `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Passes        int64           `protobuf:"varint,2,opt,name=passes,proto3" json:"passes,omitempty"`         // completed scrub passes
+	StartedAt     int64           `protobuf:"varint,3,opt,name=startedAt,proto3" json:"startedAt,omitempty"`   // unix milliseconds of the start of the current or last pass
+	FinishedAt    int64           `protobuf:"varint,4,opt,name=finishedAt,proto3" json:"finishedAt,omitempty"` // unix milliseconds of the end of the last pass
+	ScannedBlocks int64           `protobuf:"varint,5,opt,name=scannedBlocks,proto3" json:"scannedBlocks,omitempty"`
+	TotalBlocks   int64           `protobuf:"varint,6,opt,name=totalBlocks,proto3" json:"totalBlocks,omitempty"`
+	ScannedBytes  int64           `protobuf:"varint,7,opt,name=scannedBytes,proto3" json:"scannedBytes,omitempty"`
+	Findings      []*ScrubFinding `protobuf:"bytes,8,rep,name=findings,proto3" json:"findings,omitempty"`
 }
 
-func (x *ReplicateFileBlockReply) Reset() {
-	*x = ReplicateFileBlockReply{}
+func (x *GetScrubStatusReply) Reset() {
+	*x = GetScrubStatusReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dataserver_proto_msgTypes[5]
+		mi := &file_dataserver_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReplicateFileBlockReply) String() string {
+func (x *GetScrubStatusReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReplicateFileBlockReply) ProtoMessage() {}
+func (*GetScrubStatusReply) ProtoMessage() {}
 
-func (x *ReplicateFileBlockReply) ProtoReflect() protoreflect.Message {
-	mi := &file_dataserver_proto_msgTypes[5]
+func (x *GetScrubStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_dataserver_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,62 +1026,243 @@ func (x *ReplicateFileBlockReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReplicateFileBlockReply.ProtoReflect.Descriptor instead.
-func (*ReplicateFileBlockReply) Descriptor() ([]byte, []int) {
-	return file_dataserver_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetScrubStatusReply.ProtoReflect.Descriptor instead.
+func (*GetScrubStatusReply) Descriptor() ([]byte, []int) {
+	return file_dataserver_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetScrubStatusReply) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *GetScrubStatusReply) GetPasses() int64 {
+	if x != nil {
+		return x.Passes
+	}
+	return 0
+}
+
+func (x *GetScrubStatusReply) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *GetScrubStatusReply) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *GetScrubStatusReply) GetScannedBlocks() int64 {
+	if x != nil {
+		return x.ScannedBlocks
+	}
+	return 0
+}
+
+func (x *GetScrubStatusReply) GetTotalBlocks() int64 {
+	if x != nil {
+		return x.TotalBlocks
+	}
+	return 0
+}
+
+func (x *GetScrubStatusReply) GetScannedBytes() int64 {
+	if x != nil {
+		return x.ScannedBytes
+	}
+	return 0
+}
+
+func (x *GetScrubStatusReply) GetFindings() []*ScrubFinding {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
 }
 
 var File_dataserver_proto protoreflect.FileDescriptor
 
 var file_dataserver_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x4b,
-	0x0a, 0x13, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x22, 0x29, 0x0a, 0x11, 0x47,
-	0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x61, 0x0a, 0x13, 0x50, 0x75, 0x74, 0x46, 0x69, 0x6c,
-	0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f,
-	0x63, 0x6b, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63,
-	0x6b, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x23, 0x0a, 0x11, 0x50, 0x75, 0x74,
-	0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e,
-	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x22, 0x61,
-	0x0a, 0x19, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x42,
+	0x74, 0x6f, 0x12, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0xb3,
+	0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x72,
+	0x61, 0x6d, 0x65, 0x64, 0x22, 0xbd, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x07, 0x52, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x4f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x66, 0x72, 0x61,
+	0x6d, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x45, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x45, 0x6e, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x22, 0xbb, 0x01, 0x0a, 0x13, 0x50, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x07, 0x52, 0x09, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x3f, 0x0a, 0x11, 0x50, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x73, 0x22, 0xdd, 0x01, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49,
+	0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12,
+	0x26, 0x0a, 0x0e, 0x62, 0x61, 0x73, 0x65, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x62, 0x61, 0x73, 0x65, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x81, 0x01, 0x0a, 0x19, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02,
+	0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1e, 0x0a, 0x0a,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x19, 0x0a, 0x17,
+	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x9f, 0x01, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69,
+	0x70, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x1e, 0x0a,
+	0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x68,
+	0x6f, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x68, 0x6f, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0xa8, 0x01, 0x0a, 0x1b, 0x52, 0x65,
+	0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x61, 0x74,
+	0x61, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x64,
+	0x61, 0x74, 0x61, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x61, 0x72,
+	0x69, 0x74, 0x79, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x70, 0x61, 0x72, 0x69, 0x74, 0x79, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73, 0x12, 0x2f, 0x0a,
+	0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x70,
+	0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x22, 0x1b, 0x0a, 0x19, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x6e, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x42,
 	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66,
 	0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66,
 	0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
 	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49,
-	0x44, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74,
-	0x6f, 0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x46, 0x69,
-	0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x32, 0x98, 0x02, 0x0a,
-	0x0a, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x52, 0x0a, 0x0c, 0x47,
-	0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1f, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c,
-	0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12,
-	0x52, 0x0a, 0x0c, 0x50, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
-	0x1f, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x75, 0x74,
-	0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x75,
-	0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x28, 0x01, 0x12, 0x62, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65,
-	0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x25, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65,
-	0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x23, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x52, 0x65,
-	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x6c, 0x61,
-	0x62, 0x2e, 0x65, 0x6e, 0x67, 0x72, 0x2e, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x6f, 0x69, 0x73, 0x2e,
-	0x65, 0x64, 0x75, 0x2f, 0x63, 0x6b, 0x63, 0x68, 0x75, 0x32, 0x2f, 0x63, 0x73, 0x34, 0x32, 0x35,
-	0x2d, 0x6d, 0x70, 0x33, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x64, 0x61,
-	0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x16, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x17, 0x0a, 0x15, 0x47, 0x65, 0x74,
+	0x53, 0x63, 0x72, 0x75, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x98, 0x01, 0x0a, 0x0c, 0x53, 0x63, 0x72, 0x75, 0x62, 0x46, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x22, 0xa7, 0x02,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x53, 0x63, 0x72, 0x75, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x70, 0x61, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
+	0x64, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73,
+	0x68, 0x65, 0x64, 0x41, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x64,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x63,
+	0x61, 0x6e, 0x6e, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x22, 0x0a,
+	0x0c, 0x73, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x12, 0x34, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x53, 0x63, 0x72, 0x75, 0x62, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x66,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x32, 0x89, 0x05, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x52, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c,
+	0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1f, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x52, 0x0a, 0x0c, 0x50, 0x75,
+	0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1f, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x52,
+	0x0a, 0x0c, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1f,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x65,
+	0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70,
+	0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x28, 0x01, 0x12, 0x62, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x46,
+	0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x25, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x46,
+	0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x23, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x68, 0x0a, 0x14, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x73,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x27,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x59, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x22, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x0e, 0x47,
+	0x65, 0x74, 0x53, 0x63, 0x72, 0x75, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x63,
+	0x72, 0x75, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x63, 0x72, 0x75, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x65, 0x6e,
+	0x67, 0x72, 0x2e, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x6f, 0x69, 0x73, 0x2e, 0x65, 0x64, 0x75, 0x2f,
+	0x63, 0x6b, 0x63, 0x68, 0x75, 0x32, 0x2f, 0x63, 0x73, 0x34, 0x32, 0x35, 0x2d, 0x6d, 0x70, 0x34,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -398,27 +1277,47 @@ func file_dataserver_proto_rawDescGZIP() []byte {
 	return file_dataserver_proto_rawDescData
 }
 
-var file_dataserver_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_dataserver_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_dataserver_proto_goTypes = []interface{}{
-	(*GetFileBlockRequest)(nil),       // 0: dataserver.GetFileBlockRequest
-	(*GetFileBlockReply)(nil),         // 1: dataserver.GetFileBlockReply
-	(*PutFileBlockRequest)(nil),       // 2: dataserver.PutFileBlockRequest
-	(*PutFileBlockReply)(nil),         // 3: dataserver.PutFileBlockReply
-	(*ReplicateFileBlockRequest)(nil), // 4: dataserver.ReplicateFileBlockRequest
-	(*ReplicateFileBlockReply)(nil),   // 5: dataserver.ReplicateFileBlockReply
+	(*GetFileBlockRequest)(nil),         // 0: dataserver.GetFileBlockRequest
+	(*GetFileBlockReply)(nil),           // 1: dataserver.GetFileBlockReply
+	(*PutFileBlockRequest)(nil),         // 2: dataserver.PutFileBlockRequest
+	(*PutFileBlockReply)(nil),           // 3: dataserver.PutFileBlockReply
+	(*AppendRecordRequest)(nil),         // 4: dataserver.AppendRecordRequest
+	(*AppendRecordReply)(nil),           // 5: dataserver.AppendRecordReply
+	(*ReplicateFileBlockRequest)(nil),   // 6: dataserver.ReplicateFileBlockRequest
+	(*ReplicateFileBlockReply)(nil),     // 7: dataserver.ReplicateFileBlockReply
+	(*StripeBlock)(nil),                 // 8: dataserver.StripeBlock
+	(*ReconstructFileBlockRequest)(nil), // 9: dataserver.ReconstructFileBlockRequest
+	(*ReconstructFileBlockReply)(nil),   // 10: dataserver.ReconstructFileBlockReply
+	(*DeleteFileBlockRequest)(nil),      // 11: dataserver.DeleteFileBlockRequest
+	(*DeleteFileBlockReply)(nil),        // 12: dataserver.DeleteFileBlockReply
+	(*GetScrubStatusRequest)(nil),       // 13: dataserver.GetScrubStatusRequest
+	(*ScrubFinding)(nil),                // 14: dataserver.ScrubFinding
+	(*GetScrubStatusReply)(nil),         // 15: dataserver.GetScrubStatusReply
 }
 var file_dataserver_proto_depIdxs = []int32{
-	0, // 0: dataserver.DataServer.GetFileBlock:input_type -> dataserver.GetFileBlockRequest
-	2, // 1: dataserver.DataServer.PutFileBlock:input_type -> dataserver.PutFileBlockRequest
-	4, // 2: dataserver.DataServer.ReplicateFileBlock:input_type -> dataserver.ReplicateFileBlockRequest
-	1, // 3: dataserver.DataServer.GetFileBlock:output_type -> dataserver.GetFileBlockReply
-	3, // 4: dataserver.DataServer.PutFileBlock:output_type -> dataserver.PutFileBlockReply
-	5, // 5: dataserver.DataServer.ReplicateFileBlock:output_type -> dataserver.ReplicateFileBlockReply
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	8,  // 0: dataserver.ReconstructFileBlockRequest.blocks:type_name -> dataserver.StripeBlock
+	14, // 1: dataserver.GetScrubStatusReply.findings:type_name -> dataserver.ScrubFinding
+	0,  // 2: dataserver.DataServer.GetFileBlock:input_type -> dataserver.GetFileBlockRequest
+	2,  // 3: dataserver.DataServer.PutFileBlock:input_type -> dataserver.PutFileBlockRequest
+	4,  // 4: dataserver.DataServer.AppendRecord:input_type -> dataserver.AppendRecordRequest
+	6,  // 5: dataserver.DataServer.ReplicateFileBlock:input_type -> dataserver.ReplicateFileBlockRequest
+	9,  // 6: dataserver.DataServer.ReconstructFileBlock:input_type -> dataserver.ReconstructFileBlockRequest
+	11, // 7: dataserver.DataServer.DeleteFileBlock:input_type -> dataserver.DeleteFileBlockRequest
+	13, // 8: dataserver.DataServer.GetScrubStatus:input_type -> dataserver.GetScrubStatusRequest
+	1,  // 9: dataserver.DataServer.GetFileBlock:output_type -> dataserver.GetFileBlockReply
+	3,  // 10: dataserver.DataServer.PutFileBlock:output_type -> dataserver.PutFileBlockReply
+	5,  // 11: dataserver.DataServer.AppendRecord:output_type -> dataserver.AppendRecordReply
+	7,  // 12: dataserver.DataServer.ReplicateFileBlock:output_type -> dataserver.ReplicateFileBlockReply
+	10, // 13: dataserver.DataServer.ReconstructFileBlock:output_type -> dataserver.ReconstructFileBlockReply
+	12, // 14: dataserver.DataServer.DeleteFileBlock:output_type -> dataserver.DeleteFileBlockReply
+	15, // 15: dataserver.DataServer.GetScrubStatus:output_type -> dataserver.GetScrubStatusReply
+	9,  // [9:16] is the sub-list for method output_type
+	2,  // [2:9] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_dataserver_proto_init() }
@@ -476,7 +1375,7 @@ func file_dataserver_proto_init() {
 			}
 		}
 		file_dataserver_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReplicateFileBlockRequest); i {
+			switch v := v.(*AppendRecordRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -488,6 +1387,30 @@ func file_dataserver_proto_init() {
 			}
 		}
 		file_dataserver_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppendRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataserver_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicateFileBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataserver_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReplicateFileBlockReply); i {
 			case 0:
 				return &v.state
@@ -499,6 +1422,102 @@ func file_dataserver_proto_init() {
 				return nil
 			}
 		}
+		file_dataserver_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StripeBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataserver_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconstructFileBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataserver_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconstructFileBlockReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataserver_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteFileBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataserver_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteFileBlockReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataserver_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetScrubStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataserver_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScrubFinding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataserver_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetScrubStatusReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -506,7 +1525,7 @@ func file_dataserver_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_dataserver_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
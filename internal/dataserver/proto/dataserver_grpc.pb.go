@@ -24,7 +24,11 @@ const _ = grpc.SupportPackageIsVersion7
 type DataServerClient interface {
 	GetFileBlock(ctx context.Context, in *GetFileBlockRequest, opts ...grpc.CallOption) (DataServer_GetFileBlockClient, error)
 	PutFileBlock(ctx context.Context, opts ...grpc.CallOption) (DataServer_PutFileBlockClient, error)
+	AppendRecord(ctx context.Context, opts ...grpc.CallOption) (DataServer_AppendRecordClient, error)
 	ReplicateFileBlock(ctx context.Context, in *ReplicateFileBlockRequest, opts ...grpc.CallOption) (*ReplicateFileBlockReply, error)
+	ReconstructFileBlock(ctx context.Context, in *ReconstructFileBlockRequest, opts ...grpc.CallOption) (*ReconstructFileBlockReply, error)
+	DeleteFileBlock(ctx context.Context, in *DeleteFileBlockRequest, opts ...grpc.CallOption) (*DeleteFileBlockReply, error)
+	GetScrubStatus(ctx context.Context, in *GetScrubStatusRequest, opts ...grpc.CallOption) (*GetScrubStatusReply, error)
 }
 
 type dataServerClient struct {
@@ -101,6 +105,40 @@ func (x *dataServerPutFileBlockClient) CloseAndRecv() (*PutFileBlockReply, error
 	return m, nil
 }
 
+func (c *dataServerClient) AppendRecord(ctx context.Context, opts ...grpc.CallOption) (DataServer_AppendRecordClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DataServer_ServiceDesc.Streams[2], "/dataserver.DataServer/AppendRecord", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dataServerAppendRecordClient{stream}
+	return x, nil
+}
+
+type DataServer_AppendRecordClient interface {
+	Send(*AppendRecordRequest) error
+	CloseAndRecv() (*AppendRecordReply, error)
+	grpc.ClientStream
+}
+
+type dataServerAppendRecordClient struct {
+	grpc.ClientStream
+}
+
+func (x *dataServerAppendRecordClient) Send(m *AppendRecordRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *dataServerAppendRecordClient) CloseAndRecv() (*AppendRecordReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(AppendRecordReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *dataServerClient) ReplicateFileBlock(ctx context.Context, in *ReplicateFileBlockRequest, opts ...grpc.CallOption) (*ReplicateFileBlockReply, error) {
 	out := new(ReplicateFileBlockReply)
 	err := c.cc.Invoke(ctx, "/dataserver.DataServer/ReplicateFileBlock", in, out, opts...)
@@ -110,13 +148,44 @@ func (c *dataServerClient) ReplicateFileBlock(ctx context.Context, in *Replicate
 	return out, nil
 }
 
+func (c *dataServerClient) ReconstructFileBlock(ctx context.Context, in *ReconstructFileBlockRequest, opts ...grpc.CallOption) (*ReconstructFileBlockReply, error) {
+	out := new(ReconstructFileBlockReply)
+	err := c.cc.Invoke(ctx, "/dataserver.DataServer/ReconstructFileBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServerClient) DeleteFileBlock(ctx context.Context, in *DeleteFileBlockRequest, opts ...grpc.CallOption) (*DeleteFileBlockReply, error) {
+	out := new(DeleteFileBlockReply)
+	err := c.cc.Invoke(ctx, "/dataserver.DataServer/DeleteFileBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServerClient) GetScrubStatus(ctx context.Context, in *GetScrubStatusRequest, opts ...grpc.CallOption) (*GetScrubStatusReply, error) {
+	out := new(GetScrubStatusReply)
+	err := c.cc.Invoke(ctx, "/dataserver.DataServer/GetScrubStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataServerServer is the server API for DataServer service.
 // All implementations must embed UnimplementedDataServerServer
 // for forward compatibility
 type DataServerServer interface {
 	GetFileBlock(*GetFileBlockRequest, DataServer_GetFileBlockServer) error
 	PutFileBlock(DataServer_PutFileBlockServer) error
+	AppendRecord(DataServer_AppendRecordServer) error
 	ReplicateFileBlock(context.Context, *ReplicateFileBlockRequest) (*ReplicateFileBlockReply, error)
+	ReconstructFileBlock(context.Context, *ReconstructFileBlockRequest) (*ReconstructFileBlockReply, error)
+	DeleteFileBlock(context.Context, *DeleteFileBlockRequest) (*DeleteFileBlockReply, error)
+	GetScrubStatus(context.Context, *GetScrubStatusRequest) (*GetScrubStatusReply, error)
 	mustEmbedUnimplementedDataServerServer()
 }
 
@@ -130,9 +199,21 @@ func (UnimplementedDataServerServer) GetFileBlock(*GetFileBlockRequest, DataServ
 func (UnimplementedDataServerServer) PutFileBlock(DataServer_PutFileBlockServer) error {
 	return status.Errorf(codes.Unimplemented, "method PutFileBlock not implemented")
 }
+func (UnimplementedDataServerServer) AppendRecord(DataServer_AppendRecordServer) error {
+	return status.Errorf(codes.Unimplemented, "method AppendRecord not implemented")
+}
 func (UnimplementedDataServerServer) ReplicateFileBlock(context.Context, *ReplicateFileBlockRequest) (*ReplicateFileBlockReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReplicateFileBlock not implemented")
 }
+func (UnimplementedDataServerServer) ReconstructFileBlock(context.Context, *ReconstructFileBlockRequest) (*ReconstructFileBlockReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconstructFileBlock not implemented")
+}
+func (UnimplementedDataServerServer) DeleteFileBlock(context.Context, *DeleteFileBlockRequest) (*DeleteFileBlockReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFileBlock not implemented")
+}
+func (UnimplementedDataServerServer) GetScrubStatus(context.Context, *GetScrubStatusRequest) (*GetScrubStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScrubStatus not implemented")
+}
 func (UnimplementedDataServerServer) mustEmbedUnimplementedDataServerServer() {}
 
 // UnsafeDataServerServer may be embedded to opt out of forward compatibility for this service.
@@ -193,6 +274,32 @@ func (x *dataServerPutFileBlockServer) Recv() (*PutFileBlockRequest, error) {
 	return m, nil
 }
 
+func _DataServer_AppendRecord_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DataServerServer).AppendRecord(&dataServerAppendRecordServer{stream})
+}
+
+type DataServer_AppendRecordServer interface {
+	SendAndClose(*AppendRecordReply) error
+	Recv() (*AppendRecordRequest, error)
+	grpc.ServerStream
+}
+
+type dataServerAppendRecordServer struct {
+	grpc.ServerStream
+}
+
+func (x *dataServerAppendRecordServer) SendAndClose(m *AppendRecordReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *dataServerAppendRecordServer) Recv() (*AppendRecordRequest, error) {
+	m := new(AppendRecordRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _DataServer_ReplicateFileBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReplicateFileBlockRequest)
 	if err := dec(in); err != nil {
@@ -211,6 +318,60 @@ func _DataServer_ReplicateFileBlock_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataServer_ReconstructFileBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconstructFileBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServerServer).ReconstructFileBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dataserver.DataServer/ReconstructFileBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServerServer).ReconstructFileBlock(ctx, req.(*ReconstructFileBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataServer_DeleteFileBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFileBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServerServer).DeleteFileBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dataserver.DataServer/DeleteFileBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServerServer).DeleteFileBlock(ctx, req.(*DeleteFileBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataServer_GetScrubStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScrubStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServerServer).GetScrubStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dataserver.DataServer/GetScrubStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServerServer).GetScrubStatus(ctx, req.(*GetScrubStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DataServer_ServiceDesc is the grpc.ServiceDesc for DataServer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -222,6 +383,18 @@ var DataServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReplicateFileBlock",
 			Handler:    _DataServer_ReplicateFileBlock_Handler,
 		},
+		{
+			MethodName: "ReconstructFileBlock",
+			Handler:    _DataServer_ReconstructFileBlock_Handler,
+		},
+		{
+			MethodName: "DeleteFileBlock",
+			Handler:    _DataServer_DeleteFileBlock_Handler,
+		},
+		{
+			MethodName: "GetScrubStatus",
+			Handler:    _DataServer_GetScrubStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -234,6 +407,11 @@ var DataServer_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _DataServer_PutFileBlock_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "AppendRecord",
+			Handler:       _DataServer_AppendRecord_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "dataserver.proto",
 }
@@ -3,45 +3,73 @@ package dataserver
 import (
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/sirupsen/logrus"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// PutFileBlock streams a file block into a temp file and verifies each chunk against the checksums computed by the sender,
+// the block is replaced only if all chunks are received. If the sender sets a pipeline, the chunks are also forwarded to the
+// next data server, and the reply counts the data servers of the pipeline which stored the block.
 func (ds *DataServer) PutFileBlock(stream pb.DataServer_PutFileBlockServer) error {
-	var fileName string
-	var blockID int64
-	buffer := make([]byte, 0)
-	var fileSize int64 = 0
+	var writer *blockWriter
+	var downstream *pipeline
+	abort := func() {
+		if writer != nil {
+			writer.abort()
+		}
+		if downstream != nil {
+			downstream.abort()
+		}
+	}
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
+			abort()
 			return fmt.Errorf("failed to receive chunk from server: %v", err)
 		}
-		fileName = req.GetFileName()
-		blockID = req.GetBlockID()
+		if writer == nil {
+			writer, err = ds.newBlockWriter(req.GetFileName(), req.GetBlockID(), req.GetGeneration())
+			if err != nil {
+				return err
+			}
+			if len(req.GetPipeline()) > 0 {
+				downstream = ds.openPipeline(stream.Context(), req.GetFileName(), req.GetBlockID(), req.GetGeneration(), req.GetPipeline())
+			}
+		}
 		chunk := req.GetChunk()
-		fileSize += int64(len(chunk))
+		if err := writer.write(chunk, req.GetChecksums()); err != nil {
+			abort()
+			return err
+		}
+		if downstream != nil {
+			downstream.send(chunk, req.GetChecksums())
+		}
 		logrus.Debugf("received a chunk with size %v", len(chunk))
-		buffer = append(buffer, chunk...)
 	}
-	err := ds.writeFileBlock(fileName, blockID, buffer)
-	if err != nil {
+	if writer == nil {
+		return status.Errorf(codes.InvalidArgument, "no chunk received")
+	}
+	if err := writer.commit(); err != nil {
+		if downstream != nil {
+			downstream.abort()
+		}
 		return err
 	}
-	logrus.Infof("received file %s block %d with size %d", fileName, blockID, fileSize)
-	return stream.SendAndClose(&pb.PutFileBlockReply{Ok: true})
-}
-
-func (ds *DataServer) writeFileBlock(fileName string, blockID int64, data []byte) error {
-	filePath := ds.GetFilePath(fileName, blockID)
-	err := os.WriteFile(filePath, data, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write file %s: %v", filePath, err)
+	logrus.Infof("received file %s block %d with size %d", writer.fileName, writer.blockID, writer.size)
+	var replicas int32 = 1
+	if downstream != nil {
+		// the data servers after a broken one are excluded by the sender
+		downstreamReplicas, err := downstream.close()
+		if err != nil {
+			logrus.Errorf("pipeline of file %s block %d is broken at %s: %v", writer.fileName, writer.blockID, downstream.to, err)
+		}
+		replicas += downstreamReplicas
 	}
-	return nil
+	return stream.SendAndClose(&pb.PutFileBlockReply{Ok: true, Replicas: replicas})
 }
@@ -0,0 +1,120 @@
+package dataserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/checksum"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReconstructFileBlock reconstructs a lost block of a stripe from the other blocks of the stripe on the data servers
+// and stores it.
+func (ds *DataServer) ReconstructFileBlock(ctx context.Context, in *pb.ReconstructFileBlockRequest) (*pb.ReconstructFileBlockReply, error) {
+	policy := erasure.Policy{DataShards: int(in.GetDataShards()), ParityShards: int(in.GetParityShards())}
+	blocks := in.GetBlocks()
+	index := int(in.GetIndex())
+	if !policy.IsErasureCoded() || len(blocks) != policy.Shards() || index < 0 || index >= len(blocks) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid stripe of %d blocks to reconstruct block %d", len(blocks), index)
+	}
+	lost := blocks[index]
+	shards := make([][]byte, len(blocks))
+	var shardSize int64 = 0
+	available := 0
+	for i, block := range blocks {
+		if block.GetBlockSize() > shardSize {
+			shardSize = block.GetBlockSize()
+		}
+		// a data block beyond the end of the file is zeros
+		if i != index && block.GetFileName() == "" {
+			shards[i] = []byte{}
+			available++
+		}
+	}
+	// only DataShards blocks are fetched
+	for i, block := range blocks {
+		if available >= policy.DataShards {
+			break
+		}
+		if i == index || block.GetFileName() == "" {
+			continue
+		}
+		for _, hostName := range block.GetHostNames() {
+			data, err := ds.fetchFileBlock(hostName, block.GetFileName(), block.GetBlockID(), block.GetGeneration())
+			if err != nil {
+				logrus.Warnf("failed to get file %s block %d from %s: %v", block.GetFileName(), block.GetBlockID(), hostName, err)
+				continue
+			}
+			shards[i] = data
+			available++
+			break
+		}
+	}
+	if err := policy.Reconstruct(shards, int(shardSize)); err != nil {
+		return nil, fmt.Errorf("failed to reconstruct file %s block %d: %v", lost.GetFileName(), lost.GetBlockID(), err)
+	}
+	data := shards[index][:lost.GetBlockSize()]
+
+	writer, err := ds.newBlockWriter(lost.GetFileName(), lost.GetBlockID(), lost.GetGeneration())
+	if err != nil {
+		return nil, err
+	}
+	for offset := 0; offset < len(data); offset += CHUNK_SIZE {
+		end := offset + CHUNK_SIZE
+		if end > len(data) {
+			end = len(data)
+		}
+		if err := writer.write(data[offset:end], nil); err != nil {
+			writer.abort()
+			return nil, err
+		}
+	}
+	if err := writer.commit(); err != nil {
+		return nil, err
+	}
+	logrus.Infof("reconstructed file %s block %d with size %d", lost.GetFileName(), lost.GetBlockID(), len(data))
+	return &pb.ReconstructFileBlockReply{}, nil
+}
+
+// fetchFileBlock gets a block from another data server and verifies the checksums of each chunk.
+func (ds *DataServer) fetchFileBlock(hostName, fileName string, blockID int64, generation int64) ([]byte, error) {
+	conn, err := connpool.Get(hostName + ":" + ds.port)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %v", hostName, err)
+	}
+	defer conn.Close()
+
+	client := pb.NewDataServerClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+	stream, err := client.GetFileBlock(ctx, &pb.GetFileBlockRequest{FileName: fileName, BlockID: blockID, Generation: generation})
+	if err != nil {
+		return nil, err
+	}
+	data := []byte{}
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		chunk := reply.GetChunk()
+		// a block written without checksums is not verified
+		if checksums := reply.GetChecksums(); len(checksums) > 0 {
+			if err := checksum.Verify(chunk, checksums); err != nil {
+				return nil, fmt.Errorf("received corrupted chunk at offset %d: %w", len(data), err)
+			}
+		}
+		data = append(data, chunk...)
+	}
+	return data, nil
+}
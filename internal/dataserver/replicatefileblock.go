@@ -3,31 +3,23 @@ package dataserver
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
-
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // ReplicateFileBlock replicates a file block to another data server
 func (ds *DataServer) ReplicateFileBlock(ctx context.Context, in *pb.ReplicateFileBlockRequest) (*pb.ReplicateFileBlockReply, error) {
-	return &pb.ReplicateFileBlockReply{}, ds.replicateFileBlock(in.GetFileName(), in.GetBlockID(), in.GetTo())
+	return &pb.ReplicateFileBlockReply{}, ds.replicateFileBlock(in.GetFileName(), in.GetBlockID(), in.GetGeneration(), in.GetTo())
 }
 
-func (ds *DataServer) replicateFileBlock(fileName string, blockID int64, to string) error {
-	data, err := os.ReadFile(ds.GetFilePath(fileName, blockID))
-	if err != nil {
-		return err
-	}
-	conn, err := grpc.Dial(to+":"+ds.port, []grpc.DialOption{
-		grpc.WithInitialWindowSize(1024 * 1024 * 1024),
-		grpc.WithInitialConnWindowSize(1024 * 1024 * 1024),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	}...)
+// replicateFileBlock streams a file block with its checksums from disk to another data server,
+// the replication is aborted if a chunk is corrupted.
+func (ds *DataServer) replicateFileBlock(fileName string, blockID int64, generation int64, to string) error {
+	conn, err := connpool.Get(to + ":" + ds.port)
 
 	if err != nil {
 		return fmt.Errorf("failed to connect to %s: %v", to, err)
@@ -41,24 +33,20 @@ func (ds *DataServer) replicateFileBlock(fileName string, blockID int64, to stri
 	if err != nil {
 		return err
 	}
-	var fileSize int64 = 0
-	for {
-		if len(data) == 0 {
-			break
-		}
-		chunk := []byte(data)
-		if len(chunk) > CHUNK_SIZE {
-			chunk = chunk[:CHUNK_SIZE]
-		}
-		if err := stream.Send(&pb.PutFileBlockRequest{
-			FileName: fileName,
-			BlockID:  blockID,
-			Chunk:    chunk,
-		}); err != nil {
-			return err
-		}
-		fileSize += int64(len(chunk))
-		data = data[len(chunk):]
+	fileSize, err := ds.readFileBlock(fileName, blockID, generation, func(chunk []byte, checksums []uint32) error {
+		return stream.Send(&pb.PutFileBlockRequest{
+			FileName:   fileName,
+			BlockID:    blockID,
+			Chunk:      chunk,
+			Checksums:  checksums,
+			Generation: generation,
+		})
+	})
+	// io.EOF means the receiver closed the stream, its error is returned by CloseAndRecv
+	if err != nil && err != io.EOF {
+		// the stream is canceled on return, the receiver drops the partial block
+		logrus.Errorf("failed to replicate file %s block %d: %v", fileName, blockID, err)
+		return err
 	}
 	_, err = stream.CloseAndRecv()
 	if err != nil {
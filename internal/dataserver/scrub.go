@@ -0,0 +1,251 @@
+package dataserver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+	"google.golang.org/grpc/status"
+)
+
+// defaultScrubBandwidth is used if the scrub bandwidth is not set in config.
+const defaultScrubBandwidth = 10 * 1024 * 1024
+
+// Scrubber keeps the progress and findings of verifying the blocks in blocksDir.
+type Scrubber struct {
+	running       bool
+	passes        int64
+	startedAt     time.Time
+	finishedAt    time.Time
+	scannedBlocks int64
+	totalBlocks   int64
+	scannedBytes  int64
+	findings      map[string]*pb.ScrubFinding // map[file name in blocksDir]finding
+	mu            sync.Mutex
+}
+
+// NewScrubber returns a new Scrubber
+func NewScrubber() *Scrubber {
+	return &Scrubber{
+		findings: map[string]*pb.ScrubFinding{},
+		mu:       sync.Mutex{},
+	}
+}
+
+// GetScrubStatus returns the progress and findings of the scrubber through gRPC.
+func (ds *DataServer) GetScrubStatus(ctx context.Context, in *pb.GetScrubStatusRequest) (*pb.GetScrubStatusReply, error) {
+	s := ds.scrubber
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reply := &pb.GetScrubStatusReply{
+		Running:       s.running,
+		Passes:        s.passes,
+		ScannedBlocks: s.scannedBlocks,
+		TotalBlocks:   s.totalBlocks,
+		ScannedBytes:  s.scannedBytes,
+		Findings:      []*pb.ScrubFinding{},
+	}
+	if !s.startedAt.IsZero() {
+		reply.StartedAt = s.startedAt.UnixMilli()
+	}
+	if !s.finishedAt.IsZero() {
+		reply.FinishedAt = s.finishedAt.UnixMilli()
+	}
+	for _, finding := range s.findings {
+		reply.Findings = append(reply.Findings, finding)
+	}
+	sort.Slice(reply.Findings, func(i, j int) bool {
+		return reply.Findings[i].GetFileName() < reply.Findings[j].GetFileName()
+	})
+	return reply, nil
+}
+
+func (ds *DataServer) startScrubbing() {
+	if ds.scrubInterval <= 0 {
+		logrus.Warn("Scrub interval is not set, blocks will not be scrubbed")
+		return
+	}
+	logrus.Info("Start scrubbing blocks")
+	ds.scrubTicker = time.NewTicker(ds.scrubInterval)
+	defer ds.scrubTicker.Stop()
+	for {
+		select {
+		case <-ds.scrubTickerDone:
+			return
+		case <-ds.scrubTicker.C:
+			ds.scrubBlocks()
+		}
+	}
+}
+
+func (ds *DataServer) stopScrubbing() {
+	ds.scrubTickerDone <- true
+}
+
+// scrubBlocks verifies the checksums of all blocks in blocksDir at most scrubBandwidth bytes per second,
+// the corrupted blocks are reported to the leader to be re-replicated.
+func (ds *DataServer) scrubBlocks() {
+	entries, err := os.ReadDir(ds.blocksDir)
+	if err != nil {
+		logrus.Errorf("failed to read blocksDir %s: %v", ds.blocksDir, err)
+		return
+	}
+	startedAt := time.Now()
+	s := ds.scrubber
+	s.mu.Lock()
+	s.running = true
+	s.startedAt = startedAt
+	s.scannedBlocks = 0
+	s.totalBlocks = 0
+	s.scannedBytes = 0
+	for _, entry := range entries {
+		if !entry.IsDir() && !strings.HasSuffix(entry.Name(), CHECKSUM_FILE_EXT) && !strings.HasSuffix(entry.Name(), TEMP_FILE_EXT) {
+			s.totalBlocks++
+		}
+	}
+	s.mu.Unlock()
+	logrus.Infof("Start scrubbing %d files in blocksDir", len(entries))
+
+	var scannedBytes int64 = 0
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), TEMP_FILE_EXT) {
+			continue
+		}
+		name := entry.Name()
+		if strings.HasSuffix(name, CHECKSUM_FILE_EXT) {
+			if _, err := os.Stat(filepath.Join(ds.blocksDir, strings.TrimSuffix(name, CHECKSUM_FILE_EXT))); os.IsNotExist(err) {
+				ds.addScrubFinding(name, -1, "checksum file without block", false)
+			}
+			continue
+		}
+		fileName, blockID, generation, err := ParseFilePath(name)
+		if err != nil {
+			ds.addScrubFinding(name, -1, "unexpected file", false)
+			s.mu.Lock()
+			s.scannedBlocks++
+			s.mu.Unlock()
+			continue
+		}
+		// a block removed during the scrub is skipped
+		size, err := ds.scrubBlock(fileName, blockID, generation, startedAt, &scannedBytes)
+		if err != nil && !os.IsNotExist(err) {
+			// verify again, the block may be written during the scrub
+			time.Sleep(time.Second)
+			size, err = ds.scrubBlock(fileName, blockID, generation, startedAt, &scannedBytes)
+			if err != nil && !os.IsNotExist(err) {
+				logrus.Errorf("Scrubber found corrupted file %s block %d generation %d: %v", fileName, blockID, generation, err)
+				ds.addScrubFinding(name, blockID, status.Convert(err).Message(), ds.reportBadBlock(fileName, blockID, generation))
+			}
+		}
+		s.mu.Lock()
+		s.scannedBlocks++
+		s.scannedBytes += size
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// drop the findings of the last pass which are not found again
+	for name, finding := range s.findings {
+		if finding.GetDetectedAt() < startedAt.UnixMilli() {
+			delete(s.findings, name)
+		}
+	}
+	s.running = false
+	s.passes++
+	s.finishedAt = time.Now()
+	logrus.Infof("Scrubbed %d blocks with %d bytes in %v, found %d problems", s.scannedBlocks, s.scannedBytes, s.finishedAt.Sub(startedAt), len(s.findings))
+}
+
+// scrubBlock verifies a block against its checksums and returns the size of the block.
+// A block without checksums cannot be verified and is also reported.
+func (ds *DataServer) scrubBlock(fileName string, blockID int64, generation int64, startedAt time.Time, scannedBytes *int64) (int64, error) {
+	if _, err := os.Stat(ds.GetFilePath(fileName, blockID, generation)); err != nil {
+		return 0, err
+	}
+	checksums, err := ds.readChecksums(fileName, blockID, generation)
+	if err != nil {
+		return 0, err
+	}
+	if checksums == nil {
+		return 0, fmt.Errorf("no checksums of the block")
+	}
+	return ds.readFileBlock(fileName, blockID, generation, func(chunk []byte, _ []uint32) error {
+		*scannedBytes += int64(len(chunk))
+		ds.throttleScrub(startedAt, *scannedBytes)
+		return nil
+	})
+}
+
+// throttleScrub sleeps until reading scannedBytes since startedAt is within the scrub bandwidth.
+func (ds *DataServer) throttleScrub(startedAt time.Time, scannedBytes int64) {
+	bandwidth := ds.scrubBandwidth
+	if bandwidth <= 0 {
+		bandwidth = defaultScrubBandwidth
+	}
+	expected := time.Duration(float64(scannedBytes) / float64(bandwidth) * float64(time.Second))
+	if wait := expected - time.Since(startedAt); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// addScrubFinding records a problem found by the scrubber.
+func (ds *DataServer) addScrubFinding(name string, blockID int64, reason string, reported bool) {
+	s := ds.scrubber
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.findings[name] = &pb.ScrubFinding{
+		FileName:   name,
+		BlockID:    blockID,
+		Reason:     reason,
+		DetectedAt: time.Now().UnixMilli(),
+		Reported:   reported,
+	}
+}
+
+// removeScrubFinding drops the finding of a block which is written again or deleted.
+func (ds *DataServer) removeScrubFinding(fileName string, blockID int64, generation int64) {
+	s := ds.scrubber
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.findings, blockKey(fileName, blockID, generation))
+}
+
+// reportBadBlock tells the leader that the replica of a block on this data server is corrupted and returns whether it succeeds.
+func (ds *DataServer) reportBadBlock(fileName string, blockID int64, generation int64) bool {
+	leader, err := ds.getLeader()
+	if err != nil || leader == "" {
+		logrus.Errorf("failed to report corrupted file %s block %d: no leader: %v", fileName, blockID, err)
+		return false
+	}
+	conn, err := connpool.Get(leader + ":" + ds.leaderServerPort)
+	if err != nil {
+		logrus.Errorf("cannot connect to %s leaderServer: %v", leader, err)
+		return false
+	}
+	defer conn.Close()
+
+	client := leaderServerProto.NewLeaderServerClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	_, err = client.ReportBadBlock(ctx, &leaderServerProto.ReportBadBlockRequest{
+		FileName:   fileName,
+		BlockID:    blockID,
+		HostName:   ds.hostname,
+		Generation: generation,
+	})
+	if err != nil {
+		logrus.Errorf("failed to report corrupted file %s block %d to leader %s: %v", fileName, blockID, leader, err)
+		return false
+	}
+	return true
+}
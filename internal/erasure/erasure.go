@@ -0,0 +1,152 @@
+package erasure
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/reedsolomon"
+)
+
+// Replication is the name of the policy which stores full replicas of each block.
+const Replication = "replication"
+
+// Policy is the storage policy of a file. A file is replicated if DataShards is 0, otherwise every DataShards blocks of
+// it form a stripe with ParityShards parity blocks, and each block of a stripe is stored on a different data server.
+type Policy struct {
+	DataShards   int
+	ParityShards int
+}
+
+// ParsePolicy parses a policy in the form of "RS-<data shards>-<parity shards>", e.g. "RS-6-3", or "replication".
+// An empty policy is replication.
+func ParsePolicy(name string) (Policy, error) {
+	if name == "" || name == Replication {
+		return Policy{}, nil
+	}
+	parts := strings.Split(name, "-")
+	if len(parts) != 3 || parts[0] != "RS" {
+		return Policy{}, fmt.Errorf("invalid policy %s, expected replication or RS-<data shards>-<parity shards>", name)
+	}
+	dataShards, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Policy{}, fmt.Errorf("invalid data shards of policy %s: %v", name, err)
+	}
+	parityShards, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return Policy{}, fmt.Errorf("invalid parity shards of policy %s: %v", name, err)
+	}
+	if dataShards <= 0 || parityShards <= 0 || dataShards+parityShards > 256 {
+		return Policy{}, fmt.Errorf("invalid policy %s, the shards must be positive and at most 256 in total", name)
+	}
+	return Policy{DataShards: dataShards, ParityShards: parityShards}, nil
+}
+
+// String returns the name of the policy, "" for replication so that it is omitted in metadata.
+func (p Policy) String() string {
+	if !p.IsErasureCoded() {
+		return ""
+	}
+	return fmt.Sprintf("RS-%d-%d", p.DataShards, p.ParityShards)
+}
+
+// IsErasureCoded returns whether the blocks are erasure-coded instead of replicated.
+func (p Policy) IsErasureCoded() bool {
+	return p.DataShards > 0
+}
+
+// Shards returns the number of blocks in a full stripe.
+func (p Policy) Shards() int {
+	return p.DataShards + p.ParityShards
+}
+
+// Stripes returns the number of stripes of a file with blocksNum data blocks.
+func (p Policy) Stripes(blocksNum int64) int64 {
+	return (blocksNum + int64(p.DataShards) - 1) / int64(p.DataShards)
+}
+
+// DataBlockIDs returns the IDs of the data blocks in a stripe of a file with blocksNum data blocks, the last stripe
+// may have fewer data blocks and the missing ones are zeros.
+func (p Policy) DataBlockIDs(stripe int64, blocksNum int64) []int64 {
+	blockIDs := []int64{}
+	for blockID := stripe * int64(p.DataShards); blockID < (stripe+1)*int64(p.DataShards) && blockID < blocksNum; blockID++ {
+		blockIDs = append(blockIDs, blockID)
+	}
+	return blockIDs
+}
+
+// ParityBlockIDs returns the IDs of the parity blocks in a stripe.
+func (p Policy) ParityBlockIDs(stripe int64) []int64 {
+	blockIDs := []int64{}
+	for i := 0; i < p.ParityShards; i++ {
+		blockIDs = append(blockIDs, stripe*int64(p.ParityShards)+int64(i))
+	}
+	return blockIDs
+}
+
+// Encode returns the parity blocks of the data blocks of a stripe. The data blocks are padded with zeros to the
+// longest one, which is the size of each parity block.
+func (p Policy) Encode(data [][]byte) ([][]byte, error) {
+	if len(data) == 0 || len(data) > p.DataShards {
+		return nil, fmt.Errorf("%d data blocks in a stripe of policy %s", len(data), p)
+	}
+	shardSize := 0
+	for _, block := range data {
+		if len(block) > shardSize {
+			shardSize = len(block)
+		}
+	}
+	shards := make([][]byte, p.Shards())
+	for i := range shards {
+		shards[i] = make([]byte, shardSize)
+		if i < len(data) {
+			copy(shards[i], data[i])
+		}
+	}
+	encoder, err := reedsolomon.New(p.DataShards, p.ParityShards)
+	if err != nil {
+		return nil, err
+	}
+	if shardSize > 0 {
+		if err := encoder.Encode(shards); err != nil {
+			return nil, fmt.Errorf("failed to encode stripe: %v", err)
+		}
+	}
+	return shards[p.DataShards:], nil
+}
+
+// Reconstruct fills the missing blocks of a stripe in place. shards has the data blocks followed by the parity blocks,
+// nil for the missing ones, and the blocks are padded with zeros to shardSize. The data blocks beyond the end of the file
+// are empty. At least DataShards blocks are needed.
+func (p Policy) Reconstruct(shards [][]byte, shardSize int) error {
+	if len(shards) != p.Shards() {
+		return fmt.Errorf("%d blocks in a stripe of policy %s", len(shards), p)
+	}
+	available := 0
+	for i, shard := range shards {
+		if shard == nil {
+			continue
+		}
+		available++
+		if len(shard) < shardSize {
+			shards[i] = append(shard, make([]byte, shardSize-len(shard))...)
+		}
+	}
+	if available < p.DataShards {
+		return fmt.Errorf("only %d blocks of the stripe are available, %d are needed", available, p.DataShards)
+	}
+	if shardSize == 0 {
+		for i := range shards {
+			shards[i] = []byte{}
+		}
+		return nil
+	}
+	encoder, err := reedsolomon.New(p.DataShards, p.ParityShards)
+	if err != nil {
+		return err
+	}
+	if err := encoder.Reconstruct(shards); err != nil {
+		return fmt.Errorf("failed to reconstruct stripe: %v", err)
+	}
+	return nil
+}
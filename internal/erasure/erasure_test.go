@@ -0,0 +1,135 @@
+package erasure
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func randomBlocks(sizes ...int) [][]byte {
+	r := rand.New(rand.NewSource(int64(len(sizes))))
+	blocks := [][]byte{}
+	for _, size := range sizes {
+		block := make([]byte, size)
+		r.Read(block)
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Policy
+		wantErr bool
+	}{
+		{"", Policy{}, false},
+		{"replication", Policy{}, false},
+		{"RS-6-3", Policy{DataShards: 6, ParityShards: 3}, false},
+		{"RS-0-3", Policy{}, true},
+		{"RS-6", Policy{}, true},
+		{"RS-200-57", Policy{}, true},
+		{"XOR-2-1", Policy{}, true},
+	}
+	for _, test := range tests {
+		got, err := ParsePolicy(test.name)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("ParsePolicy(%q) = %v, %v, want %v with error %v", test.name, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestStripeBlockIDs(t *testing.T) {
+	policy := Policy{DataShards: 3, ParityShards: 2}
+	if got := policy.Stripes(7); got != 3 {
+		t.Errorf("got %d stripes of 7 blocks, want 3", got)
+	}
+	if got := policy.DataBlockIDs(2, 7); len(got) != 1 || got[0] != 6 {
+		t.Errorf("got data blocks %v of the last stripe, want [6]", got)
+	}
+	if got := policy.ParityBlockIDs(2); len(got) != 2 || got[0] != 4 || got[1] != 5 {
+		t.Errorf("got parity blocks %v of stripe 2, want [4 5]", got)
+	}
+}
+
+func TestReconstruct(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		sizes   []int // sizes of the data blocks, fewer than DataShards in a short last stripe
+		lost    []int // shards lost, data blocks followed by parity blocks
+		wantErr bool
+	}{
+		{"no loss", Policy{DataShards: 3, ParityShards: 2}, []int{100, 100, 100}, nil, false},
+		{"one data block", Policy{DataShards: 3, ParityShards: 2}, []int{100, 100, 100}, []int{1}, false},
+		{"all parity blocks", Policy{DataShards: 3, ParityShards: 2}, []int{100, 100, 100}, []int{3, 4}, false},
+		{"data and parity blocks", Policy{DataShards: 6, ParityShards: 3}, []int{64, 64, 64, 64, 64, 64}, []int{0, 4, 7}, false},
+		{"shorter last block", Policy{DataShards: 3, ParityShards: 2}, []int{100, 100, 37}, []int{2, 0}, false},
+		{"short last stripe", Policy{DataShards: 4, ParityShards: 2}, []int{100, 51}, []int{1, 4}, false},
+		{"empty blocks", Policy{DataShards: 2, ParityShards: 1}, []int{0, 0}, []int{0}, false},
+		{"too many lost", Policy{DataShards: 3, ParityShards: 2}, []int{100, 100, 100}, []int{0, 1, 2}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := randomBlocks(test.sizes...)
+			parity, err := test.policy.Encode(data)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			if len(parity) != test.policy.ParityShards {
+				t.Fatalf("got %d parity blocks, want %d", len(parity), test.policy.ParityShards)
+			}
+			shardSize := 0
+			for _, size := range test.sizes {
+				if size > shardSize {
+					shardSize = size
+				}
+			}
+			// the data blocks beyond the end of the file are empty
+			shards := make([][]byte, test.policy.Shards())
+			for i := range shards {
+				switch {
+				case i < len(data):
+					shards[i] = append([]byte{}, data[i]...)
+				case i < test.policy.DataShards:
+					shards[i] = []byte{}
+				default:
+					shards[i] = append([]byte{}, parity[i-test.policy.DataShards]...)
+				}
+			}
+			for _, i := range test.lost {
+				shards[i] = nil
+			}
+			err = test.policy.Reconstruct(shards, shardSize)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("Reconstruct succeeded with %d blocks lost", len(test.lost))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Reconstruct: %v", err)
+			}
+			for i, block := range data {
+				if !bytes.Equal(shards[i][:len(block)], block) {
+					t.Errorf("data block %d is not reconstructed", i)
+				}
+			}
+			for i, block := range parity {
+				if !bytes.Equal(shards[test.policy.DataShards+i], block) {
+					t.Errorf("parity block %d is not reconstructed", i)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeRejectsTooManyBlocks(t *testing.T) {
+	policy := Policy{DataShards: 2, ParityShards: 1}
+	if _, err := policy.Encode(randomBlocks(10, 10, 10)); err == nil {
+		t.Errorf("Encode succeeded with more data blocks than data shards")
+	}
+	if _, err := policy.Encode(nil); err == nil {
+		t.Errorf("Encode succeeded without data blocks")
+	}
+}
@@ -0,0 +1,296 @@
+package gateway
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxKeys is the max number of keys in a response of listing objects.
+const maxKeys = 1000
+
+const s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+type listAllMyBucketsResult struct {
+	XMLName xml.Name       `xml:"ListAllMyBucketsResult"`
+	Xmlns   string         `xml:"xmlns,attr"`
+	Buckets []bucketResult `xml:"Buckets>Bucket"`
+}
+
+type bucketResult struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate"`
+}
+
+// listBuckets lists the top-level directories with the bucket prefix.
+func (req *request) listBuckets() error {
+	entries, _, err := req.c.ListDir("")
+	if err != nil {
+		return err
+	}
+	result := listAllMyBucketsResult{Xmlns: s3Namespace, Buckets: []bucketResult{}}
+	for _, e := range entries {
+		if e.GetIsDir() && validBucketName(e.GetName()) && strings.HasPrefix(e.GetName(), req.bucketPrefix) {
+			// the directories have no creation time
+			result.Buckets = append(result.Buckets, bucketResult{Name: e.GetName(), CreationDate: time.Time{}.Format(timeFormat)})
+		}
+	}
+	writeXML(req.w, http.StatusOK, result)
+	return nil
+}
+
+// createBucket creates the directory of a bucket, it succeeds if the bucket exists.
+func (req *request) createBucket() error {
+	e, err := req.stat(req.bucket)
+	if err != nil {
+		return err
+	}
+	if e != nil && !e.isDir {
+		return errBucketExists
+	}
+	if e == nil {
+		if err := req.c.Mkdir(req.bucket); err != nil {
+			return err
+		}
+	}
+	req.w.Header().Set("Location", "/"+req.bucket)
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// deleteBucket deletes the directory of an empty bucket.
+func (req *request) deleteBucket() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+	empty, err := req.isEmpty(req.bucket)
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return errBucketNotEmpty
+	}
+	// the empty directories left by the deleted objects are deleted
+	if err := req.c.DelRecursive(req.bucket); err != nil {
+		return err
+	}
+	req.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (req *request) headBucket() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// isEmpty returns whether there is no file in a directory and its subdirectories.
+func (req *request) isEmpty(dir string) (bool, error) {
+	entries, _, err := req.c.ListDir(dir)
+	if err != nil {
+		return false, err
+	}
+	for _, e := range entries {
+		if !e.GetIsDir() {
+			return false, nil
+		}
+		empty, err := req.isEmpty(path.Join(dir, e.GetName()))
+		if err != nil || !empty {
+			return empty, err
+		}
+	}
+	return true, nil
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name         `xml:"ListBucketResult"`
+	Xmlns                 string           `xml:"xmlns,attr"`
+	Name                  string           `xml:"Name"`
+	Prefix                string           `xml:"Prefix"`
+	Delimiter             string           `xml:"Delimiter,omitempty"`
+	EncodingType          string           `xml:"EncodingType,omitempty"`
+	MaxKeys               int              `xml:"MaxKeys"`
+	IsTruncated           bool             `xml:"IsTruncated"`
+	Marker                *string          `xml:"Marker"`
+	NextMarker            string           `xml:"NextMarker,omitempty"`
+	StartAfter            string           `xml:"StartAfter,omitempty"`
+	ContinuationToken     string           `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string           `xml:"NextContinuationToken,omitempty"`
+	KeyCount              *int             `xml:"KeyCount"`
+	Contents              []objectResult   `xml:"Contents"`
+	CommonPrefixes        []commonPrefixes `xml:"CommonPrefixes"`
+}
+
+type objectResult struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type commonPrefixes struct {
+	Prefix string `xml:"Prefix"`
+}
+
+// listItem is an object or a common prefix in a listing.
+type listItem struct {
+	key      string
+	isPrefix bool
+	size     int64
+	modTime  int64
+}
+
+// listObjects lists the objects of a bucket with ListObjectsV2, or ListObjects if list-type is not 2.
+func (req *request) listObjects() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+	v2 := req.param("list-type") == "2"
+	prefix, delimiter := req.param("prefix"), req.param("delimiter")
+	limit := maxKeys
+	if s := req.param("max-keys"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return newError(http.StatusBadRequest, "InvalidArgument", "max-keys must be a non-negative integer")
+		}
+		if n < limit {
+			limit = n
+		}
+	}
+	marker := req.param("marker")
+	if v2 {
+		marker = req.param("start-after")
+		if token := req.param("continuation-token"); token != "" {
+			decoded, err := base64.StdEncoding.DecodeString(token)
+			if err != nil {
+				return newError(http.StatusBadRequest, "InvalidArgument", "The continuation token provided is incorrect")
+			}
+			marker = string(decoded)
+		}
+	}
+	items, err := req.list(prefix, delimiter)
+	if err != nil {
+		return err
+	}
+	encode := func(s string) string { return s }
+	if req.param("encoding-type") == "url" {
+		encode = url.QueryEscape
+	}
+	result := listBucketResult{
+		Xmlns:        s3Namespace,
+		Name:         req.bucket,
+		Prefix:       encode(prefix),
+		Delimiter:    encode(delimiter),
+		EncodingType: req.param("encoding-type"),
+		MaxKeys:      limit,
+	}
+	last := ""
+	for _, item := range items {
+		if item.key <= marker {
+			continue
+		}
+		if len(result.Contents)+len(result.CommonPrefixes) == limit {
+			result.IsTruncated = true
+			break
+		}
+		if item.isPrefix {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefixes{Prefix: encode(item.key)})
+		} else {
+			result.Contents = append(result.Contents, objectResult{
+				Key:          encode(item.key),
+				LastModified: time.UnixMilli(item.modTime).UTC().Format(timeFormat),
+				Size:         item.size,
+				StorageClass: "STANDARD",
+			})
+		}
+		last = item.key
+	}
+	if v2 {
+		keyCount := len(result.Contents) + len(result.CommonPrefixes)
+		result.KeyCount = &keyCount
+		result.StartAfter = encode(req.param("start-after"))
+		result.ContinuationToken = req.param("continuation-token")
+		if result.IsTruncated {
+			result.NextContinuationToken = base64.StdEncoding.EncodeToString([]byte(last))
+		}
+	} else {
+		result.Marker = &marker
+		if result.IsTruncated {
+			result.NextMarker = encode(last)
+		}
+	}
+	writeXML(req.w, http.StatusOK, result)
+	return nil
+}
+
+// list returns the objects of the bucket with the prefix and the common prefixes rolled up by the delimiter, sorted by
+// key. Only the directory of the prefix is walked, and its subdirectories are not walked if the delimiter is a slash.
+func (req *request) list(prefix, delimiter string) ([]listItem, error) {
+	items := []listItem{}
+	prefixes := map[string]bool{}
+	var walk func(dir, keyPrefix string) error
+	walk = func(dir, keyPrefix string) error {
+		entries, isDir, err := req.c.ListDir(dir)
+		if err != nil || !isDir {
+			// the directory of the prefix does not exist
+			return nil
+		}
+		for _, e := range entries {
+			key := keyPrefix + e.GetName()
+			if !e.GetIsDir() {
+				if strings.HasPrefix(key, prefix) {
+					items = append(items, listItem{key: key, size: e.GetSize(), modTime: e.GetModTime()})
+				}
+				continue
+			}
+			dirKey := key + "/"
+			if delimiter == "/" && strings.HasPrefix(dirKey, prefix) {
+				// the empty directories left by the deleted objects are not prefixes
+				empty, err := req.isEmpty(path.Join(dir, e.GetName()))
+				if err != nil {
+					return err
+				}
+				if !empty {
+					prefixes[dirKey] = true
+				}
+				continue
+			}
+			if strings.HasPrefix(dirKey, prefix) || strings.HasPrefix(prefix, dirKey) {
+				if err := walk(path.Join(dir, e.GetName()), dirKey); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	dirKey := prefix[:strings.LastIndex(prefix, "/")+1]
+	if err := walk(path.Join(req.bucket, dirKey), dirKey); err != nil {
+		return nil, err
+	}
+	if delimiter != "" && delimiter != "/" {
+		objects := items
+		items = []listItem{}
+		for _, item := range objects {
+			if i := strings.Index(item.key[len(prefix):], delimiter); i >= 0 {
+				prefixes[item.key[:len(prefix)+i+len(delimiter)]] = true
+				continue
+			}
+			items = append(items, item)
+		}
+	}
+	for p := range prefixes {
+		items = append(items, listItem{key: p, isPrefix: true})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].key < items[j].key
+	})
+	return items, nil
+}
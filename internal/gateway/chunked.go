@@ -0,0 +1,107 @@
+package gateway
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxChunkHeader is the max length of the header line of a chunk, including its signature.
+const maxChunkHeader = 4096
+
+var errInvalidChunk = newError(http.StatusBadRequest, "IncompleteBody", "The aws-chunked payload is malformed")
+
+// chunkedReader decodes an aws-chunked payload, each chunk is "<hex size>[;chunk-signature=<sig>]\r\n<data>\r\n" and
+// the last one is empty, followed by the trailing headers and an empty line. The signatures are not verified.
+type chunkedReader struct {
+	r         *bufio.Reader
+	remaining int64 // bytes left in the current chunk
+	started   bool
+	done      bool
+}
+
+func newChunkedReader(r io.Reader) *chunkedReader {
+	return &chunkedReader{r: bufio.NewReader(r)}
+}
+
+func (c *chunkedReader) Read(p []byte) (int, error) {
+	if c.done {
+		return 0, io.EOF
+	}
+	if c.remaining == 0 {
+		if err := c.nextChunk(); err != nil {
+			return 0, err
+		}
+		if c.done {
+			return 0, io.EOF
+		}
+	}
+	if int64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+	n, err := c.r.Read(p)
+	c.remaining -= int64(n)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// nextChunk reads the end of the previous chunk and the header of the next one.
+func (c *chunkedReader) nextChunk() error {
+	if c.started {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if line != "" {
+			return errInvalidChunk
+		}
+	}
+	c.started = true
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	sizeHex, _, _ := strings.Cut(line, ";")
+	size, err := strconv.ParseInt(strings.TrimSpace(sizeHex), 16, 64)
+	if err != nil || size < 0 {
+		return errInvalidChunk
+	}
+	if size > 0 {
+		c.remaining = size
+		return nil
+	}
+	// the trailing headers, e.g. x-amz-checksum-crc32, end with an empty line
+	for {
+		line, err := c.readLine()
+		if err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if line == "" {
+			break
+		}
+	}
+	c.done = true
+	return nil
+}
+
+// readLine reads a line ending with CRLF.
+func (c *chunkedReader) readLine() (string, error) {
+	line, err := c.r.ReadSlice('\n')
+	if err == bufio.ErrBufferFull || len(line) > maxChunkHeader {
+		return "", errInvalidChunk
+	}
+	if err == io.EOF {
+		return "", io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// s3Error is an error response of the S3 API.
+type s3Error struct {
+	status  int
+	code    string
+	message string
+}
+
+func newError(status int, code, message string) *s3Error {
+	return &s3Error{status: status, code: code, message: message}
+}
+
+func (e *s3Error) Error() string {
+	return e.code + ": " + e.message
+}
+
+var (
+	errAccessDenied      = newError(http.StatusForbidden, "AccessDenied", "Access Denied")
+	errNoSuchBucket      = newError(http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+	errNoSuchKey         = newError(http.StatusNotFound, "NoSuchKey", "The specified key does not exist")
+	errNoSuchUpload      = newError(http.StatusNotFound, "NoSuchUpload", "The specified multipart upload does not exist")
+	errBucketNotEmpty    = newError(http.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty")
+	errBucketExists      = newError(http.StatusConflict, "BucketAlreadyExists", "The requested bucket name is not available")
+	errInvalidBucketName = newError(http.StatusBadRequest, "InvalidBucketName", "The specified bucket is not valid")
+	errIncompleteBody    = newError(http.StatusBadRequest, "IncompleteBody", "You did not provide the number of bytes specified by the Content-Length HTTP header")
+	errBadDigest         = newError(http.StatusBadRequest, "BadDigest", "The Content-MD5 you specified did not match what we received")
+	errInvalidPart       = newError(http.StatusBadRequest, "InvalidPart", "One or more of the specified parts could not be found or the ETag did not match")
+	errInvalidPartOrder  = newError(http.StatusBadRequest, "InvalidPartOrder", "The list of parts was not in ascending order")
+	errMalformedXML      = newError(http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed")
+	errMethodNotAllowed  = newError(http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource")
+	errNotImplemented    = newError(http.StatusNotImplemented, "NotImplemented", "A header or query you provided implies functionality that is not implemented")
+)
+
+type errorResponse struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string   `xml:"Code"`
+	Message  string   `xml:"Message"`
+	Resource string   `xml:"Resource"`
+}
+
+// writeError writes an S3 error, errors which are not S3 errors are internal errors.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var e *s3Error
+	if !errors.As(err, &e) {
+		logrus.Errorf("%s %s failed: %v", r.Method, r.URL.RequestURI(), err)
+		e = newError(http.StatusInternalServerError, "InternalError", err.Error())
+	}
+	if r.Method == http.MethodHead {
+		// a response to HEAD has no body
+		w.WriteHeader(e.status)
+		return
+	}
+	writeXML(w, e.status, errorResponse{Code: e.code, Message: e.message, Resource: r.URL.Path})
+}
+
+// writeXML writes a response with an XML body.
+func writeXML(w http.ResponseWriter, status int, body interface{}) {
+	data, err := xml.Marshal(body)
+	if err != nil {
+		logrus.Errorf("failed to marshal response: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	w.Write([]byte(xml.Header))
+	w.Write(data)
+}
@@ -0,0 +1,214 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
+)
+
+// timeFormat is the format of the times in the responses.
+const timeFormat = "2006-01-02T15:04:05.000Z"
+
+// Gateway serves an S3-compatible HTTP API backed by SDFS. Each bucket is a top-level directory, and each object is the
+// file at its key in the directory. Requests are path-style and not authenticated, so only the buckets whose names start
+// with the bucket prefix are served.
+type Gateway struct {
+	client       *client.Client
+	server       *http.Server
+	bucketPrefix string
+}
+
+// NewGateway creates a gateway listening on address with the SDFS client of the config, which serves the buckets whose
+// names start with bucketPrefix.
+func NewGateway(configPath, address, bucketPrefix string) (*Gateway, error) {
+	c, err := client.NewClient(configPath)
+	if err != nil {
+		return nil, err
+	}
+	g := &Gateway{client: c, bucketPrefix: bucketPrefix}
+	g.server = &http.Server{
+		Addr:              address,
+		Handler:           g,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return g, nil
+}
+
+// Run serves the requests until the server fails.
+func (g *Gateway) Run() error {
+	if g.bucketPrefix == "" {
+		logrus.Warnf("S3 gateway serves every top-level directory of SDFS without authentication")
+	}
+	logrus.Infof("S3 gateway listening on %s", g.server.Addr)
+	return g.server.ListenAndServe()
+}
+
+// ServeHTTP routes a request by its bucket, key, method and query.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	// the leases of the requests are separated
+	req := &request{c: g.client.Session(), w: rec, r: r, bucket: bucket, key: key, query: r.URL.Query(), bucketPrefix: g.bucketPrefix}
+	if err := req.route(); err != nil {
+		writeError(rec, r, err)
+	}
+	logrus.Infof("%s %s %d %v", r.Method, r.URL.RequestURI(), rec.status, time.Since(start))
+}
+
+// request is a request to the gateway.
+type request struct {
+	c      *client.Client
+	w      http.ResponseWriter
+	r      *http.Request
+	bucket string
+	key    string
+	query  map[string][]string
+
+	bucketPrefix string // only the buckets whose names start with it are served
+}
+
+// has returns whether the query has a parameter.
+func (req *request) has(name string) bool {
+	_, ok := req.query[name]
+	return ok
+}
+
+// param returns the first value of a query parameter.
+func (req *request) param(name string) string {
+	if values := req.query[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (req *request) route() error {
+	method := req.r.Method
+	if req.bucket == "" {
+		if method == http.MethodGet {
+			return req.listBuckets()
+		}
+		return errMethodNotAllowed
+	}
+	if !validBucketName(req.bucket) {
+		return errInvalidBucketName
+	}
+	if !strings.HasPrefix(req.bucket, req.bucketPrefix) {
+		return errAccessDenied
+	}
+	if req.key == "" {
+		switch {
+		case method == http.MethodPut:
+			return req.createBucket()
+		case method == http.MethodDelete:
+			return req.deleteBucket()
+		case method == http.MethodHead:
+			return req.headBucket()
+		case method == http.MethodGet && req.has("uploads"):
+			return errNotImplemented
+		case method == http.MethodGet:
+			return req.listObjects()
+		case method == http.MethodPost && req.has("delete"):
+			return req.deleteObjects()
+		}
+		return errMethodNotAllowed
+	}
+	if err := checkKey(req.key); err != nil {
+		return err
+	}
+	switch {
+	case method == http.MethodPut && req.has("uploadId"):
+		return req.uploadPart()
+	case method == http.MethodPut && req.r.Header.Get("x-amz-copy-source") != "":
+		return errNotImplemented
+	case method == http.MethodPut:
+		return req.putObject()
+	case (method == http.MethodGet || method == http.MethodHead) && req.has("uploadId"):
+		return errNotImplemented
+	case method == http.MethodGet || method == http.MethodHead:
+		return req.getObject()
+	case method == http.MethodDelete && req.has("uploadId"):
+		return req.abortMultipartUpload()
+	case method == http.MethodDelete:
+		return req.deleteObject()
+	case method == http.MethodPost && req.has("uploads"):
+		return req.createMultipartUpload()
+	case method == http.MethodPost && req.has("uploadId"):
+		return req.completeMultipartUpload()
+	}
+	return errMethodNotAllowed
+}
+
+// objectPath returns the path of an object in SDFS.
+func objectPath(bucket, key string) string {
+	return bucket + "/" + key
+}
+
+// validBucketName returns whether a bucket name follows the naming rules of S3, names starting with a dot are not
+// buckets so that the directories of the gateway are hidden.
+func validBucketName(name string) bool {
+	if len(name) < 3 || len(name) > 63 {
+		return false
+	}
+	for i, ch := range name {
+		alnum := (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9')
+		if !alnum && (i == 0 || i == len(name)-1 || (ch != '-' && ch != '.')) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkKey returns an error if a key is not the same path in SDFS, e.g. "a//b" or "a/../b". A key ending with a slash
+// is a directory.
+func checkKey(key string) error {
+	clean := strings.TrimSuffix(key, "/")
+	if clean == "" || metadata.CleanPath(clean) != clean {
+		return newError(http.StatusBadRequest, "InvalidArgument", "key "+key+" is not a valid path")
+	}
+	return nil
+}
+
+// stat returns the entry of a file or directory, nil if it does not exist.
+func (req *request) stat(name string) (*entry, error) {
+	e, err := req.c.Stat(name)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	return &entry{name: name, isDir: e.GetIsDir(), size: e.GetSize(), modTime: e.GetModTime()}, nil
+}
+
+// entry is a file or directory in SDFS.
+type entry struct {
+	name    string
+	isDir   bool
+	size    int64
+	modTime int64 // unix milliseconds
+}
+
+// checkBucket returns NoSuchBucket if the bucket does not exist.
+func (req *request) checkBucket() error {
+	e, err := req.stat(req.bucket)
+	if err != nil {
+		return err
+	}
+	if e == nil || !e.isDir {
+		return errNoSuchBucket
+	}
+	return nil
+}
+
+// statusRecorder records the status of a response for the access log.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
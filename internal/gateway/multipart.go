@@ -0,0 +1,218 @@
+package gateway
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// uploadsDir is the directory of the multipart uploads in SDFS, so that any gateway can complete an upload. Each upload
+// is a directory with the bucket and key of the object in "key", and each part in "part-<number>-<MD5 in hex>".
+const uploadsDir = ".gateway/uploads"
+
+// maxPartNumber is the max number of parts of an upload.
+const maxPartNumber = 10000
+
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+type completeMultipartUpload struct {
+	Parts []struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	} `xml:"Part"`
+}
+
+type completeMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"CompleteMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Location string   `xml:"Location"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
+
+// part is a part of a multipart upload stored in SDFS.
+type part struct {
+	name string
+	sum  string // MD5 in hex
+}
+
+// createMultipartUpload creates the directory of a new upload.
+func (req *request) createMultipartUpload() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	uploadID := hex.EncodeToString(id)
+	writer, err := req.c.Create(path.Join(uploadsDir, uploadID, "key"))
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(writer, objectPath(req.bucket, req.key)); err != nil {
+		writer.Abort()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	writeXML(req.w, http.StatusOK, initiateMultipartUploadResult{Xmlns: s3Namespace, Bucket: req.bucket, Key: req.key, UploadID: uploadID})
+	return nil
+}
+
+// uploadPart stores a part of an upload, a part uploaded again replaces the previous one.
+func (req *request) uploadPart() error {
+	partNumber, err := strconv.Atoi(req.param("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > maxPartNumber {
+		return newError(http.StatusBadRequest, "InvalidArgument", fmt.Sprintf("Part number must be an integer between 1 and %d", maxPartNumber))
+	}
+	parts, err := req.uploadParts()
+	if err != nil {
+		return err
+	}
+	dir := path.Join(uploadsDir, req.param("uploadId"))
+	temp := path.Join(dir, fmt.Sprintf("temp-%05d", partNumber))
+	sum, err := req.writeFile(temp, req.body())
+	if err != nil {
+		return err
+	}
+	if err := req.c.Rename(temp, path.Join(dir, fmt.Sprintf("part-%05d-%s", partNumber, hex.EncodeToString(sum)))); err != nil {
+		return err
+	}
+	if previous, ok := parts[partNumber]; ok && previous.sum != hex.EncodeToString(sum) {
+		if err := req.c.DelFile(previous.name); err != nil {
+			return err
+		}
+	}
+	req.w.Header().Set("ETag", etag(sum))
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// uploadParts returns the parts of the upload by their numbers, or NoSuchUpload if the upload does not exist.
+func (req *request) uploadParts() (map[int]part, error) {
+	uploadID := req.param("uploadId")
+	if _, err := hex.DecodeString(uploadID); err != nil || uploadID == "" {
+		return nil, errNoSuchUpload
+	}
+	dir := path.Join(uploadsDir, uploadID)
+	entries, isDir, err := req.c.ListDir(dir)
+	if err != nil || !isDir {
+		return nil, errNoSuchUpload
+	}
+	parts := map[int]part{}
+	for _, e := range entries {
+		fields := strings.Split(e.GetName(), "-")
+		if len(fields) != 3 || fields[0] != "part" {
+			continue
+		}
+		number, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		parts[number] = part{name: path.Join(dir, e.GetName()), sum: fields[2]}
+	}
+	return parts, nil
+}
+
+// completeMultipartUpload concatenates the parts in the body into the object and deletes the upload.
+func (req *request) completeMultipartUpload() error {
+	parts, err := req.uploadParts()
+	if err != nil {
+		return err
+	}
+	dir := path.Join(uploadsDir, req.param("uploadId"))
+	target, err := req.c.ReadRange(path.Join(dir, "key"), 0, -1)
+	if err != nil {
+		return err
+	}
+	name := objectPath(req.bucket, req.key)
+	if string(target) != name {
+		return errNoSuchUpload
+	}
+	data, err := io.ReadAll(io.LimitReader(req.r.Body, 1<<20))
+	if err != nil {
+		return errIncompleteBody
+	}
+	var in completeMultipartUpload
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&in); err != nil || len(in.Parts) == 0 {
+		return errMalformedXML
+	}
+	// the parts are checked before the object is written
+	sums := []byte{}
+	for i, p := range in.Parts {
+		if i > 0 && p.PartNumber <= in.Parts[i-1].PartNumber {
+			return errInvalidPartOrder
+		}
+		stored, ok := parts[p.PartNumber]
+		if !ok || strings.Trim(p.ETag, "\"") != stored.sum {
+			return errInvalidPart
+		}
+		sum, _ := hex.DecodeString(stored.sum)
+		sums = append(sums, sum...)
+	}
+	writer, err := req.c.Create(name)
+	if err != nil {
+		return err
+	}
+	for _, p := range in.Parts {
+		if err := req.copyFile(writer, parts[p.PartNumber].name); err != nil {
+			writer.Abort()
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if err := req.c.DelRecursive(dir); err != nil {
+		return err
+	}
+	sum := md5.Sum(sums)
+	writeXML(req.w, http.StatusOK, completeMultipartUploadResult{
+		Xmlns:    s3Namespace,
+		Location: "/" + name,
+		Bucket:   req.bucket,
+		Key:      req.key,
+		ETag:     fmt.Sprintf("\"%s-%d\"", hex.EncodeToString(sum[:]), len(in.Parts)),
+	})
+	return nil
+}
+
+// copyFile streams a file in SDFS to w.
+func (req *request) copyFile(w io.Writer, name string) error {
+	reader, err := req.c.Open(name)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+// abortMultipartUpload deletes the upload and its parts.
+func (req *request) abortMultipartUpload() error {
+	if _, err := req.uploadParts(); err != nil {
+		return err
+	}
+	if err := req.c.DelRecursive(path.Join(uploadsDir, req.param("uploadId"))); err != nil {
+		return err
+	}
+	req.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
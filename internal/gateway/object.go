@@ -0,0 +1,242 @@
+package gateway
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// putObject streams the body to a new version of the file of the object. A key ending with a slash creates a directory.
+func (req *request) putObject() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+	name := objectPath(req.bucket, req.key)
+	if strings.HasSuffix(req.key, "/") {
+		if req.r.ContentLength > 0 {
+			return newError(http.StatusBadRequest, "InvalidArgument", "a key ending with a slash must be empty")
+		}
+		if err := req.c.Mkdir(name); err != nil {
+			return err
+		}
+		req.w.Header().Set("ETag", etag(md5.New().Sum(nil)))
+		req.w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	e, err := req.stat(name)
+	if err != nil {
+		return err
+	}
+	if e != nil && e.isDir {
+		return newError(http.StatusBadRequest, "InvalidArgument", "key "+req.key+" is a prefix of other keys")
+	}
+	sum, err := req.writeFile(name, req.body())
+	if err != nil {
+		return err
+	}
+	req.w.Header().Set("ETag", etag(sum))
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// body returns the body of the request, the payload of an aws-chunked body is decoded.
+func (req *request) body() io.Reader {
+	if strings.HasPrefix(req.r.Header.Get("x-amz-content-sha256"), "STREAMING-") ||
+		strings.Contains(req.r.Header.Get("Content-Encoding"), "aws-chunked") {
+		return newChunkedReader(req.r.Body)
+	}
+	return req.r.Body
+}
+
+// contentLength returns the length of the payload, -1 if it is unknown.
+func (req *request) contentLength() int64 {
+	if s := req.r.Header.Get("x-amz-decoded-content-length"); s != "" {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+		return -1
+	}
+	return req.r.ContentLength
+}
+
+// writeFile streams body to a new version of a file and returns its MD5. Nothing is written if the body is shorter
+// than its length or does not match its Content-MD5.
+func (req *request) writeFile(name string, body io.Reader) ([]byte, error) {
+	writer, err := req.c.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	hash := md5.New()
+	n, err := io.Copy(io.MultiWriter(writer, hash), body)
+	if err != nil {
+		writer.Abort()
+		if _, ok := err.(*s3Error); ok {
+			return nil, err
+		}
+		return nil, errIncompleteBody
+	}
+	if length := req.contentLength(); length >= 0 && n != length {
+		writer.Abort()
+		return nil, errIncompleteBody
+	}
+	sum := hash.Sum(nil)
+	if contentMD5 := req.r.Header.Get("Content-MD5"); contentMD5 != "" && contentMD5 != base64.StdEncoding.EncodeToString(sum) {
+		writer.Abort()
+		return nil, errBadDigest
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return sum, nil
+}
+
+// getObject serves the file of the object with its ranges, or only the headers of the object for HEAD.
+func (req *request) getObject() error {
+	name := objectPath(req.bucket, req.key)
+	e, err := req.stat(name)
+	if err != nil {
+		return err
+	}
+	if e == nil || e.isDir {
+		if err := req.checkBucket(); err != nil {
+			return err
+		}
+		return errNoSuchKey
+	}
+	// HEAD only needs the size of the file, so it does not take a read lease
+	var content io.ReadSeeker = io.NewSectionReader(noContent{}, 0, e.size)
+	if req.r.Method != http.MethodHead {
+		reader, err := req.c.Open(name)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		content = reader
+		// the file is not replaced while its read lock is held, so the modification time is the one of the version being
+		// read; the size is the one of the reader
+		e, err = req.stat(name)
+		if err != nil {
+			return err
+		}
+		if e == nil {
+			return errNoSuchKey
+		}
+	}
+	contentType := mime.TypeByExtension(path.Ext(req.key))
+	if contentType == "" {
+		contentType = "binary/octet-stream"
+	}
+	req.w.Header().Set("Content-Type", contentType)
+	// ServeContent handles the ranges and the conditional requests
+	http.ServeContent(req.w, req.r, req.key, time.UnixMilli(e.modTime), content)
+	return nil
+}
+
+// noContent is the content of an object for HEAD, which is never read.
+type noContent struct{}
+
+func (noContent) ReadAt(p []byte, off int64) (int, error) {
+	return 0, errors.New("the content of an object is not read for HEAD")
+}
+
+// deleteObject deletes the file of the object, or the directory of a key ending with a slash if it is empty. Deleting an
+// object which does not exist succeeds.
+func (req *request) deleteObject() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+	if err := req.delete(req.key); err != nil {
+		return err
+	}
+	req.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (req *request) delete(key string) error {
+	name := objectPath(req.bucket, key)
+	e, err := req.stat(name)
+	if err != nil || e == nil {
+		return err
+	}
+	if !e.isDir {
+		return req.c.DelFile(name)
+	}
+	if !strings.HasSuffix(key, "/") {
+		return nil
+	}
+	empty, err := req.isEmpty(name)
+	if err != nil || !empty {
+		return err
+	}
+	return req.c.DelRecursive(name)
+}
+
+type deleteRequest struct {
+	Quiet   bool `xml:"Quiet"`
+	Objects []struct {
+		Key string `xml:"Key"`
+	} `xml:"Object"`
+}
+
+type deleteResult struct {
+	XMLName xml.Name        `xml:"DeleteResult"`
+	Xmlns   string          `xml:"xmlns,attr"`
+	Deleted []deletedResult `xml:"Deleted"`
+	Errors  []deleteError   `xml:"Error"`
+}
+
+type deletedResult struct {
+	Key string `xml:"Key"`
+}
+
+type deleteError struct {
+	Key     string `xml:"Key"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// deleteObjects deletes the objects in the body, the result of each object is reported.
+func (req *request) deleteObjects() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(io.LimitReader(req.r.Body, 1<<20))
+	if err != nil {
+		return errIncompleteBody
+	}
+	var in deleteRequest
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&in); err != nil {
+		return errMalformedXML
+	}
+	result := deleteResult{Xmlns: s3Namespace}
+	for _, object := range in.Objects {
+		err := checkKey(object.Key)
+		if err == nil {
+			err = req.delete(object.Key)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, deleteError{Key: object.Key, Code: "InternalError", Message: err.Error()})
+			continue
+		}
+		if !in.Quiet {
+			result.Deleted = append(result.Deleted, deletedResult{Key: object.Key})
+		}
+	}
+	writeXML(req.w, http.StatusOK, result)
+	return nil
+}
+
+// etag returns the quoted hex of an MD5.
+func etag(sum []byte) string {
+	return "\"" + hex.EncodeToString(sum) + "\""
+}
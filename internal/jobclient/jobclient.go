@@ -9,11 +9,10 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/enums"
 	schedulerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/scheduler/proto"
 	sdfsclient "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type JobClient struct {
@@ -89,7 +88,7 @@ func (c *JobClient) Juice(juiceExe, numJuices, sdfsIntermediateFileNamePrefix, s
 }
 
 func (c *JobClient) sendJob(hostname, port, jobType, jobID string, params []string) error {
-	conn, err := grpc.Dial(hostname+":"+port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := connpool.Get(hostname + ":" + port)
 	if err != nil {
 		return err
 	}
@@ -2,40 +2,53 @@ package leaderserver
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/compress"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
 )
 
 // AppendBlockInfo handles the request to choose the block to append the file
 func (l *LeaderServer) AppendBlockInfo(ctx context.Context, in *pb.AppendBlockInfoRequest) (*pb.AppendBlockInfoReply, error) {
-	blockInfo, err := l.appendBlockInfo(in.FileName, in.FileSize)
+	if err := l.metadata.CheckFilePath(in.FileName); err != nil {
+		return nil, err
+	}
+	if l.records.hasPending(metadata.CleanPath(in.FileName)) {
+		return nil, fmt.Errorf("file %s is being appended with records", in.FileName)
+	}
+	if fileInfo, err := l.metadata.GetFile(in.FileName); err == nil && l.policyOf(fileInfo.Current()).IsErasureCoded() {
+		return nil, fmt.Errorf("cannot append to erasure-coded file %s, set its policy to %s first", in.FileName, erasure.Replication)
+	}
+	compression, err := l.getCompression(in.FileName, in.GetCompression(), erasure.Policy{})
 	if err != nil {
 		return nil, err
 	}
-	appendBlockInfoReplyBlockMeta := map[int64]*pb.BlockMeta{}
-	for blockID, blockMeta := range blockInfo {
-		appendBlockInfoReplyBlockMeta[blockID] = &pb.BlockMeta{
-			HostNames: blockMeta.HostNames,
-			FileName:  blockMeta.FileName,
-			BlockID:   blockMeta.BlockID,
-			BlockSize: blockMeta.BlockSize,
-		}
+	generation, err := l.metadata.NewGeneration()
+	if err != nil {
+		return nil, err
+	}
+	blockInfo, err := l.appendBlockInfo(in.FileName, in.FileSize, generation, l.getReplication(in.FileName, int(in.GetReplication())))
+	if err != nil {
+		return nil, err
 	}
 	return &pb.AppendBlockInfoReply{
-		BlockInfo: appendBlockInfoReplyBlockMeta,
+		BlockInfo:   toProtoBlockInfo(blockInfo),
+		Generation:  generation,
+		Compression: compression.String(),
 	}, nil
 }
 
-// appendBlockInfo select the block to append the file
-func (l *LeaderServer) appendBlockInfo(fileName string, fileSize int64) (metadata.BlockInfo, error) {
-	// if the file does not exist, same as putting a new file
-	if !l.metadata.IsFileExist(fileName) {
-		return l.putBlockInfo(fileName, fileSize)
-	}
+// appendBlockInfo select the block to append the file. The last block keeps its current generation so that the client
+// can read it, all blocks are written with the new generation. The new blocks are placed on replication data servers.
+func (l *LeaderServer) appendBlockInfo(fileName string, fileSize int64, generation int64, replication int) (metadata.BlockInfo, error) {
+	// if the file does not exist or is empty, same as putting a new file
 	oldBlockInfo, err := l.metadata.GetBlockInfo(fileName)
-	if err != nil {
-		return nil, err
+	if err != nil || len(oldBlockInfo) == 0 {
+		return l.putBlockInfo(fileName, fileSize, generation, replication)
 	}
 	toAppendBlockInfo := metadata.BlockInfo{}
 	// get the last block id
@@ -49,23 +62,13 @@ func (l *LeaderServer) appendBlockInfo(fileName string, fileSize int64) (metadat
 	lastBlock := oldBlockInfo[lastBlockID]
 	if lastBlock.BlockSize+fileSize <= l.blockSize {
 		// append to the last block
-		toAppendBlockInfo[lastBlockID] = metadata.BlockMeta{
-			HostNames: lastBlock.HostNames,
-			FileName:  lastBlock.FileName,
-			BlockID:   lastBlock.BlockID,
-			BlockSize: lastBlock.BlockSize, // should be updated by client after put
-		}
+		toAppendBlockInfo[lastBlockID] = lastBlock
 		return toAppendBlockInfo, nil
 	}
 
 	// create new blocks
 	if l.blockSize-lastBlock.BlockSize > 0 {
-		toAppendBlockInfo[lastBlockID] = metadata.BlockMeta{
-			HostNames: lastBlock.HostNames,
-			FileName:  lastBlock.FileName,
-			BlockID:   lastBlock.BlockID,
-			BlockSize: lastBlock.BlockSize, // should be updated by client after put
-		}
+		toAppendBlockInfo[lastBlockID] = lastBlock
 	}
 	leftBlockSize := fileSize - (l.blockSize - lastBlock.BlockSize)
 	blocksNum := leftBlockSize / l.blockSize
@@ -73,28 +76,53 @@ func (l *LeaderServer) appendBlockInfo(fileName string, fileSize int64) (metadat
 		blocksNum++
 	}
 	for i := int64(0); i < blocksNum; i++ {
+		hostNames, err := l.selectBlockHosts(replication)
+		if err != nil {
+			return nil, err
+		}
 		toAppendBlockInfo[lastBlockID+i+1] = metadata.BlockMeta{
-			HostNames: l.selectBlockHosts(),
-			FileName:  fileName,
-			BlockID:   lastBlockID + i + 1,
-			BlockSize: 0, // should be updated by client after put
+			HostNames:  hostNames,
+			FileName:   lastBlock.FileName,
+			BlockID:    lastBlockID + i + 1,
+			BlockSize:  0, // should be updated by client after put
+			Generation: generation,
 		}
 	}
 	return toAppendBlockInfo, nil
 }
 
 func (l *LeaderServer) AppendFileOK(ctx context.Context, in *pb.AppendFileOKRequest) (*pb.AppendFileOKReply, error) {
+	compression, err := compress.Parse(in.GetCompression())
+	if err != nil {
+		return nil, err
+	}
 	for _, blockMeta := range in.BlockInfo {
-		newBlockMeta := metadata.BlockMeta{
-			HostNames: blockMeta.HostNames,
-			FileName:  blockMeta.FileName,
-			BlockID:   blockMeta.BlockID,
-			BlockSize: blockMeta.BlockSize,
-		}
-		err := l.metadata.AddOrUpdateBlockMeta(in.FileName, newBlockMeta)
+		newBlockMeta := fromProtoBlockMeta(blockMeta)
+		oldBlockMeta, oldErr := l.metadata.GetBlockMeta(blockMeta.FileName, blockMeta.BlockID)
+		err := l.metadata.AddOrUpdateBlockMeta(in.FileName, newBlockMeta, auth.Caller(ctx))
 		if err != nil {
 			return nil, err
 		}
+		if oldErr != nil {
+			continue
+		}
+		// the replicas of the previous generation are removed by collectGarbage once no client reads the file
+		garbage := metadata.GarbageOf(metadata.BlockInfo{oldBlockMeta.BlockID: oldBlockMeta}, metadata.BlockInfo{newBlockMeta.BlockID: newBlockMeta})
+		if err := l.metadata.AddGarbage(garbage); err != nil {
+			logrus.Errorf("failed to add garbage of file %s: %v", in.FileName, err)
+		}
+	}
+	// the replicas of the existing blocks are added or trimmed by recoverReplica and trimReplicas
+	if in.GetReplication() > 0 {
+		if err := l.metadata.SetReplication(in.FileName, int(in.GetReplication()), auth.Caller(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	// the blocks written before keep their codecs
+	if fileInfo, err := l.metadata.GetFile(in.FileName); err == nil && fileInfo.Compression != compression.String() {
+		if err := l.metadata.SetCompression(in.FileName, compression.String(), auth.Caller(ctx)); err != nil {
+			return nil, err
+		}
 	}
 	return &pb.AppendFileOKReply{}, nil
 }
@@ -0,0 +1,48 @@
+package leaderserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// ReportBadBlock handles a corrupted replica found by a client through gRPC.
+func (l *LeaderServer) ReportBadBlock(ctx context.Context, in *pb.ReportBadBlockRequest) (*pb.ReportBadBlockReply, error) {
+	if l.getLeader() != l.hostname {
+		return nil, fmt.Errorf("%s is not the leader", l.hostname)
+	}
+	return &pb.ReportBadBlockReply{}, l.removeBadReplica(in.GetFileName(), in.GetBlockID(), in.GetGeneration(), in.GetHostName())
+}
+
+// removeBadReplica removes a corrupted replica from the metadata and deletes it from the data server,
+// recoverReplica re-replicates the block from the other replicas. A corrupted replica of another generation is an
+// orphan and removed by the garbage collector.
+func (l *LeaderServer) removeBadReplica(fileName string, blockID int64, generation int64, hostName string) error {
+	l.reconcileMu.Lock()
+	defer l.reconcileMu.Unlock()
+	blockMeta, err := l.metadata.GetBlockMeta(fileName, blockID)
+	if err != nil {
+		return err
+	}
+	if blockMeta.Generation != generation || !containsHost(blockMeta.HostNames, hostName) {
+		// already removed
+		return nil
+	}
+	if len(blockMeta.HostNames) == 1 {
+		// the only replica of an erasure-coded block is reconstructed from its stripe by recoverReplica
+		if version, ok := l.metadata.VersionOf(fileName); !ok || !l.policyOf(version).IsErasureCoded() {
+			return fmt.Errorf("cannot remove the only replica %s of block %d of file %s", hostName, blockID, fileName)
+		}
+	}
+	logrus.Warnf("Replica of block %d of file %s on %s is corrupted", blockID, fileName, hostName)
+	l.removeReplica(blockMeta, hostName)
+	return l.metadata.AddGarbage([]metadata.GarbageBlock{{
+		HostName:   hostName,
+		FileName:   blockMeta.FileName,
+		BlockID:    blockID,
+		Generation: generation,
+	}})
+}
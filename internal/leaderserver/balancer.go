@@ -0,0 +1,297 @@
+package leaderserver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// defaultBalancerBandwidth is used if the balancer bandwidth is not set in config.
+const defaultBalancerBandwidth = 10 * 1024 * 1024
+
+// Balance trims the excess replicas and moves blocks from the fullest to the emptiest data servers through gRPC.
+// It returns when the disk usage of every data server is within the threshold of the average or no block can be moved.
+func (l *LeaderServer) Balance(ctx context.Context, in *pb.BalanceRequest) (*pb.BalanceReply, error) {
+	if l.getLeader() != l.hostname {
+		return nil, fmt.Errorf("%s is not the leader", l.hostname)
+	}
+	threshold := in.GetThreshold()
+	if threshold <= 0 || threshold >= 1 {
+		return nil, fmt.Errorf("threshold %v is not between 0 and 1", threshold)
+	}
+	if !l.balanceMu.TryLock() {
+		return nil, fmt.Errorf("balancer is already running")
+	}
+	defer l.balanceMu.Unlock()
+
+	logrus.Infof("Start balancing with threshold %.2f", threshold)
+	reply := &pb.BalanceReply{TrimmedReplicas: l.trimReplicas()}
+	if err := l.moveBlocks(ctx, threshold, reply); err != nil {
+		return nil, err
+	}
+	logrus.Infof("Balanced: %+v", reply)
+	return reply, nil
+}
+
+// trimReplicas removes the replicas beyond the replication of each file, e.g. the replicas on a data server which comes
+// back after its blocks are re-replicated or after the replication is lowered, and returns the number of removed replicas.
+func (l *LeaderServer) trimReplicas() int64 {
+	// only leader can trim replicas
+	if l.getLeader() != l.hostname {
+		return 0
+	}
+	nodes, err := l.aliveNodes()
+	if err != nil {
+		logrus.Errorf("Failed to get alive data servers: %v", err)
+		return 0
+	}
+	var trimmed int64 = 0
+	for fileName, fileInfo := range l.metadata.GetFileInfo() {
+		if l.isBeingWritten(fileName) {
+			continue
+		}
+		for _, version := range fileInfo.AllVersions() {
+			replication := l.replicationOf(fileInfo, version)
+			for _, blockMeta := range version.Blocks() {
+				blockID := blockMeta.BlockID
+				// only the alive replicas are counted, recoverReplica drops the others
+				replicas := []Node{}
+				for _, node := range nodes {
+					if containsHost(blockMeta.HostNames, node.HostName) {
+						replicas = append(replicas, node)
+					}
+				}
+				excess := len(replicas) - replication
+				if excess <= 0 {
+					continue
+				}
+				for _, hostName := range l.placementPolicy.ChooseExcess(excess, replicas) {
+					if err := l.removeExcessReplica(blockMeta.FileName, blockID, blockMeta.Generation, replication, hostName); err != nil {
+						logrus.Errorf("Failed to remove excess replica %s of block %d of file %s: %v", hostName, blockID, fileName, err)
+						continue
+					}
+					trimmed++
+				}
+			}
+		}
+	}
+	return trimmed
+}
+
+// removeExcessReplica removes a replica of a block if the block still has more replicas than replication, the replica is
+// deleted from the data server by the garbage collector.
+func (l *LeaderServer) removeExcessReplica(fileName string, blockID int64, generation int64, replication int, hostName string) error {
+	l.reconcileMu.Lock()
+	defer l.reconcileMu.Unlock()
+	blockMeta, err := l.metadata.GetBlockMeta(fileName, blockID)
+	if err != nil {
+		return err
+	}
+	if blockMeta.Generation != generation || !containsHost(blockMeta.HostNames, hostName) || len(blockMeta.HostNames) <= replication {
+		return fmt.Errorf("block is not over-replicated")
+	}
+	logrus.Infof("Remove excess replica of block %d of file %s on %s", blockID, fileName, hostName)
+	l.removeReplica(blockMeta, hostName)
+	return l.metadata.AddGarbage([]metadata.GarbageBlock{{
+		HostName:   hostName,
+		FileName:   blockMeta.FileName,
+		BlockID:    blockID,
+		Generation: generation,
+	}})
+}
+
+// moveBlocks moves blocks from the data servers above the average disk usage to the ones below it until the disk usage
+// of every data server is within the threshold of the average. Each block is moved at most once.
+func (l *LeaderServer) moveBlocks(ctx context.Context, threshold float64, reply *pb.BalanceReply) error {
+	nodes, err := l.aliveNodes()
+	if err != nil {
+		return fmt.Errorf("failed to get alive data servers: %v", err)
+	}
+	// the disks are estimated from the moved blocks, the block reports are not updated until the blocks are deleted
+	disks := map[string]*disk{}
+	var capacity, used int64 = 0, 0
+	for _, node := range nodes {
+		d, ok := l.blockReports.getDisk(node.HostName)
+		if !ok {
+			logrus.Warnf("Skip data server %s without disk usage", node.HostName)
+			continue
+		}
+		disks[node.HostName] = &d
+		capacity += d.capacity
+		used += d.used
+	}
+	if len(disks) < 2 {
+		return nil
+	}
+	average := float64(used) / float64(capacity)
+	reply.AverageUsage = average
+	usage := func(hostName string) float64 {
+		return float64(disks[hostName].used) / float64(disks[hostName].capacity)
+	}
+
+	blocks := map[string][]metadata.BlockMeta{} // blocks on each data server
+	for fileName, fileInfo := range l.metadata.GetFileInfo() {
+		if l.isBeingWritten(fileName) {
+			continue
+		}
+		for _, version := range fileInfo.AllVersions() {
+			// the blocks of a stripe must stay on distinct data servers
+			if l.policyOf(version).IsErasureCoded() {
+				continue
+			}
+			for _, blockMeta := range version.Blocks() {
+				for _, hostName := range blockMeta.HostNames {
+					blocks[hostName] = append(blocks[hostName], blockMeta)
+				}
+			}
+		}
+	}
+	tried := map[string]bool{}
+
+	startedAt := time.Now()
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("balancer is stopped: %v", err)
+		}
+		over, above, under, below := []string{}, []string{}, []string{}, []string{}
+		for hostName := range disks {
+			switch u := usage(hostName); {
+			case u > average+threshold:
+				over = append(over, hostName)
+			case u > average:
+				above = append(above, hostName)
+			case u < average-threshold:
+				under = append(under, hostName)
+			case u < average:
+				below = append(below, hostName)
+			}
+		}
+		if len(over) == 0 && len(under) == 0 {
+			return nil
+		}
+		// the data servers beyond the threshold are balanced with the ones on the other side of the average
+		sources, targets := over, under
+		if len(sources) == 0 {
+			sources = above
+		}
+		if len(targets) == 0 {
+			targets = below
+		}
+		sort.Slice(sources, func(i, j int) bool { return usage(sources[i]) > usage(sources[j]) })
+		sort.Slice(targets, func(i, j int) bool { return usage(targets[i]) < usage(targets[j]) })
+
+		from, to, blockMeta, ok := l.chooseMove(sources, targets, blocks, tried, func(hostName string, size int64) bool {
+			return float64(disks[hostName].used+size)/float64(disks[hostName].capacity) <= average+threshold
+		})
+		if !ok {
+			logrus.Infof("No block can be moved to balance the data servers")
+			return nil
+		}
+		tried[reportedBlockKey(blockMeta.FileName, blockMeta.BlockID, blockMeta.Generation)] = true
+		if err := l.moveBlock(blockMeta, from, to); err != nil {
+			logrus.Errorf("Failed to move block %d of file %s from %s to %s: %v", blockMeta.BlockID, blockMeta.FileName, from, to, err)
+			continue
+		}
+		disks[from].used -= blockMeta.PhysicalSize()
+		disks[to].used += blockMeta.PhysicalSize()
+		reply.MovedBlocks++
+		reply.MovedBytes += blockMeta.PhysicalSize()
+		l.throttleBalance(ctx, startedAt, reply.MovedBytes)
+	}
+}
+
+// chooseMove returns a block on the first source which can be moved to the first possible target. A block is not moved
+// to a data server which has a replica of it, or to a zone which has a replica of it unless the source is in the same zone.
+func (l *LeaderServer) chooseMove(sources, targets []string, blocks map[string][]metadata.BlockMeta, tried map[string]bool, fits func(hostName string, size int64) bool) (string, string, metadata.BlockMeta, bool) {
+	for _, from := range sources {
+		for _, to := range targets {
+			for _, blockMeta := range blocks[from] {
+				if tried[reportedBlockKey(blockMeta.FileName, blockMeta.BlockID, blockMeta.Generation)] || containsHost(blockMeta.HostNames, to) {
+					continue
+				}
+				if !fits(to, blockMeta.PhysicalSize()) {
+					continue
+				}
+				zoneTaken := false
+				for _, hostName := range blockMeta.HostNames {
+					if hostName != from && l.zones[hostName] == l.zones[to] {
+						zoneTaken = true
+					}
+				}
+				if zoneTaken && l.zones[from] != l.zones[to] {
+					continue
+				}
+				return from, to, blockMeta, true
+			}
+		}
+	}
+	return "", "", metadata.BlockMeta{}, false
+}
+
+// moveBlock copies a block from a data server to another, then replaces the replica in metadata and deletes the old one
+// by the garbage collector.
+func (l *LeaderServer) moveBlock(blockMeta metadata.BlockMeta, from, to string) error {
+	err := l.replicate(ToReplicate{
+		FileName:   blockMeta.FileName,
+		BlockID:    blockMeta.BlockID,
+		Generation: blockMeta.Generation,
+		From:       from,
+		To:         to,
+	})
+	if err != nil {
+		return err
+	}
+	l.reconcileMu.Lock()
+	defer l.reconcileMu.Unlock()
+	current, err := l.metadata.GetBlockMeta(blockMeta.FileName, blockMeta.BlockID)
+	if err != nil {
+		return err
+	}
+	if current.Generation != blockMeta.Generation || !containsHost(current.HostNames, from) {
+		// the copy is an orphan and removed by the garbage collector
+		return fmt.Errorf("block is changed while moving")
+	}
+	hostNames := []string{}
+	for _, hostName := range current.HostNames {
+		if hostName != from && hostName != to {
+			hostNames = append(hostNames, hostName)
+		}
+	}
+	current.HostNames = append(hostNames, to)
+	if err := l.metadata.UpdateBlockMeta(current); err != nil {
+		return err
+	}
+	logrus.Infof("Moved block %d of file %s from %s to %s", blockMeta.BlockID, blockMeta.FileName, from, to)
+	return l.metadata.AddGarbage([]metadata.GarbageBlock{{
+		HostName:   from,
+		FileName:   current.FileName,
+		BlockID:    current.BlockID,
+		Generation: current.Generation,
+	}})
+}
+
+// throttleBalance sleeps until moving movedBytes since startedAt is within the balancer bandwidth.
+func (l *LeaderServer) throttleBalance(ctx context.Context, startedAt time.Time, movedBytes int64) {
+	bandwidth := l.balancerBandwidth
+	if bandwidth <= 0 {
+		bandwidth = defaultBalancerBandwidth
+	}
+	expected := time.Duration(float64(movedBytes) / float64(bandwidth) * float64(time.Second))
+	if wait := expected - time.Since(startedAt); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+		}
+	}
+}
+
+// isBeingWritten returns whether a client holds the write lock of a file or records are appended to it, the blocks of
+// the file may be changed.
+func (l *LeaderServer) isBeingWritten(fileName string) bool {
+	return l.isWriteLocked(fileName) || l.records.isOpen(fileName)
+}
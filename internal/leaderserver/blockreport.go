@@ -0,0 +1,285 @@
+package leaderserver
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// BlockReports keeps the block inventory reported by each data server.
+type BlockReports struct {
+	inventory map[string]map[string]*pb.ReportedBlock // map[hostname]map[blockKey]ReportedBlock
+	orphans   map[string]map[string]*pb.ReportedBlock // blocks on data servers which are not referenced by metadata
+	disks     map[string]disk                         // disk reported by each data server
+
+	rebuildUntil time.Time // unknown blocks are adopted into metadata until rebuildUntil
+
+	mu sync.Mutex
+}
+
+// NewBlockReports returns a new BlockReports
+func NewBlockReports() *BlockReports {
+	return &BlockReports{
+		inventory: map[string]map[string]*pb.ReportedBlock{},
+		orphans:   map[string]map[string]*pb.ReportedBlock{},
+		disks:     map[string]disk{},
+		mu:        sync.Mutex{},
+	}
+}
+
+// BlockReport handles the block report from a data server through gRPC.
+func (l *LeaderServer) BlockReport(ctx context.Context, in *pb.BlockReportRequest) (*pb.BlockReportReply, error) {
+	if l.getLeader() != l.hostname {
+		return nil, fmt.Errorf("%s is not the leader", l.hostname)
+	}
+	hostName := in.GetHostName()
+	if !in.GetFull() && !l.blockReports.hasFullReport(hostName) {
+		return &pb.BlockReportReply{NeedFullReport: true}, nil
+	}
+	l.blockReports.update(hostName, in.GetFull(), in.GetAddedBlocks(), in.GetRemovedBlocks())
+	l.blockReports.setDiskUsage(hostName, in.GetDiskCapacity(), in.GetDiskUsed())
+	l.reconcileBlockReport(hostName, in.GetFull(), in.GetAddedBlocks(), in.GetRemovedBlocks())
+	return &pb.BlockReportReply{}, nil
+}
+
+// startRebuildingMetadata is called when this server becomes the leader and has applied the replicated log.
+// The data servers send full block reports to the new leader. If the metadata is lost on all leader servers,
+// the blocks are adopted from the block reports for a few report intervals.
+func (l *LeaderServer) startRebuildingMetadata() {
+	l.blockReports.mu.Lock()
+	defer l.blockReports.mu.Unlock()
+	l.blockReports.inventory = map[string]map[string]*pb.ReportedBlock{}
+	l.blockReports.orphans = map[string]map[string]*pb.ReportedBlock{}
+	l.blockReports.rebuildUntil = time.Time{}
+	if len(l.metadata.GetFileInfo()) != 0 {
+		return
+	}
+	l.blockReports.rebuildUntil = time.Now().Add(3 * l.blockReportInterval)
+	logrus.Infof("Rebuilding metadata from block reports")
+}
+
+// reconcileBlockReport updates the metadata with the reported blocks of a data server.
+func (l *LeaderServer) reconcileBlockReport(hostName string, full bool, added, removed []*pb.ReportedBlock) {
+	l.reconcileMu.Lock()
+	defer l.reconcileMu.Unlock()
+	for _, block := range added {
+		blockMeta, err := l.metadata.GetBlockMeta(block.GetFileName(), block.GetBlockID())
+		if err != nil {
+			// the block is not in metadata
+			if l.blockReports.shouldAdopt(block) {
+				blockMeta = metadata.BlockMeta{
+					HostNames:  []string{hostName},
+					FileName:   block.GetFileName(),
+					BlockID:    block.GetBlockID(),
+					BlockSize:  block.GetBlockSize(),
+					Generation: block.GetGeneration(),
+				}
+				// the path of a file is lost with the metadata, the storage name of a new file is its escaped path. The
+				// codec of a compressed block is also lost, it is adopted as the stored frames.
+				fileName, err := url.PathUnescape(block.GetFileName())
+				if err != nil {
+					fileName = block.GetFileName()
+				}
+				logrus.Infof("Adopted block %d of file %s from %s", block.GetBlockID(), fileName, hostName)
+				if err := l.metadata.AddOrUpdateBlockMeta(fileName, blockMeta, ""); err != nil {
+					logrus.Errorf("failed to adopt block %d of file %s: %v", block.GetBlockID(), fileName, err)
+				}
+				continue
+			}
+			l.blockReports.addOrphan(hostName, block)
+			continue
+		}
+		if blockMeta.Generation != block.GetGeneration() {
+			// stale generation of the block, e.g. the host failed before the last append
+			l.blockReports.addOrphan(hostName, block)
+			continue
+		}
+		if containsHost(blockMeta.HostNames, hostName) {
+			continue
+		}
+		if blockMeta.PhysicalSize() != block.GetBlockSize() {
+			// incomplete copy of the block
+			l.blockReports.addOrphan(hostName, block)
+			continue
+		}
+		if l.metadata.IsGarbage(metadata.GarbageBlock{HostName: hostName, FileName: block.GetFileName(), BlockID: block.GetBlockID(), Generation: block.GetGeneration()}) {
+			// e.g. a corrupted replica waiting to be deleted
+			continue
+		}
+		// the replica is back, e.g. the host restarted or the metadata was not synced
+		logrus.Infof("Found replica of block %d of file %s on %s", block.GetBlockID(), block.GetFileName(), hostName)
+		l.addReplica(blockMeta, hostName)
+	}
+	for _, block := range removed {
+		l.blockReports.removeOrphan(hostName, block)
+		blockMeta, err := l.metadata.GetBlockMeta(block.GetFileName(), block.GetBlockID())
+		if err != nil || blockMeta.Generation != block.GetGeneration() {
+			continue
+		}
+		if containsHost(blockMeta.HostNames, hostName) {
+			logrus.Infof("Replica of block %d of file %s is removed from %s", block.GetBlockID(), block.GetFileName(), hostName)
+			l.removeReplica(blockMeta, hostName)
+		}
+	}
+	if !full {
+		return
+	}
+	// replicas in metadata which are missing in the full report
+	for fileName, fileInfo := range l.metadata.GetFileInfo() {
+		for _, blockMeta := range fileInfo.Blocks() {
+			if !containsHost(blockMeta.HostNames, hostName) {
+				continue
+			}
+			if l.blockReports.hasBlock(hostName, blockMeta.FileName, blockMeta.BlockID, blockMeta.Generation) {
+				continue
+			}
+			logrus.Warnf("Replica of block %d of file %s is missing on %s", blockMeta.BlockID, fileName, hostName)
+			l.removeReplica(blockMeta, hostName)
+		}
+	}
+}
+
+// addReplica adds a host to the replicas of a block.
+func (l *LeaderServer) addReplica(blockMeta metadata.BlockMeta, hostName string) {
+	hostNames := append(append([]string{}, blockMeta.HostNames...), hostName)
+	blockMeta.HostNames = hostNames
+	if err := l.metadata.UpdateBlockMeta(blockMeta); err != nil {
+		logrus.Errorf("failed to add replica %s of block %d of file %s: %v", hostName, blockMeta.BlockID, blockMeta.FileName, err)
+	}
+}
+
+// removeReplica removes a host from the replicas of a block, recoverReplica will re-replicate it.
+func (l *LeaderServer) removeReplica(blockMeta metadata.BlockMeta, hostName string) {
+	hostNames := []string{}
+	for _, host := range blockMeta.HostNames {
+		if host != hostName {
+			hostNames = append(hostNames, host)
+		}
+	}
+	blockMeta.HostNames = hostNames
+	if err := l.metadata.UpdateBlockMeta(blockMeta); err != nil {
+		logrus.Errorf("failed to remove replica %s of block %d of file %s: %v", hostName, blockMeta.BlockID, blockMeta.FileName, err)
+	}
+}
+
+type disk struct {
+	capacity int64
+	used     int64
+}
+
+// setDiskUsage records the disk usage of a data server.
+func (br *BlockReports) setDiskUsage(hostName string, capacity, used int64) {
+	if capacity <= 0 {
+		return
+	}
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	br.disks[hostName] = disk{capacity: capacity, used: used}
+}
+
+// getDiskUsage returns the used fraction of the disk of a data server, 0 if it is not reported yet.
+func (br *BlockReports) getDiskUsage(hostName string) float64 {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	d, ok := br.disks[hostName]
+	if !ok {
+		return 0
+	}
+	return float64(d.used) / float64(d.capacity)
+}
+
+// getDisk returns the disk of a data server and whether it is reported.
+func (br *BlockReports) getDisk(hostName string) (disk, bool) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	d, ok := br.disks[hostName]
+	return d, ok
+}
+
+// update updates the inventory of a data server.
+func (br *BlockReports) update(hostName string, full bool, added, removed []*pb.ReportedBlock) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	if full || br.inventory[hostName] == nil {
+		br.inventory[hostName] = map[string]*pb.ReportedBlock{}
+		br.orphans[hostName] = map[string]*pb.ReportedBlock{}
+	}
+	for _, block := range added {
+		br.inventory[hostName][reportedBlockKey(block.GetFileName(), block.GetBlockID(), block.GetGeneration())] = block
+	}
+	for _, block := range removed {
+		delete(br.inventory[hostName], reportedBlockKey(block.GetFileName(), block.GetBlockID(), block.GetGeneration()))
+	}
+}
+
+func (br *BlockReports) hasFullReport(hostName string) bool {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	_, ok := br.inventory[hostName]
+	return ok
+}
+
+func (br *BlockReports) hasBlock(hostName, fileName string, blockID int64, generation int64) bool {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	_, ok := br.inventory[hostName][reportedBlockKey(fileName, blockID, generation)]
+	return ok
+}
+
+// shouldAdopt returns whether an unknown block should be adopted into metadata.
+func (br *BlockReports) shouldAdopt(block *pb.ReportedBlock) bool {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	return time.Now().Before(br.rebuildUntil)
+}
+
+func (br *BlockReports) addOrphan(hostName string, block *pb.ReportedBlock) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	if br.orphans[hostName] == nil {
+		br.orphans[hostName] = map[string]*pb.ReportedBlock{}
+	}
+	key := reportedBlockKey(block.GetFileName(), block.GetBlockID(), block.GetGeneration())
+	if _, ok := br.orphans[hostName][key]; !ok {
+		logrus.Warnf("Found orphaned block %d of file %s on %s", block.GetBlockID(), block.GetFileName(), hostName)
+	}
+	br.orphans[hostName][key] = block
+}
+
+func (br *BlockReports) removeOrphan(hostName string, block *pb.ReportedBlock) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	delete(br.orphans[hostName], reportedBlockKey(block.GetFileName(), block.GetBlockID(), block.GetGeneration()))
+}
+
+// getOrphans returns the orphaned blocks of each data server.
+func (br *BlockReports) getOrphans() map[string][]*pb.ReportedBlock {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	orphans := map[string][]*pb.ReportedBlock{}
+	for hostName, blocks := range br.orphans {
+		for _, block := range blocks {
+			orphans[hostName] = append(orphans[hostName], block)
+		}
+	}
+	return orphans
+}
+
+func reportedBlockKey(fileName string, blockID int64, generation int64) string {
+	return fmt.Sprintf("%s_%d_g%d", fileName, blockID, generation)
+}
+
+func containsHost(hostNames []string, hostName string) bool {
+	for _, host := range hostNames {
+		if host == hostName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package leaderserver
+
+import (
+	"fmt"
+
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/compress"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
+)
+
+// getCompression returns the codec of the blocks written to a file, the one in the request or the one of the file. The
+// blocks of an erasure-coded file are not compressed since the stripes are encoded from the data of the blocks.
+func (l *LeaderServer) getCompression(fileName string, requested string, policy erasure.Policy) (compress.Codec, error) {
+	if requested != "" {
+		codec, err := compress.Parse(requested)
+		if err != nil {
+			return compress.None, err
+		}
+		if codec.IsCompressed() && policy.IsErasureCoded() {
+			return compress.None, fmt.Errorf("cannot compress erasure-coded file %s with policy %s", fileName, policy)
+		}
+		return codec, nil
+	}
+	fileInfo, err := l.metadata.GetFile(fileName)
+	if err != nil || policy.IsErasureCoded() {
+		return compress.None, nil
+	}
+	codec, err := compress.Parse(fileInfo.Compression)
+	if err != nil {
+		return compress.None, fmt.Errorf("invalid compression of file %s: %v", fileName, err)
+	}
+	return codec, nil
+}
@@ -4,14 +4,39 @@ import (
 	"context"
 	"fmt"
 
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
 )
 
 func (l *LeaderServer) DelFile(ctx context.Context, in *pb.DelFileRequest) (*pb.DelFileReply, error) {
+	if l.metadata.IsDir(in.FileName) {
+		return &pb.DelFileReply{}, l.delDir(in.FileName, in.GetRecursive(), auth.Caller(ctx))
+	}
 	if !l.metadata.IsFileExist(in.FileName) {
 		return nil, fmt.Errorf("file %s does not exist", in.FileName)
 	}
-	// TODO: acquire file semaphore?
-	l.metadata.DelFile(in.FileName)
+	if l.metadata.IsLocked(in.FileName, in.GetClientID()) {
+		return nil, fmt.Errorf("file %s is locked by other clients", in.FileName)
+	}
+	// the replicas of all versions are removed from the data servers by collectGarbage
+	if err := l.metadata.DelFile(in.FileName, auth.Caller(ctx)); err != nil {
+		return nil, err
+	}
 	return &pb.DelFileReply{}, nil
 }
+
+// delDir deletes a directory, a directory which is not empty is only deleted if recursive.
+func (l *LeaderServer) delDir(dirName string, recursive bool, caller string) error {
+	entries, err := l.metadata.ListDir(dirName)
+	if err != nil {
+		return err
+	}
+	if len(entries) != 0 && !recursive {
+		return fmt.Errorf("directory %s is not empty", dirName)
+	}
+	if l.metadata.IsLocked(dirName, "") {
+		return fmt.Errorf("files in directory %s are locked", dirName)
+	}
+	// the replicas of the files are removed from the data servers by collectGarbage
+	return l.metadata.DelDir(dirName, caller)
+}
@@ -2,106 +2,102 @@ package leaderserver
 
 import (
 	"context"
-	"sync"
-	"time"
 
 	"github.com/sirupsen/logrus"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
-	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/memberserver/heartbeat"
-	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/memberserver/membership"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
-func (l *LeaderServer) startElectingLeader() {
-	logrus.Info("Start electing leader")
-	l.electLeaderTicker = time.NewTicker(time.Second * 5)
-	defer l.electLeaderTicker.Stop()
-	for {
-		select {
-		case <-l.electLeaderTickerDone:
-			return
-		case <-l.electLeaderTicker.C:
-			l.electLeader()
-		}
-	}
+// RequestVote handles the vote request of a candidate through gRPC.
+func (l *LeaderServer) RequestVote(ctx context.Context, in *pb.RequestVoteRequest) (*pb.RequestVoteReply, error) {
+	return l.raft.handleRequestVote(in)
 }
 
-// Elect Leader
-func (l *LeaderServer) electLeader() {
-	heartbeat, err := heartbeat.GetInstance()
-	if err != nil {
-		logrus.Errorf("failed to get heartbeat instance: %v", err)
+// startElection becomes a candidate of the next term and requests votes from the peers.
+func (r *Raft) startElection() {
+	r.mu.Lock()
+	r.role = roleCandidate
+	r.term++
+	r.votedFor = r.hostname
+	r.setLeader("")
+	r.resetElectionTimer()
+	if err := r.persist(); err != nil {
+		logrus.Errorf("failed to persist raft state: %v", err)
+		r.mu.Unlock()
 		return
 	}
-	_membership := heartbeat.GetMembership()
-	if _membership == nil {
-		logrus.Debugf("failed to get membership instance")
-		return
+	term := r.term
+	request := &pb.RequestVoteRequest{
+		Term:         term,
+		Candidate:    r.hostname,
+		LastLogIndex: r.log.LastIndex(),
+		LastLogTerm:  r.log.LastTerm(),
 	}
-	members := _membership.GetAliveMembers()
-	// If leader is not alive, reset the leader
-	leader := l.getLeader()
-	if _, ok := members[leader]; !ok {
-		l.setLeader("")
+	logrus.Infof("Start election of term %d", term)
+	votes := 1
+	if votes >= r.majority() {
+		r.becomeLeader()
 	}
-	// If there is no leader, elect a leader
-	leader = l.getLeader()
-	if leader == "" {
-		// See if the alive node with the largest ID is me
-		leader = l.electLeaderFromMembers(members)
-	}
-	// If I am not the leader, do nothing (wait for the leader to update me)
-	if leader != l.hostname {
-		return
-	}
-	// I am the leader, update the other nodes
-	var wg sync.WaitGroup
-	for _, member := range members {
-		wg.Add(1)
-		go func(member *membership.Member) {
-			defer wg.Done()
-			hostname := member.GetName()
-			conn, err := grpc.Dial(hostname+":"+l.port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.mu.Unlock()
+
+	for _, peer := range r.peers {
+		go func(peer string) {
+			reply, err := r.requestVote(peer, request)
 			if err != nil {
-				logrus.Errorf("cannot connect to %s leaderServer: %v", hostname, err)
+				logrus.Debugf("failed to request vote from %s: %v", peer, err)
+				return
 			}
-			defer conn.Close()
-			client := pb.NewLeaderServerClient(conn)
-			_, err = client.SetLeader(context.Background(), &pb.SetLeaderRequest{Leader: leader})
-			if err != nil {
-				logrus.Errorf("failed to set leader of %s: %v", hostname, err)
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if reply.GetTerm() > r.term {
+				r.stepDown(reply.GetTerm())
+				return
+			}
+			if r.role != roleCandidate || r.term != term || !reply.GetVoteGranted() {
+				return
 			}
-		}(member)
+			votes++
+			if votes >= r.majority() {
+				r.becomeLeader()
+			}
+		}(peer)
 	}
-	wg.Wait()
-	return
 }
 
-// electLeader elects the leader and returns the leader hostname.
-func (l *LeaderServer) electLeaderFromMembers(members map[string]*membership.Member) string {
-	// // Find the members with the largest heartbeat, and then find the member with the smallest hostname
-	// maxHeartbeat := 0
-	// hostname := ""
-	// for _, member := range members {
-	// 	if member.Heartbeat > maxHeartbeat {
-	// 		maxHeartbeat = member.Heartbeat
-	// 		hostname = member.GetName()
-	// 	} else if member.Heartbeat == maxHeartbeat {
-	// 		if member.GetName() < hostname {
-	// 			hostname = member.GetName()
-	// 		}
-	// 	}
-	// }
+// handleRequestVote grants the vote if this server has not voted in the term and the log of the candidate is up to date.
+func (r *Raft) handleRequestVote(in *pb.RequestVoteRequest) (*pb.RequestVoteReply, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if in.GetTerm() > r.term {
+		r.stepDown(in.GetTerm())
+	}
+	reply := &pb.RequestVoteReply{Term: r.term}
+	if in.GetTerm() < r.term {
+		return reply, nil
+	}
+	if r.votedFor != "" && r.votedFor != in.GetCandidate() {
+		return reply, nil
+	}
+	lastLogIndex, lastLogTerm := r.log.LastIndex(), r.log.LastTerm()
+	if in.GetLastLogTerm() < lastLogTerm || (in.GetLastLogTerm() == lastLogTerm && in.GetLastLogIndex() < lastLogIndex) {
+		return reply, nil
+	}
+	r.votedFor = in.GetCandidate()
+	if err := r.persist(); err != nil {
+		return nil, err
+	}
+	r.resetElectionTimer()
+	reply.VoteGranted = true
+	logrus.Infof("Voted for %s in term %d", in.GetCandidate(), r.term)
+	return reply, nil
+}
 
-	// Find the member with the smallest hostname
-	hostname := ""
-	for _, member := range members {
-		if hostname == "" {
-			hostname = member.GetName()
-		} else if member.GetName() < hostname {
-			hostname = member.GetName()
-		}
+func (r *Raft) requestVote(peer string, request *pb.RequestVoteRequest) (*pb.RequestVoteReply, error) {
+	conn, err := r.conn(peer)
+	if err != nil {
+		return nil, err
 	}
-	return hostname
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), r.baseElectionTimeout/2)
+	defer cancel()
+	return pb.NewLeaderServerClient(conn).RequestVote(ctx, request)
 }
@@ -0,0 +1,210 @@
+package leaderserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
+	dataServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+)
+
+// ToReconstruct is a lost block of a stripe to be reconstructed on a data server from the other blocks of the stripe.
+type ToReconstruct struct {
+	Policy erasure.Policy
+	Blocks []metadata.BlockMeta // data blocks followed by parity blocks with the alive replicas, empty beyond the file
+	Index  int                  // index of the lost block in Blocks
+	To     string
+}
+
+// getPolicy returns the storage policy of a new version of a file, the one in the request or the one of the file.
+func (l *LeaderServer) getPolicy(fileName string, requested string) (erasure.Policy, error) {
+	if requested != "" {
+		return erasure.ParsePolicy(requested)
+	}
+	fileInfo, err := l.metadata.GetFile(fileName)
+	if err != nil {
+		// a new file is replicated
+		return erasure.Policy{}, nil
+	}
+	return l.policyOf(fileInfo.Current()), nil
+}
+
+// policyOf returns the storage policy of a version of a file.
+func (l *LeaderServer) policyOf(version metadata.FileVersion) erasure.Policy {
+	policy, err := erasure.ParsePolicy(version.Policy)
+	if err != nil {
+		logrus.Errorf("Invalid policy of version %d: %v", version.Version, err)
+	}
+	return policy
+}
+
+// putStripes selects the data servers of the blocks of a new erasure-coded version of a file, the data and parity
+// blocks of each stripe are placed on distinct data servers by the placement policy.
+func (l *LeaderServer) putStripes(fileName string, fileSize int64, generation int64, policy erasure.Policy) (metadata.BlockInfo, metadata.BlockInfo, error) {
+	blocksNum := fileSize / l.blockSize
+	if fileSize%l.blockSize != 0 {
+		blocksNum++
+	}
+	nodes, err := l.aliveNodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	storageName := l.metadata.NewStorageName(fileName)
+	blockInfo, parity := metadata.BlockInfo{}, metadata.BlockInfo{}
+	for stripe := int64(0); stripe < policy.Stripes(blocksNum); stripe++ {
+		dataBlockIDs := policy.DataBlockIDs(stripe, blocksNum)
+		parityBlockIDs := policy.ParityBlockIDs(stripe)
+		hostNames := l.placeReplicas(nodes, len(dataBlockIDs)+len(parityBlockIDs), nil)
+		if len(hostNames) < len(dataBlockIDs)+len(parityBlockIDs) {
+			return nil, nil, fmt.Errorf("only %d data servers available for a stripe of %d blocks of policy %s", len(hostNames), len(dataBlockIDs)+len(parityBlockIDs), policy)
+		}
+		for i, blockID := range dataBlockIDs {
+			blockInfo[blockID] = metadata.BlockMeta{
+				HostNames:  []string{hostNames[i]},
+				FileName:   storageName,
+				BlockID:    blockID,
+				BlockSize:  0, // should be updated by client after put
+				Generation: generation,
+			}
+		}
+		for i, blockID := range parityBlockIDs {
+			parity[blockID] = metadata.BlockMeta{
+				HostNames:  []string{hostNames[len(dataBlockIDs)+i]},
+				FileName:   metadata.ParityStorageName(storageName),
+				BlockID:    blockID,
+				BlockSize:  0,
+				Generation: generation,
+			}
+		}
+	}
+	return blockInfo, parity, nil
+}
+
+// stripeBlocks returns the data blocks followed by the parity blocks of a stripe of an erasure-coded version, the data
+// blocks beyond the end of the file are empty.
+func stripeBlocks(version metadata.FileVersion, policy erasure.Policy, stripe int64) []metadata.BlockMeta {
+	blocks := make([]metadata.BlockMeta, policy.Shards())
+	for i, blockID := range policy.DataBlockIDs(stripe, int64(len(version.BlockInfo))) {
+		blocks[i] = version.BlockInfo[blockID]
+	}
+	for i, blockID := range policy.ParityBlockIDs(stripe) {
+		blocks[policy.DataShards+i] = version.Parity[blockID]
+	}
+	return blocks
+}
+
+// lostStripeBlocks returns the blocks of an erasure-coded version without alive replicas, each one is reconstructed on
+// a data server which stores no other block of the stripe. A stripe with fewer than DataShards blocks is lost.
+func (l *LeaderServer) lostStripeBlocks(fileName string, version metadata.FileVersion, policy erasure.Policy, nodes []Node, alive map[string]struct{}) []ToReconstruct {
+	toReconstructs := []ToReconstruct{}
+	for stripe := int64(0); stripe < policy.Stripes(int64(len(version.BlockInfo))); stripe++ {
+		blocks := stripeBlocks(version, policy, stripe)
+		used := []string{}
+		lost := []int{}
+		for i, blockMeta := range blocks {
+			used = append(used, blockMeta.HostNames...)
+			aliveHostNames := []string{}
+			for _, hostName := range blockMeta.HostNames {
+				if _, ok := alive[hostName]; ok {
+					aliveHostNames = append(aliveHostNames, hostName)
+				}
+			}
+			blocks[i].HostNames = aliveHostNames
+			if blockMeta.FileName != "" && len(aliveHostNames) == 0 {
+				lost = append(lost, i)
+			}
+		}
+		if len(lost) == 0 {
+			continue
+		}
+		if len(blocks)-len(lost) < policy.DataShards {
+			logrus.Errorf("Stripe %d of file %s is lost, only %d of %d blocks are available", stripe, fileName, len(blocks)-len(lost), len(blocks))
+			continue
+		}
+		for _, index := range lost {
+			to := l.placeReplicas(nodes, 1, used)
+			if len(to) == 0 {
+				logrus.Debugf("No data server to reconstruct block %d of file %s", blocks[index].BlockID, fileName)
+				break
+			}
+			used = append(used, to[0])
+			toReconstructs = append(toReconstructs, ToReconstruct{
+				Policy: policy,
+				Blocks: blocks,
+				Index:  index,
+				To:     to[0],
+			})
+		}
+	}
+	return toReconstructs
+}
+
+// reconstructBlocks reconstructs the lost blocks of stripes on the data servers and replaces their replicas in metadata.
+func (l *LeaderServer) reconstructBlocks(toReconstructs []ToReconstruct) {
+	if len(toReconstructs) == 0 {
+		return
+	}
+	var wg sync.WaitGroup
+	for _, toReconstruct := range toReconstructs {
+		wg.Add(1)
+		go func(toReconstruct ToReconstruct) {
+			defer wg.Done()
+			lost := toReconstruct.Blocks[toReconstruct.Index]
+			if err := l.reconstruct(toReconstruct); err != nil {
+				logrus.Errorf("Failed to reconstruct block %d of file %s on %s: %v", lost.BlockID, lost.FileName, toReconstruct.To, err)
+				return
+			}
+			logrus.Infof("Reconstructed block %d of file %s on %s", lost.BlockID, lost.FileName, toReconstruct.To)
+			current, err := l.metadata.GetBlockMeta(lost.FileName, lost.BlockID)
+			if err != nil {
+				logrus.Errorf("Failed to get block meta of block %d of file %s: %v", lost.BlockID, lost.FileName, err)
+				return
+			}
+			if current.Generation != lost.Generation {
+				// the block is an orphan and removed by the garbage collector
+				logrus.Warnf("Block %d of file %s is written again while reconstructing", lost.BlockID, lost.FileName)
+				return
+			}
+			// the lost replica becomes an orphan if its data server comes back
+			current.HostNames = []string{toReconstruct.To}
+			if err := l.metadata.UpdateBlockMeta(current); err != nil {
+				logrus.Errorf("Failed to update block meta of block %d of file %s: %v", lost.BlockID, lost.FileName, err)
+			}
+		}(toReconstruct)
+	}
+	wg.Wait()
+}
+
+// reconstruct asks a data server to reconstruct a lost block from the other blocks of its stripe and store it.
+func (l *LeaderServer) reconstruct(toReconstruct ToReconstruct) error {
+	conn, err := connpool.Get(toReconstruct.To + ":" + l.dataServerPort)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := dataServerProto.NewDataServerClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*120)
+	defer cancel()
+	blocks := []*dataServerProto.StripeBlock{}
+	for _, blockMeta := range toReconstruct.Blocks {
+		blocks = append(blocks, &dataServerProto.StripeBlock{
+			FileName:   blockMeta.FileName,
+			BlockID:    blockMeta.BlockID,
+			Generation: blockMeta.Generation,
+			BlockSize:  blockMeta.BlockSize,
+			HostNames:  blockMeta.HostNames,
+		})
+	}
+	_, err = client.ReconstructFileBlock(ctx, &dataServerProto.ReconstructFileBlockRequest{
+		DataShards:   int32(toReconstruct.Policy.DataShards),
+		ParityShards: int32(toReconstruct.Policy.ParityShards),
+		Blocks:       blocks,
+		Index:        int32(toReconstruct.Index),
+	})
+	return err
+}
@@ -0,0 +1,172 @@
+package leaderserver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
+	dataServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/memberserver/heartbeat"
+)
+
+func (l *LeaderServer) startCollectingGarbage() {
+	if l.gcInterval <= 0 {
+		logrus.Warn("GC interval is not set, deleted blocks will not be removed from the data servers")
+		return
+	}
+	logrus.Info("Start collecting garbage")
+	l.collectGarbageTicker = time.NewTicker(l.gcInterval)
+	defer l.collectGarbageTicker.Stop()
+	for {
+		select {
+		case <-l.collectGarbageTickerDone:
+			return
+		case <-l.collectGarbageTicker.C:
+			l.collectGarbage()
+		}
+	}
+}
+
+func (l *LeaderServer) stopCollectingGarbage() {
+	l.collectGarbageTickerDone <- true
+}
+
+// collectGarbage removes the replicas of deleted or overwritten blocks and the orphaned blocks from the data servers.
+// Replicas on hosts which are not alive or of files being read are kept and retried in the next round.
+func (l *LeaderServer) collectGarbage() {
+	// only leader can collect garbage
+	leader := l.getLeader()
+	if leader != l.hostname {
+		return
+	}
+	heartbeat, err := heartbeat.GetInstance()
+	if err != nil {
+		logrus.Errorf("Failed to get heartbeat instance: %v", err)
+		return
+	}
+	membership := heartbeat.GetMembership()
+	if membership == nil {
+		logrus.Errorf("Failed to get membership instance")
+		return
+	}
+	members := membership.GetAliveMembers()
+
+	toDeletes := []metadata.GarbageBlock{}
+	toKeeps := []metadata.GarbageBlock{}
+	for _, block := range l.metadata.GetGarbage() {
+		// the block is written again on the same host
+		if l.isReplicaReferenced(block.HostName, block.FileName, block.BlockID, block.Generation) {
+			toKeeps = append(toKeeps, block)
+			continue
+		}
+		if _, ok := members[block.HostName]; !ok {
+			continue
+		}
+		// a client may still read the stale generation
+		if l.isBeingRead(block.FileName) {
+			continue
+		}
+		toDeletes = append(toDeletes, block)
+	}
+	if err := l.metadata.DelGarbage(toKeeps); err != nil {
+		logrus.Errorf("Failed to drop garbage %+v: %v", toKeeps, err)
+	}
+
+	toDeleteOrphans := []metadata.GarbageBlock{}
+	for hostName, blocks := range l.blockReports.getOrphans() {
+		if _, ok := members[hostName]; !ok {
+			continue
+		}
+		for _, block := range blocks {
+			// the block may be written by an ongoing put or replication
+			if time.Since(time.Unix(0, block.GetModTime())) < l.orphanGracePeriod {
+				continue
+			}
+			if l.isReplicaReferenced(hostName, block.GetFileName(), block.GetBlockID(), block.GetGeneration()) || l.isBeingRead(block.GetFileName()) {
+				continue
+			}
+			toDeleteOrphans = append(toDeleteOrphans, metadata.GarbageBlock{
+				HostName:   hostName,
+				FileName:   block.GetFileName(),
+				BlockID:    block.GetBlockID(),
+				Generation: block.GetGeneration(),
+			})
+		}
+	}
+	if len(toDeletes) == 0 && len(toDeleteOrphans) == 0 {
+		return
+	}
+
+	logrus.Infof("To delete %+v, orphans %+v", toDeletes, toDeleteOrphans)
+	deleted := l.deleteBlocks(toDeletes)
+	if err := l.metadata.DelGarbage(deleted); err != nil {
+		logrus.Errorf("Failed to drop garbage %+v: %v", deleted, err)
+	}
+	l.deleteBlocks(toDeleteOrphans)
+	logrus.Infof("Deleted %d of %d garbage blocks", len(deleted), len(toDeletes))
+}
+
+// deleteBlocks deletes the blocks from the data servers and returns the deleted ones.
+func (l *LeaderServer) deleteBlocks(blocks []metadata.GarbageBlock) []metadata.GarbageBlock {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	deleted := []metadata.GarbageBlock{}
+	for _, block := range blocks {
+		wg.Add(1)
+		go func(block metadata.GarbageBlock) {
+			defer wg.Done()
+			if err := l.deleteBlock(block); err != nil {
+				logrus.Errorf("Failed to delete %+v: %v", block, err)
+				return
+			}
+			mu.Lock()
+			deleted = append(deleted, block)
+			mu.Unlock()
+		}(block)
+	}
+	wg.Wait()
+	return deleted
+}
+
+// isReplicaReferenced returns whether the replica of the generation of the block on the host is in metadata.
+func (l *LeaderServer) isReplicaReferenced(hostName, fileName string, blockID int64, generation int64) bool {
+	blockMeta, err := l.metadata.GetBlockMeta(fileName, blockID)
+	if err != nil {
+		return false
+	}
+	return blockMeta.Generation == generation && containsHost(blockMeta.HostNames, hostName)
+}
+
+// isBeingRead returns whether a client holds the read lock of the file whose blocks are stored as storageName.
+func (l *LeaderServer) isBeingRead(storageName string) bool {
+	fileName, ok := l.metadata.PathOf(storageName)
+	if !ok {
+		return false
+	}
+	for _, lease := range l.metadata.GetLeases(fileName) {
+		if lease.Mode == metadata.ReadLock {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *LeaderServer) deleteBlock(block metadata.GarbageBlock) error {
+	conn, err := connpool.Get(block.HostName + ":" + l.dataServerPort)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := dataServerProto.NewDataServerClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	_, err = client.DeleteFileBlock(ctx, &dataServerProto.DeleteFileBlockRequest{
+		FileName:   block.FileName,
+		BlockID:    block.BlockID,
+		Generation: block.Generation,
+	})
+	return err
+}
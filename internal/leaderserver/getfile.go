@@ -4,32 +4,75 @@ import (
 	"context"
 	"fmt"
 
-	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
-
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
 )
 
 func (l *LeaderServer) GetBlockInfo(ctx context.Context, in *pb.GetBlockInfoRequest) (*pb.GetBlockInfoReply, error) {
-	blockInfo, err := l.getBlockInfo(in.FileName)
+	epoch := l.raft.getEpoch()
+	version, modIndex, err := l.metadata.GetVersionWithModIndex(in.FileName, in.GetVersion())
 	if err != nil {
 		return nil, err
 	}
-	getBlockInfoReplyBlockMeta := map[int64]*pb.BlockMeta{}
-	for blockID, blockMeta := range blockInfo {
-		getBlockInfoReplyBlockMeta[blockID] = &pb.BlockMeta{
-			HostNames: blockMeta.HostNames,
-			FileName:  blockMeta.FileName,
-			BlockID:   blockMeta.BlockID,
-			BlockSize: blockMeta.BlockSize,
-		}
-	}
 	return &pb.GetBlockInfoReply{
-		BlockInfo: getBlockInfoReplyBlockMeta,
+		BlockInfo: toProtoBlockInfo(version.BlockInfo),
+		Version:   version.Version,
+		Parity:    toProtoBlockInfo(version.Parity),
+		Policy:    version.Policy,
+		Epoch:     epoch,
+		ModIndex:  modIndex,
 	}, nil
 }
 
-func (l *LeaderServer) getBlockInfo(fileName string) (metadata.BlockInfo, error) {
-	return l.metadata.GetBlockInfo(fileName)
+// toProtoBlockInfo converts the blocks in metadata to the ones in gRPC messages.
+func toProtoBlockInfo(blockInfo metadata.BlockInfo) map[int64]*pb.BlockMeta {
+	protoBlockInfo := map[int64]*pb.BlockMeta{}
+	for blockID, blockMeta := range blockInfo {
+		protoBlockInfo[blockID] = toProtoBlockMeta(blockMeta)
+	}
+	return protoBlockInfo
+}
+
+// fromProtoBlockInfo converts the blocks in gRPC messages to the ones in metadata.
+func fromProtoBlockInfo(protoBlockInfo map[int64]*pb.BlockMeta) metadata.BlockInfo {
+	blockInfo := metadata.BlockInfo{}
+	for blockID, blockMeta := range protoBlockInfo {
+		blockInfo[blockID] = fromProtoBlockMeta(blockMeta)
+	}
+	return blockInfo
+}
+
+func toProtoBlockMeta(blockMeta metadata.BlockMeta) *pb.BlockMeta {
+	return &pb.BlockMeta{
+		HostNames:   blockMeta.HostNames,
+		FileName:    blockMeta.FileName,
+		BlockID:     blockMeta.BlockID,
+		BlockSize:   blockMeta.BlockSize,
+		Generation:  blockMeta.Generation,
+		Compression: blockMeta.Compression,
+		StoredSize:  blockMeta.StoredSize,
+	}
+}
+
+func fromProtoBlockMeta(blockMeta *pb.BlockMeta) metadata.BlockMeta {
+	return metadata.BlockMeta{
+		HostNames:   blockMeta.GetHostNames(),
+		FileName:    blockMeta.GetFileName(),
+		BlockID:     blockMeta.GetBlockID(),
+		BlockSize:   blockMeta.GetBlockSize(),
+		Generation:  blockMeta.GetGeneration(),
+		Compression: blockMeta.GetCompression(),
+		StoredSize:  blockMeta.GetStoredSize(),
+	}
+}
+
+// GetVersions returns the versions of a file which are kept through gRPC.
+func (l *LeaderServer) GetVersions(ctx context.Context, in *pb.GetVersionsRequest) (*pb.GetVersionsReply, error) {
+	versions, err := l.metadata.GetVersions(in.GetFileName())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetVersionsReply{Versions: versions}, nil
 }
 
 func (l *LeaderServer) GetFileOK(ctx context.Context, in *pb.GetFileOKRequest) (*pb.GetFileOKReply, error) {
@@ -5,54 +5,106 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
-	"google.golang.org/grpc"
 )
 
 // LeaderServer handles file operations permission and Leader election.
 type LeaderServer struct {
 	port           string
 	dataServerPort string
-	leader         string
 	hostname       string
+	raft           *Raft
 	metadata       *metadata.Metadata
 	fileLock       *FileLock
+	records        *Records
 	blockSize      int64
+	maxVersions    int // versions kept of a file unless set for the file
+
+	expireLocksTicker     *time.Ticker
+	expireLocksTickerDone chan bool
 
 	recoverReplicaTicker     *time.Ticker
 	recoverReplicaTickerDone chan bool
 	replicationFactor        int
+	placementPolicy          PlacementPolicy
+	zones                    map[string]string // zone of each machine
+
+	balancerBandwidth int64
+	balanceMu         sync.Mutex
+
+	blockReports        *BlockReports
+	blockReportInterval time.Duration
+	reconcileMu         sync.Mutex
 
-	electLeaderTicker     *time.Ticker
-	electLeaderTickerDone chan bool
+	gcInterval               time.Duration
+	orphanGracePeriod        time.Duration
+	collectGarbageTicker     *time.Ticker
+	collectGarbageTickerDone chan bool
 
-	syncMetadataTicker     *time.Ticker
-	syncMetadataTickerDone chan bool
+	snapshotInterval           time.Duration
+	snapshotMetadataTicker     *time.Ticker
+	snapshotMetadataTickerDone chan bool
 
 	pb.UnimplementedLeaderServerServer
 }
 
 // NewLeader creates a new Leader.
-func NewLeaderServer(port, dataServerPort string, blockSize int64, replicationFactor int) *LeaderServer {
+func NewLeaderServer(config *config.Config) *LeaderServer {
 	hostname, err := os.Hostname()
 	if err != nil {
 		logrus.Fatalf("failed to get hostname: %v\n", err)
 		return nil
 	}
-	return &LeaderServer{
-		port:              port,
-		dataServerPort:    dataServerPort,
-		leader:            "", // find the right leader
+	metadata, err := metadata.NewPersistentMetadata(config.Metadata.Dir)
+	if err != nil {
+		logrus.Fatalf("failed to load metadata: %v\n", err)
+		return nil
+	}
+	raft, err := NewRaft(config, hostname, metadata)
+	if err != nil {
+		logrus.Fatalf("failed to load raft: %v\n", err)
+		return nil
+	}
+	placementPolicy, err := NewPlacementPolicy(config.Placement)
+	if err != nil {
+		logrus.Fatalf("failed to create placement policy: %v\n", err)
+		return nil
+	}
+	zones := map[string]string{}
+	for _, machine := range config.Machines {
+		zones[machine.Hostname] = machine.Zone
+	}
+	l := &LeaderServer{
+		port:              config.LeaderServerPort,
+		dataServerPort:    config.DataServerPort,
 		hostname:          hostname,
-		metadata:          metadata.NewMetadata(),
-		fileLock:          NewFileLock(),
-		blockSize:         blockSize,
-		replicationFactor: replicationFactor,
+		raft:              raft,
+		metadata:          metadata,
+		fileLock:          NewFileLock(metadata, config.Lock.LeaseDuration),
+		records:           NewRecords(),
+		blockSize:         config.BlockSize,
+		maxVersions:       config.MaxVersions,
+		replicationFactor: config.RelicationFactor,
+		placementPolicy:   placementPolicy,
+		zones:             zones,
+		balancerBandwidth: config.Balancer.Bandwidth,
+		snapshotInterval:  config.Metadata.SnapshotInterval,
+
+		blockReports:        NewBlockReports(),
+		blockReportInterval: config.BlockReport.Interval,
+
+		gcInterval:        config.GC.Interval,
+		orphanGracePeriod: config.GC.OrphanGracePeriod,
 	}
+	raft.onBecomeLeader = l.startRebuildingMetadata
+	return l
 }
 
 // Run starts the Leader.
@@ -63,10 +115,22 @@ func (l *LeaderServer) Run() {
 		return
 	}
 	defer listen.Close()
-	go l.startElectingLeader()
+	go l.raft.start()
 	go l.startRecoveringReplica()
-	go l.startSyncingMetadata()
-	grpcServer := grpc.NewServer()
+	go l.startSnapshottingMetadata()
+	go l.startCollectingGarbage()
+	go l.startExpiringLocks()
+	// only the nodes may call the methods of the cluster itself
+	grpcServer, err := auth.NewServer(
+		auth.Method(pb.LeaderServer_ServiceDesc, "BlockReport"),
+		auth.Method(pb.LeaderServer_ServiceDesc, "RequestVote"),
+		auth.Method(pb.LeaderServer_ServiceDesc, "AppendEntries"),
+		auth.Method(pb.LeaderServer_ServiceDesc, "InstallSnapshot"),
+	)
+	if err != nil {
+		logrus.Fatalf("failed to create gRPC server: %v", err)
+		return
+	}
 	pb.RegisterLeaderServerServer(grpcServer, l)
 	logrus.Infof("LeaderServer listening on port %s", l.port)
 	if err := grpcServer.Serve(listen); err != nil {
@@ -83,7 +147,7 @@ func (l *LeaderServer) GetLeader(ctx context.Context, in *pb.GetLeaderRequest) (
 
 // getLeader returns the leader.
 func (l *LeaderServer) getLeader() string {
-	return l.leader
+	return l.raft.getLeader()
 }
 
 // GetMetadata returns the metadata to the client through gRPC.
@@ -101,12 +165,7 @@ func (l *LeaderServer) GetMetadata(ctx context.Context, in *pb.GetMetadataReques
 			},
 		}
 		for blockID, blockMeta := range fileInfo.BlockInfo {
-			getMetadaReply.Metadata.FileInfo[fileName].BlockInfo.BlockInfo[blockID] = &pb.BlockMeta{
-				HostNames: blockMeta.HostNames,
-				FileName:  blockMeta.FileName,
-				BlockID:   blockMeta.BlockID,
-				BlockSize: blockMeta.BlockSize,
-			}
+			getMetadaReply.Metadata.FileInfo[fileName].BlockInfo.BlockInfo[blockID] = toProtoBlockMeta(blockMeta)
 		}
 	}
 	return getMetadaReply, nil
@@ -116,15 +175,3 @@ func (l *LeaderServer) GetMetadata(ctx context.Context, in *pb.GetMetadataReques
 func (l *LeaderServer) getMetadata() *metadata.Metadata {
 	return l.metadata
 }
-
-func (l *LeaderServer) SetLeader(ctx context.Context, in *pb.SetLeaderRequest) (*pb.SetLeaderReply, error) {
-	l.setLeader(in.GetLeader())
-	return &pb.SetLeaderReply{Ok: true}, nil
-}
-
-func (l *LeaderServer) setLeader(leader string) {
-	if leader != l.leader {
-		logrus.Infof("leader changed from %s to %s", l.leader, leader)
-	}
-	l.leader = leader
-}
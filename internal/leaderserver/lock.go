@@ -4,61 +4,298 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
-	"golang.org/x/sync/semaphore"
 )
 
-// FileLock is a lock for file
+// defaultLeaseDuration is used if the lease duration is not set in config.
+const defaultLeaseDuration = time.Second * 30
+
+// FileLock grants leases on files to clients, the leases are replicated in metadata.
+// Waiting clients are served in arrival order. The lock only guards the waiting and granting requests of each file and
+// is never held while a lease is committed, so the leases of different files are granted at the same time.
 type FileLock struct {
-	fileSempahore map[string]*semaphore.Weighted
+	metadata      *metadata.Metadata
+	leaseDuration time.Duration
+	files         map[string]*fileLockState
 	mu            sync.Mutex
 }
 
+// fileLockState is the requests on a file which are waiting or being granted.
+type fileLockState struct {
+	queue    []*lockRequest               // waiting requests in arrival order
+	granting map[string]metadata.LockMode // leases being committed by client
+	changed  chan struct{}                // closed when a lease is released or a request leaves the queue
+}
+
+type lockRequest struct {
+	clientID string
+	mode     metadata.LockMode
+}
+
 // NewFileLock returns a new FileLock
-func NewFileLock() *FileLock {
+func NewFileLock(metadata *metadata.Metadata, leaseDuration time.Duration) *FileLock {
+	if leaseDuration <= 0 {
+		leaseDuration = defaultLeaseDuration
+	}
 	return &FileLock{
-		fileSempahore: map[string]*semaphore.Weighted{},
+		metadata:      metadata,
+		leaseDuration: leaseDuration,
+		files:         map[string]*fileLockState{},
 		mu:            sync.Mutex{},
 	}
 }
 
 // AcquireReadLock acquires a read lock for a file through gRPC.
 func (l *LeaderServer) AcquireReadLock(ctx context.Context, in *pb.AcquireLockRequest) (*pb.AcquireLockReply, error) {
-	return &pb.AcquireLockReply{}, l.fileLock.acquireLock(in.GetFileName(), 1)
+	if err := l.fileLock.acquireLock(ctx, metadata.CleanPath(in.GetFileName()), in.GetClientID(), metadata.ReadLock); err != nil {
+		return nil, err
+	}
+	return l.acquireLockReply(in.GetFileName()), nil
+}
+
+// acquireLockReply returns the lease duration with the version of the file the lock is granted at, with which the
+// client checks its cached blocks of the file.
+func (l *LeaderServer) acquireLockReply(fileName string) *pb.AcquireLockReply {
+	epoch := l.raft.getEpoch()
+	return &pb.AcquireLockReply{
+		LeaseDuration: l.fileLock.leaseDuration.Milliseconds(),
+		Epoch:         epoch,
+		ModIndex:      l.metadata.ModIndexOf(fileName),
+	}
 }
 
 // ReleaseReadLock releases a read lock for a file through gRPC.
 func (l *LeaderServer) ReleaseReadLock(ctx context.Context, in *pb.ReleaseLockRequest) (*pb.ReleaseLockReply, error) {
-	return &pb.ReleaseLockReply{}, l.fileLock.releaseLock(in.GetFileName(), 1)
+	return &pb.ReleaseLockReply{}, l.fileLock.releaseLock(metadata.CleanPath(in.GetFileName()), in.GetClientID())
 }
 
 // AcquireWriteLock acquires a write lock for a file through gRPC.
 func (l *LeaderServer) AcquireWriteLock(ctx context.Context, in *pb.AcquireLockRequest) (*pb.AcquireLockReply, error) {
-	return &pb.AcquireLockReply{}, l.fileLock.acquireLock(in.GetFileName(), 2)
+	if err := l.fileLock.acquireLock(ctx, metadata.CleanPath(in.GetFileName()), in.GetClientID(), metadata.WriteLock); err != nil {
+		return nil, err
+	}
+	return l.acquireLockReply(in.GetFileName()), nil
 }
 
 // ReleaseWriteLock releases a write lock for a file through gRPC.
 func (l *LeaderServer) ReleaseWriteLock(ctx context.Context, in *pb.ReleaseLockRequest) (*pb.ReleaseLockReply, error) {
-	return &pb.ReleaseLockReply{}, l.fileLock.releaseLock(in.GetFileName(), 2)
+	return &pb.ReleaseLockReply{}, l.fileLock.releaseLock(metadata.CleanPath(in.GetFileName()), in.GetClientID())
 }
 
-// acquireLock acquires a lock for a file with weight.
-func (fl *FileLock) acquireLock(fileName string, weight int64) error {
-	if _, ok := fl.fileSempahore[fileName]; !ok {
+// RenewLock extends the lease of a client on a file through gRPC.
+func (l *LeaderServer) RenewLock(ctx context.Context, in *pb.RenewLockRequest) (*pb.RenewLockReply, error) {
+	return &pb.RenewLockReply{}, l.fileLock.renewLock(metadata.CleanPath(in.GetFileName()), in.GetClientID())
+}
+
+// ListLocks returns the leases on all files through gRPC.
+func (l *LeaderServer) ListLocks(ctx context.Context, in *pb.ListLocksRequest) (*pb.ListLocksReply, error) {
+	locks := []*pb.Lock{}
+	for _, lease := range l.metadata.GetAllLeases() {
+		locks = append(locks, &pb.Lock{
+			FileName:  lease.FileName,
+			ClientID:  lease.ClientID,
+			Mode:      string(lease.Mode),
+			ExpiresAt: lease.ExpiresAt.UnixMilli(),
+			Caller:    lease.Caller,
+		})
+	}
+	return &pb.ListLocksReply{Locks: locks}, nil
+}
+
+// acquireLock waits until the lease can be granted to the client.
+func (fl *FileLock) acquireLock(ctx context.Context, fileName, clientID string, mode metadata.LockMode) error {
+	if clientID == "" {
+		return fmt.Errorf("client id is required to lock file %s", fileName)
+	}
+	request := &lockRequest{clientID: clientID, mode: mode}
+	fl.mu.Lock()
+	state := fl.file(fileName)
+	state.queue = append(state.queue, request)
+	fl.mu.Unlock()
+	defer fl.dequeue(fileName, state, request)
+	for {
 		fl.mu.Lock()
-		fl.fileSempahore[fileName] = semaphore.NewWeighted(2)
+		if fl.grantable(fileName, state, request) {
+			state.granting[clientID] = mode
+			fl.mu.Unlock()
+			err := fl.metadata.PutLease(metadata.Lease{
+				FileName:  fileName,
+				ClientID:  clientID,
+				Mode:      mode,
+				ExpiresAt: time.Now().Add(fl.leaseDuration),
+				Caller:    auth.Caller(ctx),
+			})
+			fl.mu.Lock()
+			delete(state.granting, clientID)
+			state.notify()
+			fl.mu.Unlock()
+			if err != nil {
+				return fmt.Errorf("failed to grant %s lock of file %s: %v", mode, fileName, err)
+			}
+			logrus.Infof("Granted %s lock of file %s to %s", mode, fileName, clientID)
+			return nil
+		}
+		changed := state.changed
 		fl.mu.Unlock()
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			// the leases may expire
+		case <-ctx.Done():
+			return fmt.Errorf("failed to acquire %s lock of file %s: %v", mode, fileName, ctx.Err())
+		}
 	}
-	fl.fileSempahore[fileName].Acquire(context.Background(), weight)
+}
+
+// file returns the state of a file, the caller must hold the lock.
+func (fl *FileLock) file(fileName string) *fileLockState {
+	state, ok := fl.files[fileName]
+	if !ok {
+		state = &fileLockState{granting: map[string]metadata.LockMode{}, changed: make(chan struct{})}
+		fl.files[fileName] = state
+	}
+	return state
+}
+
+// grantable returns whether the request is not behind a conflicting request and does not conflict with the leases,
+// including the ones being granted. The caller must hold the lock.
+func (fl *FileLock) grantable(fileName string, state *fileLockState, request *lockRequest) bool {
+	for _, r := range state.queue {
+		if r == request {
+			break
+		}
+		if r.mode == metadata.WriteLock || request.mode == metadata.WriteLock {
+			return false
+		}
+	}
+	for clientID, mode := range state.granting {
+		if clientID != request.clientID && (mode == metadata.WriteLock || request.mode == metadata.WriteLock) {
+			return false
+		}
+	}
+	for _, lease := range fl.metadata.GetLeases(fileName) {
+		// the lease is replaced if the client locks the file again
+		if lease.ClientID == request.clientID || time.Now().After(lease.ExpiresAt) {
+			continue
+		}
+		if lease.Mode == metadata.WriteLock || request.mode == metadata.WriteLock {
+			return false
+		}
+	}
+	return true
+}
+
+func (fl *FileLock) dequeue(fileName string, state *fileLockState, request *lockRequest) {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+	queue := []*lockRequest{}
+	for _, r := range state.queue {
+		if r != request {
+			queue = append(queue, r)
+		}
+	}
+	state.queue = queue
+	state.notify()
+	if len(state.queue) == 0 && len(state.granting) == 0 {
+		delete(fl.files, fileName)
+	}
+}
+
+// notifyFile wakes up the requests waiting for a file.
+func (fl *FileLock) notifyFile(fileName string) {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+	if state, ok := fl.files[fileName]; ok {
+		state.notify()
+	}
+}
+
+// releaseLock releases the lease of the client on a file.
+func (fl *FileLock) releaseLock(fileName, clientID string) error {
+	if _, ok := fl.getLease(fileName, clientID); !ok {
+		return fmt.Errorf("file %s lock of %s not found", fileName, clientID)
+	}
+	if err := fl.metadata.DelLease(fileName, clientID); err != nil {
+		return fmt.Errorf("failed to release lock of file %s: %v", fileName, err)
+	}
+	logrus.Infof("Released lock of file %s from %s", fileName, clientID)
+	fl.notifyFile(fileName)
 	return nil
 }
 
-// releaseLock releases a lock for a file with weight.
-func (fl *FileLock) releaseLock(fileName string, weight int64) error {
-	if _, ok := fl.fileSempahore[fileName]; !ok {
-		return fmt.Errorf("file %s lock not found", fileName)
+// renewLock extends the lease of the client on a file, an expired lease cannot be renewed.
+func (fl *FileLock) renewLock(fileName, clientID string) error {
+	lease, ok := fl.getLease(fileName, clientID)
+	if !ok || time.Now().After(lease.ExpiresAt) {
+		return fmt.Errorf("file %s lock of %s is expired", fileName, clientID)
+	}
+	lease.ExpiresAt = time.Now().Add(fl.leaseDuration)
+	if err := fl.metadata.RenewLease(lease); err != nil {
+		return fmt.Errorf("failed to renew lock of file %s: %v", fileName, err)
 	}
-	fl.fileSempahore[fileName].Release(weight)
 	return nil
 }
+
+// expireLocks reclaims the expired leases in one entry.
+func (fl *FileLock) expireLocks() {
+	expired := []metadata.Lease{}
+	for _, lease := range fl.metadata.GetAllLeases() {
+		if time.Now().After(lease.ExpiresAt) {
+			expired = append(expired, lease)
+		}
+	}
+	if len(expired) == 0 {
+		return
+	}
+	if err := fl.metadata.ExpireLeases(expired); err != nil {
+		logrus.Errorf("failed to reclaim %d expired locks: %v", len(expired), err)
+		return
+	}
+	for _, lease := range expired {
+		logrus.Warnf("Reclaimed expired %s lock of file %s from %s", lease.Mode, lease.FileName, lease.ClientID)
+		fl.notifyFile(lease.FileName)
+	}
+}
+
+// getLease returns the lease of the client on a file.
+func (fl *FileLock) getLease(fileName, clientID string) (metadata.Lease, bool) {
+	for _, lease := range fl.metadata.GetLeases(fileName) {
+		if lease.ClientID == clientID {
+			return lease, true
+		}
+	}
+	return metadata.Lease{}, false
+}
+
+// notify wakes up the waiting requests, the caller must hold the lock of FileLock.
+func (s *fileLockState) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (l *LeaderServer) startExpiringLocks() {
+	logrus.Info("Start expiring locks")
+	l.expireLocksTicker = time.NewTicker(time.Second)
+	defer l.expireLocksTicker.Stop()
+	for {
+		select {
+		case <-l.expireLocksTickerDone:
+			return
+		case <-l.expireLocksTicker.C:
+			// only leader can reclaim the leases
+			if l.getLeader() != l.hostname {
+				continue
+			}
+			l.fileLock.expireLocks()
+		}
+	}
+}
+
+func (l *LeaderServer) stopExpiringLocks() {
+	l.expireLocksTickerDone <- true
+}
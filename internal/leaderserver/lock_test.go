@@ -0,0 +1,80 @@
+package leaderserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+)
+
+func TestFileLockReadersDoNotBlock(t *testing.T) {
+	fl := NewFileLock(metadata.NewMetadata(), time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for _, clientID := range []string{"a", "b", "c", "d"} {
+		if err := fl.acquireLock(ctx, "/file", clientID, metadata.ReadLock); err != nil {
+			t.Fatalf("read lock of %s: %v", clientID, err)
+		}
+	}
+	if got := len(fl.metadata.GetLeases("/file")); got != 4 {
+		t.Errorf("got %d leases, want 4", got)
+	}
+}
+
+func TestFileLockWriterWaitsForRelease(t *testing.T) {
+	fl := NewFileLock(metadata.NewMetadata(), time.Minute)
+	ctx := context.Background()
+	if err := fl.acquireLock(ctx, "/file", "reader", metadata.ReadLock); err != nil {
+		t.Fatalf("read lock: %v", err)
+	}
+	granted := make(chan error, 1)
+	go func() {
+		granted <- fl.acquireLock(ctx, "/file", "writer", metadata.WriteLock)
+	}()
+	select {
+	case err := <-granted:
+		t.Fatalf("write lock granted while the file is read: %v", err)
+	case <-time.After(time.Millisecond * 100):
+	}
+	// another file is not blocked by the waiting writer
+	if err := fl.acquireLock(ctx, "/other", "writer", metadata.WriteLock); err != nil {
+		t.Fatalf("write lock of another file: %v", err)
+	}
+	if err := fl.releaseLock("/file", "reader"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	select {
+	case err := <-granted:
+		if err != nil {
+			t.Fatalf("write lock: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("write lock is not granted after the read lock is released")
+	}
+}
+
+func TestFileLockExpireKeepsRenewedLeases(t *testing.T) {
+	m := metadata.NewMetadata()
+	fl := NewFileLock(m, time.Minute)
+	expired := time.Now().Add(-time.Second)
+	for _, clientID := range []string{"a", "b"} {
+		if err := m.PutLease(metadata.Lease{FileName: "/file", ClientID: clientID, Mode: metadata.ReadLock, ExpiresAt: expired}); err != nil {
+			t.Fatalf("PutLease: %v", err)
+		}
+	}
+	leases := m.GetLeases("/file")
+	// b locks the file again before the expired leases are reclaimed
+	if err := m.PutLease(metadata.Lease{FileName: "/file", ClientID: "b", Mode: metadata.ReadLock, ExpiresAt: time.Now().Add(time.Minute)}); err != nil {
+		t.Fatalf("PutLease: %v", err)
+	}
+	if err := m.ExpireLeases(leases); err != nil {
+		t.Fatalf("ExpireLeases: %v", err)
+	}
+	if _, ok := fl.getLease("/file", "a"); ok {
+		t.Errorf("expired lease of a is kept")
+	}
+	if _, ok := fl.getLease("/file", "b"); !ok {
+		t.Errorf("lease of b granted again is reclaimed")
+	}
+}
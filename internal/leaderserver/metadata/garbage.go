@@ -0,0 +1,106 @@
+package metadata
+
+import "fmt"
+
+// GarbageBlock is a replica of a deleted or overwritten block to be removed from a data server.
+type GarbageBlock struct {
+	HostName   string
+	FileName   string
+	BlockID    int64
+	Generation int64
+}
+
+func (g GarbageBlock) key() string {
+	if g.Generation == 0 {
+		return fmt.Sprintf("%s/%s_%d", g.HostName, g.FileName, g.BlockID)
+	}
+	return fmt.Sprintf("%s/%s_%d_g%d", g.HostName, g.FileName, g.BlockID, g.Generation)
+}
+
+// GetGarbage returns the replicas waiting to be removed.
+func (m *Metadata) GetGarbage() []GarbageBlock {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	garbage := make([]GarbageBlock, 0, len(m.garbage))
+	for _, block := range m.garbage {
+		garbage = append(garbage, block)
+	}
+	return garbage
+}
+
+// IsGarbage returns whether a replica is waiting to be removed.
+func (m *Metadata) IsGarbage(block GarbageBlock) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.garbage[block.key()]
+	return ok
+}
+
+// AddGarbage records replicas to be removed from the data servers.
+func (m *Metadata) AddGarbage(blocks []GarbageBlock) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+	return m.commit(Entry{
+		Op:      OpAddGarbage,
+		Garbage: blocks,
+	})
+}
+
+// addGarbage records replicas to be removed, the caller must hold the lock.
+func (m *Metadata) addGarbage(blocks []GarbageBlock) {
+	for _, block := range blocks {
+		m.garbage[block.key()] = block
+	}
+}
+
+// DelGarbage drops replicas which are removed from the data servers or should be kept.
+func (m *Metadata) DelGarbage(blocks []GarbageBlock) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+	return m.commit(Entry{
+		Op:      OpDelGarbage,
+		Garbage: blocks,
+	})
+}
+
+// GarbageOfFile returns the replicas of the current and previous versions of a file.
+func GarbageOfFile(fileInfo FileInfo) []GarbageBlock {
+	blocks := []GarbageBlock{}
+	for _, version := range fileInfo.AllVersions() {
+		blocks = append(blocks, GarbageOfVersion(version)...)
+	}
+	return blocks
+}
+
+// GarbageOfVersion returns the replicas of the data and parity blocks of a version of a file.
+func GarbageOfVersion(version FileVersion) []GarbageBlock {
+	return append(GarbageOf(version.BlockInfo, nil), GarbageOf(version.Parity, nil)...)
+}
+
+// GarbageOf returns the replicas in oldBlockInfo which are not in newBlockInfo, a replica of another generation
+// of a block is not the same replica.
+func GarbageOf(oldBlockInfo, newBlockInfo BlockInfo) []GarbageBlock {
+	blocks := []GarbageBlock{}
+	for blockID, oldBlockMeta := range oldBlockInfo {
+		hostNames := map[string]struct{}{}
+		if newBlockMeta, ok := newBlockInfo[blockID]; ok && newBlockMeta.FileName == oldBlockMeta.FileName && newBlockMeta.Generation == oldBlockMeta.Generation {
+			for _, hostName := range newBlockMeta.HostNames {
+				hostNames[hostName] = struct{}{}
+			}
+		}
+		for _, hostName := range oldBlockMeta.HostNames {
+			if _, ok := hostNames[hostName]; ok {
+				continue
+			}
+			blocks = append(blocks, GarbageBlock{
+				HostName:   hostName,
+				FileName:   oldBlockMeta.FileName,
+				BlockID:    blockID,
+				Generation: oldBlockMeta.Generation,
+			})
+		}
+	}
+	return blocks
+}
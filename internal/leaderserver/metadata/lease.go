@@ -0,0 +1,103 @@
+package metadata
+
+import (
+	"sort"
+	"time"
+)
+
+// LockMode is the mode of a lease on a file.
+type LockMode string
+
+const (
+	ReadLock  LockMode = "read"
+	WriteLock LockMode = "write"
+)
+
+// Lease is a lock on a file held by a client until it expires.
+type Lease struct {
+	FileName  string
+	ClientID  string
+	Mode      LockMode
+	ExpiresAt time.Time
+	Caller    string `json:",omitempty"` // identity of the user or node the lease is granted to
+}
+
+// GetLeases returns the leases on a file.
+func (m *Metadata) GetLeases(fileName string) []Lease {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	leases := []Lease{}
+	for _, lease := range m.leases[fileName] {
+		leases = append(leases, lease)
+	}
+	return leases
+}
+
+// GetAllLeases returns the leases on all files, sorted by file name.
+func (m *Metadata) GetAllLeases() []Lease {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	leases := []Lease{}
+	for _, fileLeases := range m.leases {
+		for _, lease := range fileLeases {
+			leases = append(leases, lease)
+		}
+	}
+	sort.Slice(leases, func(i, j int) bool {
+		if leases[i].FileName != leases[j].FileName {
+			return leases[i].FileName < leases[j].FileName
+		}
+		return leases[i].ClientID < leases[j].ClientID
+	})
+	return leases
+}
+
+// PutLease grants a lease.
+func (m *Metadata) PutLease(lease Lease) error {
+	return m.commit(Entry{
+		Op:       OpPutLease,
+		FileName: lease.FileName,
+		Lease:    &lease,
+	})
+}
+
+// RenewLease extends a lease, it is not granted again if released in the meantime.
+func (m *Metadata) RenewLease(lease Lease) error {
+	return m.commit(Entry{
+		Op:       OpRenewLease,
+		FileName: lease.FileName,
+		Lease:    &lease,
+	})
+}
+
+// DelLease releases the lease of a client on a file.
+func (m *Metadata) DelLease(fileName, clientID string) error {
+	return m.commit(Entry{
+		Op:       OpDelLease,
+		FileName: fileName,
+		Lease: &Lease{
+			FileName: fileName,
+			ClientID: clientID,
+		},
+	})
+}
+
+// ExpireLeases reclaims expired leases in one entry, a lease which is renewed or granted again before the entry is
+// applied is kept.
+func (m *Metadata) ExpireLeases(leases []Lease) error {
+	if len(leases) == 0 {
+		return nil
+	}
+	return m.commit(Entry{
+		Op:     OpDelLease,
+		Leases: leases,
+	})
+}
+
+// delLease removes the lease of a client on a file, the caller must hold the lock.
+func (m *Metadata) delLease(fileName, clientID string) {
+	delete(m.leases[fileName], clientID)
+	if len(m.leases[fileName]) == 0 {
+		delete(m.leases, fileName)
+	}
+}
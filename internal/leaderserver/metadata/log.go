@@ -0,0 +1,249 @@
+package metadata
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Log is the replicated log of metadata mutations after the latest snapshot, persisted in the write-ahead log.
+type Log struct {
+	wal           *WAL
+	entries       []Entry // entries[i].Index == snapshotIndex+i+1
+	snapshotIndex uint64
+	snapshotTerm  uint64
+	mu            sync.Mutex
+}
+
+// OpenLog opens the log in dir, entries already compacted into the snapshot at snapshotIndex are dropped. A gap in
+// the entries after the snapshot is an error, the server must not vote or acknowledge without the entries it had.
+func OpenLog(dir string, snapshotIndex, snapshotTerm uint64) (*Log, error) {
+	wal, entries, err := OpenWAL(filepath.Join(dir, "wal.log"))
+	if err != nil {
+		return nil, err
+	}
+	kept := []Entry{}
+	for _, entry := range entries {
+		if entry.Index <= snapshotIndex {
+			continue
+		}
+		if entry.Index != snapshotIndex+uint64(len(kept))+1 {
+			wal.Close()
+			return nil, fmt.Errorf("wal entry %d is not contiguous to the log, expected index %d", entry.Index, snapshotIndex+uint64(len(kept))+1)
+		}
+		kept = append(kept, entry)
+	}
+	if len(kept) != len(entries) {
+		if err := wal.Rewrite(kept); err != nil {
+			wal.Close()
+			return nil, err
+		}
+	}
+	logrus.Infof("Loaded metadata log with %d entries after snapshot index %d", len(kept), snapshotIndex)
+	return &Log{
+		wal:           wal,
+		entries:       kept,
+		snapshotIndex: snapshotIndex,
+		snapshotTerm:  snapshotTerm,
+		mu:            sync.Mutex{},
+	}, nil
+}
+
+// SnapshotIndex returns the index of the last entry compacted into the snapshot.
+func (l *Log) SnapshotIndex() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.snapshotIndex
+}
+
+// LastIndex returns the index of the last entry.
+func (l *Log) LastIndex() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.snapshotIndex + uint64(len(l.entries))
+}
+
+// LastTerm returns the term of the last entry.
+func (l *Log) LastTerm() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) == 0 {
+		return l.snapshotTerm
+	}
+	return l.entries[len(l.entries)-1].Term
+}
+
+// Term returns the term of the entry at index.
+func (l *Log) Term(index uint64) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index == l.snapshotIndex {
+		return l.snapshotTerm, nil
+	}
+	entry, err := l.entry(index)
+	if err != nil {
+		return 0, err
+	}
+	return entry.Term, nil
+}
+
+// Entry returns the entry at index.
+func (l *Log) Entry(index uint64) (Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.entry(index)
+}
+
+func (l *Log) entry(index uint64) (Entry, error) {
+	if index <= l.snapshotIndex {
+		return Entry{}, fmt.Errorf("entry %d is compacted into the snapshot", index)
+	}
+	if index > l.snapshotIndex+uint64(len(l.entries)) {
+		return Entry{}, fmt.Errorf("entry %d is not in the log", index)
+	}
+	return l.entries[index-l.snapshotIndex-1], nil
+}
+
+// Entries returns at most max entries from index.
+func (l *Log) Entries(index uint64, max int) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index <= l.snapshotIndex {
+		return nil, fmt.Errorf("entry %d is compacted into the snapshot", index)
+	}
+	start := index - l.snapshotIndex - 1
+	if start > uint64(len(l.entries)) {
+		return nil, fmt.Errorf("entry %d is not in the log", index)
+	}
+	end := start + uint64(max)
+	if end > uint64(len(l.entries)) {
+		end = uint64(len(l.entries))
+	}
+	return append([]Entry{}, l.entries[start:end]...), nil
+}
+
+// Append appends entries to the end of the log.
+func (l *Log) Append(entries ...Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.append(entries)
+}
+
+func (l *Log) append(entries []Entry) error {
+	for i, entry := range entries {
+		if entry.Index != l.snapshotIndex+uint64(len(l.entries)+i)+1 {
+			return fmt.Errorf("entry %d is not contiguous to the log", entry.Index)
+		}
+	}
+	if err := l.wal.Append(entries...); err != nil {
+		return err
+	}
+	l.entries = append(l.entries, entries...)
+	return nil
+}
+
+// Merge appends the entries from the leader which follow a matching entry of the log. The entries already in the log
+// are skipped, and the log is dropped from the first conflicting entry.
+func (l *Log) Merge(entries []Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for len(entries) > 0 && entries[0].Index <= l.snapshotIndex+uint64(len(l.entries)) {
+		if entries[0].Index <= l.snapshotIndex {
+			// compacted entries are committed and match
+			entries = entries[1:]
+			continue
+		}
+		entry, err := l.entry(entries[0].Index)
+		if err != nil {
+			return err
+		}
+		if entry.Term != entries[0].Term {
+			if err := l.truncateFrom(entries[0].Index); err != nil {
+				return err
+			}
+			break
+		}
+		entries = entries[1:]
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return l.append(entries)
+}
+
+// CommitIndex returns the last entry of term which is in this log and replicated on the majority with the peers, given
+// the last entry replicated on each peer, or commitIndex if no later entry is. The entries of previous terms are
+// committed with it.
+func (l *Log) CommitIndex(commitIndex, term uint64, matchIndex []uint64) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	majority := (len(matchIndex)+1)/2 + 1
+	for index := l.snapshotIndex + uint64(len(l.entries)); index > commitIndex && index > l.snapshotIndex; index-- {
+		entry, err := l.entry(index)
+		if err != nil || entry.Term != term {
+			break
+		}
+		count := 1
+		for _, match := range matchIndex {
+			if match >= index {
+				count++
+			}
+		}
+		if count >= majority {
+			return index
+		}
+	}
+	return commitIndex
+}
+
+// truncateFrom drops the entries from index, the caller must hold the lock.
+func (l *Log) truncateFrom(index uint64) error {
+	if index <= l.snapshotIndex {
+		return fmt.Errorf("entry %d is compacted into the snapshot", index)
+	}
+	i := int(index - l.snapshotIndex - 1)
+	if i >= len(l.entries) {
+		return nil
+	}
+	if err := l.wal.TruncateFrom(i); err != nil {
+		return err
+	}
+	l.entries = l.entries[:i]
+	return nil
+}
+
+// Compact drops the entries up to index, which are in the snapshot.
+func (l *Log) Compact(index uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index <= l.snapshotIndex {
+		return nil
+	}
+	entry, err := l.entry(index)
+	if err != nil {
+		return err
+	}
+	entries := append([]Entry{}, l.entries[index-l.snapshotIndex:]...)
+	if err := l.wal.Rewrite(entries); err != nil {
+		return err
+	}
+	l.entries = entries
+	l.snapshotIndex = index
+	l.snapshotTerm = entry.Term
+	return nil
+}
+
+// Reset drops all entries, called when a snapshot at index is installed from the leader.
+func (l *Log) Reset(index, term uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.wal.Rewrite([]Entry{}); err != nil {
+		return err
+	}
+	l.entries = []Entry{}
+	l.snapshotIndex = index
+	l.snapshotTerm = term
+	return nil
+}
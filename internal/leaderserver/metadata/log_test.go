@@ -0,0 +1,173 @@
+package metadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testEntries returns entries from index first with the terms.
+func testEntries(first uint64, terms ...uint64) []Entry {
+	entries := []Entry{}
+	for i, term := range terms {
+		entries = append(entries, Entry{Index: first + uint64(i), Term: term, Op: OpNoop})
+	}
+	return entries
+}
+
+func openTestLog(t *testing.T, dir string, terms ...uint64) *Log {
+	t.Helper()
+	log, err := OpenLog(dir, 0, 0)
+	if err != nil {
+		t.Fatalf("OpenLog: %v", err)
+	}
+	if len(terms) > 0 {
+		if err := log.Append(testEntries(1, terms...)...); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	return log
+}
+
+func logTerms(t *testing.T, log *Log) []uint64 {
+	t.Helper()
+	terms := []uint64{}
+	for index := log.SnapshotIndex() + 1; index <= log.LastIndex(); index++ {
+		term, err := log.Term(index)
+		if err != nil {
+			t.Fatalf("Term(%d): %v", index, err)
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+func equalTerms(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLogMerge(t *testing.T) {
+	tests := []struct {
+		name    string
+		log     []uint64
+		first   uint64
+		entries []uint64
+		want    []uint64
+	}{
+		{"append after the last entry", []uint64{1, 1}, 3, []uint64{2, 2}, []uint64{1, 1, 2, 2}},
+		{"entries already in the log", []uint64{1, 1, 2}, 2, []uint64{1, 2}, []uint64{1, 1, 2}},
+		{"stale entries keep the longer log", []uint64{1, 1, 2, 2}, 2, []uint64{1}, []uint64{1, 1, 2, 2}},
+		{"conflict drops the rest of the log", []uint64{1, 1, 2, 2}, 3, []uint64{3}, []uint64{1, 1, 3}},
+		{"conflict after matching entries", []uint64{1, 2, 2, 2}, 2, []uint64{2, 3, 3, 3}, []uint64{1, 2, 3, 3, 3}},
+		{"empty entries", []uint64{1, 1}, 3, []uint64{}, []uint64{1, 1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			log := openTestLog(t, dir, test.log...)
+			if err := log.Merge(testEntries(test.first, test.entries...)); err != nil {
+				t.Fatalf("Merge: %v", err)
+			}
+			if got := logTerms(t, log); !equalTerms(got, test.want) {
+				t.Fatalf("got terms %v, want %v", got, test.want)
+			}
+			// the merged log is persisted
+			log.wal.Close()
+			reopened := openTestLog(t, dir)
+			defer reopened.wal.Close()
+			if got := logTerms(t, reopened); !equalTerms(got, test.want) {
+				t.Errorf("got terms %v after reopen, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLogMergeRejectsGap(t *testing.T) {
+	log := openTestLog(t, t.TempDir(), 1, 1)
+	defer log.wal.Close()
+	if err := log.Merge(testEntries(4, 1)); err == nil {
+		t.Fatalf("Merge succeeded with a gap after the last entry")
+	}
+}
+
+func TestLogCommitIndex(t *testing.T) {
+	tests := []struct {
+		name        string
+		log         []uint64
+		commitIndex uint64
+		term        uint64
+		matchIndex  []uint64
+		want        uint64
+	}{
+		{"single server", []uint64{1, 1, 1}, 0, 1, []uint64{}, 3},
+		{"replicated on the majority", []uint64{1, 1, 1}, 0, 1, []uint64{3, 0}, 3},
+		{"highest index on the majority", []uint64{1, 1, 1, 1}, 0, 1, []uint64{2, 3, 0, 0}, 2},
+		{"not on the majority", []uint64{1, 1, 1}, 1, 1, []uint64{1, 0, 3, 0}, 1},
+		{"previous term is not counted", []uint64{1, 1, 2}, 0, 3, []uint64{3, 3}, 0},
+		{"previous terms committed by the current term", []uint64{1, 1, 2}, 0, 2, []uint64{3, 0}, 3},
+		{"current term entry not replicated", []uint64{1, 1, 2}, 0, 2, []uint64{2, 2}, 0},
+		{"never moves back", []uint64{1, 1, 1}, 3, 1, []uint64{0, 0}, 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			log := openTestLog(t, t.TempDir(), test.log...)
+			defer log.wal.Close()
+			if got := log.CommitIndex(test.commitIndex, test.term, test.matchIndex); got != test.want {
+				t.Errorf("got commit index %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLogCompactAndReopen(t *testing.T) {
+	dir := t.TempDir()
+	log := openTestLog(t, dir, 1, 1, 2, 2)
+	if err := log.Compact(2); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if got := logTerms(t, log); !equalTerms(got, []uint64{2, 2}) {
+		t.Fatalf("got terms %v after compaction, want [2 2]", got)
+	}
+	if term, err := log.Term(2); err != nil || term != 1 {
+		t.Errorf("got term %d and error %v of the snapshot entry, want term 1", term, err)
+	}
+	log.wal.Close()
+
+	reopened, err := OpenLog(dir, 2, 1)
+	if err != nil {
+		t.Fatalf("OpenLog: %v", err)
+	}
+	defer reopened.wal.Close()
+	if reopened.LastIndex() != 4 || reopened.LastTerm() != 2 {
+		t.Errorf("got last index %d and term %d, want 4 and 2", reopened.LastIndex(), reopened.LastTerm())
+	}
+}
+
+func TestOpenLogRejectsGap(t *testing.T) {
+	dir := t.TempDir()
+	wal, _, err := OpenWAL(filepath.Join(dir, "wal.log"))
+	if err != nil {
+		t.Fatalf("OpenWAL: %v", err)
+	}
+	entries := append(testEntries(1, 1, 1), testEntries(4, 1)...)
+	if err := wal.Append(entries...); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	wal.Close()
+	before, _ := os.ReadFile(filepath.Join(dir, "wal.log"))
+	if _, err := OpenLog(dir, 0, 0); err == nil {
+		t.Fatalf("OpenLog succeeded with a gap in the log")
+	}
+	after, _ := os.ReadFile(filepath.Join(dir, "wal.log"))
+	if string(before) != string(after) {
+		t.Errorf("wal is modified by a failed open")
+	}
+}
@@ -1,18 +1,69 @@
 package metadata
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 // Metadata handle metadata.
 type Metadata struct {
-	FileInfo map[string]FileInfo
+	FileInfo map[string]FileInfo // map[path]FileInfo
+	Dirs     map[string]bool     // directories except the root directory
 	mu       sync.RWMutex
+
+	children   map[string]map[string]bool // names in each directory
+	blockFiles map[string]string          // map[storage name of the blocks]path of the file
+
+	garbage map[string]GarbageBlock     // replicas to be removed from the data servers
+	leases  map[string]map[string]Lease // map[fileName]map[clientID]Lease
+
+	generation   int64      // last generation assigned to a write of blocks
+	generationMu sync.Mutex // serializes the assignment of generations
+
+	snapshotPath string // empty if the metadata is not persisted
+	index        uint64 // index of the last applied entry
+	term         uint64 // term of the last applied entry
+
+	// propose replicates an entry and returns after the entry is applied, nil if the metadata is not replicated
+	propose func(entry Entry) error
 }
 
 type FileInfo struct {
-	BlockInfo BlockInfo
+	BlockInfo   BlockInfo
+	Parity      BlockInfo     `json:",omitempty"` // parity blocks of the stripes of BlockInfo if it is erasure-coded
+	Policy      string        `json:",omitempty"` // storage policy of BlockInfo, replication if empty
+	Compression string        `json:",omitempty"` // codec of the new blocks of the file, not compressed if empty
+	Version     int64         `json:",omitempty"` // version of BlockInfo, increased by every put
+	MaxVersions int           `json:",omitempty"` // number of versions kept including the current one
+	Versions    []FileVersion `json:",omitempty"` // previous versions, the newest first
+	Replication int           `json:",omitempty"` // replicas of each block, the default in config if 0
+	ModTime     int64         `json:",omitempty"` // time of the last put or append in unix milliseconds
+	ModIndex    uint64        `json:",omitempty"` // index of the last entry which changed the data of BlockInfo, 0 if unknown
+}
+
+// Blocks returns the blocks of the current and previous versions of a file, including the parity blocks.
+func (f FileInfo) Blocks() []BlockMeta {
+	blocks := []BlockMeta{}
+	for _, version := range f.AllVersions() {
+		blocks = append(blocks, version.Blocks()...)
+	}
+	return blocks
+}
+
+// Current returns the current version of a file.
+func (f FileInfo) Current() FileVersion {
+	return FileVersion{Version: f.Version, BlockInfo: f.BlockInfo, Parity: f.Parity, Policy: f.Policy, Compression: f.Compression}
+}
+
+// AllVersions returns the current and previous versions of a file, the newest first.
+func (f FileInfo) AllVersions() []FileVersion {
+	return append([]FileVersion{f.Current()}, f.Versions...)
 }
 
 // BlockInfo is the metadata of a file.
@@ -21,35 +72,134 @@ type BlockInfo map[int64]BlockMeta // map[blockID]BlockMeta}
 // BlockMeta is the metadata of a file block.
 type BlockMeta struct {
 	HostNames []string
-	FileName  string
+	FileName  string // name of the block files on the data servers, kept when the file is renamed
 	BlockID   int64
 	BlockSize int64
+	// Generation is assigned by the leader to every write of the block, the replicas of each generation are stored in
+	// different files. It is 0 for the blocks written before generations were assigned.
+	Generation int64
+	// Compression is the codec of the frames a compressed block is stored as, empty if the block is not compressed.
+	// BlockSize is the size of the data of the block, and StoredSize the size of a replica on a data server.
+	Compression string `json:",omitempty"`
+	StoredSize  int64  `json:",omitempty"`
+}
+
+// PhysicalSize returns the size of a replica of a block on a data server.
+func (b BlockMeta) PhysicalSize() int64 {
+	if b.Compression != "" {
+		return b.StoredSize
+	}
+	return b.BlockSize
 }
 
 // NewMetadata creates a new metadata.
 func NewMetadata() *Metadata {
 	return &Metadata{
-		FileInfo: make(map[string]FileInfo),
-		mu:       sync.RWMutex{},
+		FileInfo:   make(map[string]FileInfo),
+		Dirs:       make(map[string]bool),
+		mu:         sync.RWMutex{},
+		children:   map[string]map[string]bool{},
+		blockFiles: map[string]string{},
+		garbage:    make(map[string]GarbageBlock),
+		leases:     make(map[string]map[string]Lease),
 	}
 }
 
+// NewPersistentMetadata creates a metadata persisted in dir, the latest snapshot is loaded.
+// The entries after the snapshot are in the log and applied once they are committed.
+func NewPersistentMetadata(dir string) (*Metadata, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create metadata dir %s: %v", dir, err)
+	}
+	snapshotPath := filepath.Join(dir, "snapshot.json")
+	snapshot, err := readSnapshot(snapshotPath)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Loaded metadata snapshot from %s with %d files at index %d", dir, len(snapshot.FileInfo), snapshot.Index)
+	m := &Metadata{
+		FileInfo:     snapshot.FileInfo,
+		Dirs:         snapshot.Dirs,
+		mu:           sync.RWMutex{},
+		garbage:      snapshot.Garbage,
+		leases:       snapshot.Leases,
+		generation:   snapshot.Generation,
+		snapshotPath: snapshotPath,
+		index:        snapshot.Index,
+		term:         snapshot.Term,
+	}
+	m.rebuildTree()
+	return m, nil
+}
+
+// SetProposer sets the function to replicate the mutations.
+func (m *Metadata) SetProposer(propose func(entry Entry) error) {
+	m.propose = propose
+}
+
+// Applied returns the index and term of the last applied entry.
+func (m *Metadata) Applied() (uint64, uint64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.index, m.term
+}
+
 func (m *Metadata) IsFileExist(fileName string) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	_, ok := m.FileInfo[fileName]
-	return ok
+	return m.isFile(CleanPath(fileName))
 }
 
+// GetFileInfo returns a copy of the file info, safe to iterate while the metadata is updated.
 func (m *Metadata) GetFileInfo() map[string]FileInfo {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.FileInfo
+	fileInfo := make(map[string]FileInfo, len(m.FileInfo))
+	for fileName, info := range m.FileInfo {
+		fileInfo[fileName] = info.copy()
+	}
+	return fileInfo
+}
+
+// GetFile returns a copy of the info of a file with its versions.
+func (m *Metadata) GetFile(fileName string) (FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	fileName = CleanPath(fileName)
+	fileInfo, ok := m.FileInfo[fileName]
+	if !ok {
+		return FileInfo{}, fmt.Errorf("file %s not found", fileName)
+	}
+	return fileInfo.copy(), nil
+}
+
+// copy returns a copy of the file info, the blocks are not shared with the metadata.
+func (f FileInfo) copy() FileInfo {
+	info := f
+	info.BlockInfo = f.BlockInfo.copy()
+	info.Parity = f.Parity.copy()
+	info.Versions = make([]FileVersion, len(f.Versions))
+	for i, version := range f.Versions {
+		info.Versions[i] = version.copy()
+	}
+	return info
+}
+
+func (b BlockInfo) copy() BlockInfo {
+	if b == nil {
+		return nil
+	}
+	blockInfo := make(BlockInfo, len(b))
+	for blockID, blockMeta := range b {
+		blockInfo[blockID] = blockMeta
+	}
+	return blockInfo
 }
 
 func (m *Metadata) GetBlockInfo(fileName string) (BlockInfo, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	fileName = CleanPath(fileName)
 	if _, ok := m.FileInfo[fileName]; !ok {
 		return nil, fmt.Errorf("file %s not found", fileName)
 	}
@@ -57,55 +207,400 @@ func (m *Metadata) GetBlockInfo(fileName string) (BlockInfo, error) {
 }
 
 // AddOrUpdateFile adds or updates a file to metadata.
-func (m *Metadata) AddOrUpdateBlockInfo(fileName string, blockInfo BlockInfo) {
-	if !m.IsFileExist(fileName) {
-		m.mu.Lock()
-		defer m.mu.Unlock()
-		m.FileInfo[fileName] = FileInfo{
-			BlockInfo: blockInfo,
-		}
-	} else {
-		m.mu.Lock()
-		defer m.mu.Unlock()
-		fileInfo := m.FileInfo[fileName]
-		fileInfo.BlockInfo = blockInfo
-		m.FileInfo[fileName] = fileInfo
+func (m *Metadata) AddOrUpdateBlockInfo(fileName string, blockInfo BlockInfo) error {
+	return m.commit(Entry{
+		Op:        OpPutFile,
+		FileName:  CleanPath(fileName),
+		BlockInfo: blockInfo,
+	})
+}
+
+// GetBlockMeta returns a block by the name of its block files on the data servers.
+func (m *Metadata) GetBlockMeta(storageName string, blockID int64) (BlockMeta, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	blockInfo, ok := m.blocksOf(storageName)
+	if !ok {
+		return BlockMeta{}, fmt.Errorf("file of blocks %s not found", storageName)
 	}
+	blockMeta, ok := blockInfo[blockID]
+	if !ok {
+		return BlockMeta{}, fmt.Errorf("block %d of file %s not found", blockID, m.blockFiles[storageName])
+	}
+	return blockMeta, nil
 }
 
-func (m *Metadata) GetBlockMeta(fileName string, blockID int64) (BlockMeta, error) {
-	blockInfo, err := m.GetBlockInfo(fileName)
-	if err != nil {
-		return BlockMeta{}, err
+// blocksOf returns the data or parity blocks of the version of a file stored as storageName, the caller must hold the lock.
+func (m *Metadata) blocksOf(storageName string) (BlockInfo, bool) {
+	version, ok := m.versionOf(storageName)
+	if !ok {
+		return nil, false
 	}
+	for _, blockMeta := range version.Parity {
+		if blockMeta.FileName == storageName {
+			return version.Parity, true
+		}
+	}
+	return version.BlockInfo, true
+}
+
+// VersionOf returns a copy of the version of a file whose data or parity blocks are stored as storageName.
+func (m *Metadata) VersionOf(storageName string) (FileVersion, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	if _, ok := blockInfo[blockID]; !ok {
-		return BlockMeta{}, fmt.Errorf("block %d of file %s not found", blockID, fileName)
+	version, ok := m.versionOf(storageName)
+	return version.copy(), ok
+}
+
+// versionOf returns the version of a file stored as storageName, the caller must hold the lock.
+func (m *Metadata) versionOf(storageName string) (FileVersion, bool) {
+	fileName, ok := m.blockFiles[storageName]
+	if !ok {
+		return FileVersion{}, false
+	}
+	versions := m.FileInfo[fileName].AllVersions()
+	for _, version := range versions {
+		for _, blockMeta := range version.Blocks() {
+			if blockMeta.FileName == storageName {
+				return version, true
+			}
+		}
+	}
+	// a file without blocks
+	return versions[0], true
+}
+
+// AddOrUpdateBlockMeta adds or updates a block of a file.
+func (m *Metadata) AddOrUpdateBlockMeta(fileName string, blockMeta BlockMeta, caller string) error {
+	return m.commit(Entry{
+		Op:        OpPutBlock,
+		FileName:  CleanPath(fileName),
+		BlockMeta: blockMeta,
+		ModTime:   time.Now().UnixMilli(),
+		Caller:    caller,
+	})
+}
+
+// UpdateBlockMeta updates a block of the file which its block files belong to, e.g. the replicas of the block are changed.
+// Nothing is updated if the file is deleted.
+func (m *Metadata) UpdateBlockMeta(blockMeta BlockMeta) error {
+	return m.commit(Entry{
+		Op:        OpPutBlock,
+		BlockMeta: blockMeta,
+	})
+}
+
+// AppendRecord commits a record appended to the blocks of a file in place, the blocks are added or grown together so
+// that the record is never partially visible. The file is created if it does not exist. The replicas of the previous
+// generations of the blocks become garbage in the same entry.
+func (m *Metadata) AppendRecord(fileName string, blockInfo BlockInfo, garbage []GarbageBlock, caller string) error {
+	return m.commit(Entry{
+		Op:        OpAppendRecord,
+		FileName:  CleanPath(fileName),
+		BlockInfo: blockInfo,
+		Garbage:   garbage,
+		ModTime:   time.Now().UnixMilli(),
+		Caller:    caller,
+	})
+}
+
+// NewGeneration assigns a new generation to a write of blocks, the generations are increasing across leaders.
+func (m *Metadata) NewGeneration() (int64, error) {
+	m.generationMu.Lock()
+	defer m.generationMu.Unlock()
+	m.mu.RLock()
+	generation := m.generation + 1
+	m.mu.RUnlock()
+	if err := m.commit(Entry{Op: OpNewGeneration, Generation: generation}); err != nil {
+		return 0, err
 	}
-	return blockInfo[blockID], nil
+	return generation, nil
 }
 
-func (m *Metadata) AddOrUpdateBlockMeta(fileName string, blockMeta BlockMeta) error {
+// SetReplication sets the number of replicas of each block of a file.
+func (m *Metadata) SetReplication(fileName string, replication int, caller string) error {
+	fileName = CleanPath(fileName)
+	if replication <= 0 {
+		return fmt.Errorf("replication %d is not positive", replication)
+	}
 	if !m.IsFileExist(fileName) {
-		m.mu.Lock()
-		m.FileInfo[fileName] = FileInfo{
-			BlockInfo: BlockInfo{},
-		}
-		m.mu.Unlock()
+		return fmt.Errorf("file %s not found", fileName)
 	}
-	blockInfo, err := m.GetBlockInfo(fileName)
-	if err != nil {
-		return err
+	return m.commit(Entry{
+		Op:          OpSetReplication,
+		FileName:    fileName,
+		Replication: replication,
+		Caller:      caller,
+	})
+}
+
+// SetCompression sets the codec of the new blocks of a file, the blocks are not compressed if it is empty.
+func (m *Metadata) SetCompression(fileName string, compression string, caller string) error {
+	fileName = CleanPath(fileName)
+	if !m.IsFileExist(fileName) {
+		return fmt.Errorf("file %s not found", fileName)
 	}
+	return m.commit(Entry{
+		Op:          OpSetCompression,
+		FileName:    fileName,
+		Compression: compression,
+		Caller:      caller,
+	})
+}
+
+// DelFile deletes a file, the replicas of all its versions become garbage in the same entry.
+func (m *Metadata) DelFile(fileName string, caller string) error {
+	return m.commit(Entry{
+		Op:       OpDelFile,
+		FileName: CleanPath(fileName),
+		Caller:   caller,
+	})
+}
+
+// commit replicates the entry if the metadata is replicated, or applies it directly otherwise.
+func (m *Metadata) commit(entry Entry) error {
+	if m.propose != nil {
+		return m.propose(entry)
+	}
+	m.Apply(entry)
+	return nil
+}
+
+// Apply applies a committed entry.
+func (m *Metadata) Apply(entry Entry) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	blockInfo[blockMeta.BlockID] = blockMeta
-	return nil
+	m.apply(entry)
 }
 
-func (m *Metadata) DelFile(fileName string) {
+// apply applies the entry to the in-memory metadata, the caller must hold the lock.
+func (m *Metadata) apply(entry Entry) {
+	if entry.Index != 0 {
+		m.index = entry.Index
+		m.term = entry.Term
+	}
+	switch entry.Op {
+	case OpPutFile:
+		fileInfo, exists := m.FileInfo[entry.FileName]
+		if entry.MaxVersions > 0 {
+			m.putVersion(entry.FileName, &fileInfo, exists, entry.MaxVersions)
+		}
+		if entry.Replication > 0 {
+			fileInfo.Replication = entry.Replication
+		}
+		fileInfo.BlockInfo = entry.BlockInfo
+		if fileInfo.BlockInfo == nil {
+			fileInfo.BlockInfo = BlockInfo{}
+		}
+		fileInfo.Parity = entry.Parity
+		fileInfo.Policy = entry.Policy
+		fileInfo.Compression = entry.Compression
+		fileInfo.ModTime = entry.ModTime
+		fileInfo.ModIndex = entry.Index
+		m.removeFile(entry.FileName)
+		m.FileInfo[entry.FileName] = fileInfo
+		m.addFile(entry.FileName)
+	case OpPutBlock:
+		if entry.FileName == "" {
+			blockInfo, ok := m.blocksOf(entry.BlockMeta.FileName)
+			if !ok {
+				logrus.Warnf("file of block %d stored as %s is deleted", entry.BlockMeta.BlockID, entry.BlockMeta.FileName)
+				return
+			}
+			if current, ok := blockInfo[entry.BlockMeta.BlockID]; ok && current.Generation != entry.BlockMeta.Generation {
+				// the replicas are changed after the block is written again
+				logrus.Warnf("ignore the update of block %d stored as %s of generation %d, the block is at generation %d", entry.BlockMeta.BlockID, entry.BlockMeta.FileName, entry.BlockMeta.Generation, current.Generation)
+				return
+			} else if ok && current.BlockSize > entry.BlockMeta.BlockSize {
+				// the block grows by the records appended after the update is made
+				entry.BlockMeta.BlockSize = current.BlockSize
+			}
+			blockInfo[entry.BlockMeta.BlockID] = entry.BlockMeta
+			return
+		}
+		if _, ok := m.FileInfo[entry.FileName]; !ok {
+			m.FileInfo[entry.FileName] = FileInfo{
+				BlockInfo: BlockInfo{},
+			}
+			m.addFile(entry.FileName)
+		}
+		fileInfo := m.FileInfo[entry.FileName]
+		fileInfo.BlockInfo[entry.BlockMeta.BlockID] = entry.BlockMeta
+		if entry.ModTime > 0 {
+			fileInfo.ModTime = entry.ModTime
+		}
+		fileInfo.ModIndex = entry.Index
+		m.FileInfo[entry.FileName] = fileInfo
+		m.blockFiles[entry.BlockMeta.FileName] = entry.FileName
+	case OpAppendRecord:
+		m.appendRecord(entry)
+	case OpDelFile:
+		m.addGarbage(GarbageOfFile(m.FileInfo[entry.FileName]))
+		m.removeFile(entry.FileName)
+	case OpSetReplication:
+		fileInfo, ok := m.FileInfo[entry.FileName]
+		if !ok {
+			logrus.Warnf("file %s is deleted before its replication is set", entry.FileName)
+			return
+		}
+		fileInfo.Replication = entry.Replication
+		m.FileInfo[entry.FileName] = fileInfo
+	case OpSetCompression:
+		fileInfo, ok := m.FileInfo[entry.FileName]
+		if !ok {
+			logrus.Warnf("file %s is deleted before its compression is set", entry.FileName)
+			return
+		}
+		fileInfo.Compression = entry.Compression
+		m.FileInfo[entry.FileName] = fileInfo
+	case OpMkdir:
+		if err := m.checkParents(entry.FileName); err != nil || m.isFile(entry.FileName) {
+			logrus.Errorf("failed to create directory %s: %v", entry.FileName, err)
+			return
+		}
+		m.mkdirAll(entry.FileName)
+	case OpRename:
+		m.rename(entry.FileName, entry.NewFileName)
+	case OpDelDir:
+		m.walk(entry.FileName, func(name string, isDir bool) {
+			if !isDir {
+				m.addGarbage(GarbageOfFile(m.FileInfo[name]))
+			}
+		})
+		m.removeDir(entry.FileName)
+	case OpAddGarbage:
+		m.addGarbage(entry.Garbage)
+	case OpDelGarbage:
+		for _, block := range entry.Garbage {
+			delete(m.garbage, block.key())
+		}
+	case OpPutLease:
+		if _, ok := m.leases[entry.FileName]; !ok {
+			m.leases[entry.FileName] = map[string]Lease{}
+		}
+		m.leases[entry.FileName][entry.Lease.ClientID] = *entry.Lease
+	case OpRenewLease:
+		if _, ok := m.leases[entry.FileName][entry.Lease.ClientID]; !ok {
+			logrus.Warnf("lock of file %s is released before it is renewed by %s", entry.FileName, entry.Lease.ClientID)
+			return
+		}
+		m.leases[entry.FileName][entry.Lease.ClientID] = *entry.Lease
+	case OpDelLease:
+		if entry.Lease != nil {
+			m.delLease(entry.FileName, entry.Lease.ClientID)
+		}
+		for _, lease := range entry.Leases {
+			// a lease renewed or granted again after it is found expired is kept
+			if held, ok := m.leases[lease.FileName][lease.ClientID]; ok && held.ExpiresAt.Equal(lease.ExpiresAt) {
+				m.delLease(lease.FileName, lease.ClientID)
+			}
+		}
+	case OpNewGeneration:
+		if entry.Generation > m.generation {
+			m.generation = entry.Generation
+		}
+	case OpNoop:
+	default:
+		logrus.Errorf("unknown metadata operation %s", entry.Op)
+	}
+}
+
+// appendRecord grows the blocks of a file by a record, the caller must hold the lock. The record is dropped if the file
+// is replaced by another version since the blocks are reserved, and a block never shrinks as the records of the same
+// generation may be committed out of order.
+func (m *Metadata) appendRecord(entry Entry) {
+	fileInfo, exists := m.FileInfo[entry.FileName]
+	if !exists {
+		fileInfo = FileInfo{BlockInfo: BlockInfo{}}
+	}
+	for _, blockMeta := range entry.BlockInfo {
+		for _, current := range fileInfo.BlockInfo {
+			if current.FileName != blockMeta.FileName {
+				logrus.Warnf("drop the record appended to file %s stored as %s, the file is stored as %s", entry.FileName, blockMeta.FileName, current.FileName)
+				return
+			}
+		}
+	}
+	if !exists {
+		m.FileInfo[entry.FileName] = fileInfo
+		m.addFile(entry.FileName)
+	}
+	m.addGarbage(entry.Garbage)
+	for blockID, blockMeta := range entry.BlockInfo {
+		if current, ok := fileInfo.BlockInfo[blockID]; ok && current.Generation == blockMeta.Generation && current.BlockSize > blockMeta.BlockSize {
+			blockMeta.BlockSize = current.BlockSize
+		}
+		fileInfo.BlockInfo[blockID] = blockMeta
+		m.blockFiles[blockMeta.FileName] = entry.FileName
+	}
+	fileInfo.ModTime = entry.ModTime
+	fileInfo.ModIndex = entry.Index
+	m.FileInfo[entry.FileName] = fileInfo
+}
+
+// Snapshot writes the applied state to the snapshot and returns the index of it.
+// The entries up to the index can then be compacted from the log.
+func (m *Metadata) Snapshot() (uint64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.snapshotPath == "" {
+		return 0, fmt.Errorf("metadata is not persisted")
+	}
+	err := writeSnapshot(m.snapshotPath, m.snapshot())
+	if err != nil {
+		return 0, err
+	}
+	return m.index, nil
+}
+
+// EncodeSnapshot returns the applied state in json, sent to a follower which is behind the compacted log.
+func (m *Metadata) EncodeSnapshot() ([]byte, uint64, uint64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	data, err := json.Marshal(m.snapshot())
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to encode snapshot: %v", err)
+	}
+	return data, m.index, m.term, nil
+}
+
+// RestoreSnapshot replaces the state with a snapshot from the leader and returns the index and term of it.
+// A snapshot older than the applied state is ignored.
+func (m *Metadata) RestoreSnapshot(data []byte) (uint64, uint64, error) {
+	snapshot, err := decodeSnapshot(data)
+	if err != nil {
+		return 0, 0, err
+	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	delete(m.FileInfo, fileName)
+	if snapshot.Index <= m.index {
+		return m.index, m.term, nil
+	}
+	if m.snapshotPath != "" {
+		if err := writeSnapshot(m.snapshotPath, snapshot); err != nil {
+			return 0, 0, err
+		}
+	}
+	m.FileInfo = snapshot.FileInfo
+	m.Dirs = snapshot.Dirs
+	m.rebuildTree()
+	m.garbage = snapshot.Garbage
+	m.leases = snapshot.Leases
+	m.generation = snapshot.Generation
+	m.index = snapshot.Index
+	m.term = snapshot.Term
+	return m.index, m.term, nil
+}
+
+// snapshot returns the applied state, the caller must hold the lock.
+func (m *Metadata) snapshot() *Snapshot {
+	return &Snapshot{
+		Index:      m.index,
+		Term:       m.term,
+		FileInfo:   m.FileInfo,
+		Dirs:       m.Dirs,
+		Garbage:    m.garbage,
+		Leases:     m.leases,
+		Generation: m.generation,
+	}
 }
@@ -0,0 +1,408 @@
+package metadata
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DirEntry is a file or directory in a directory.
+type DirEntry struct {
+	Name       string // name in the directory
+	IsDir      bool
+	Size       int64 // total size of the data of the blocks of a file
+	StoredSize int64 // total size of a replica of each block of a file on the data servers, less than Size if compressed
+	ModTime    int64 // time of the last put or append of a file in unix milliseconds
+}
+
+// CleanPath returns the canonical path of a file or directory, "/a/b/", "a//b" and "a/./b" are all "a/b".
+// The root directory is "".
+func CleanPath(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// parentDir returns the directory of a path, "" for the root directory.
+func parentDir(name string) string {
+	dir := path.Dir(name)
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// IsDir returns whether a path is a directory.
+func (m *Metadata) IsDir(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.isDir(CleanPath(name))
+}
+
+// ListDir returns the files and directories in a directory, sorted by name.
+func (m *Metadata) ListDir(name string) ([]DirEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	name = CleanPath(name)
+	if !m.isDir(name) {
+		return nil, fmt.Errorf("directory %s not found", name)
+	}
+	entries := []DirEntry{}
+	for child := range m.children[name] {
+		entries = append(entries, m.entryOf(path.Join(name, child)))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// Stat returns the entry of a file or directory in its parent directory, and whether it exists.
+func (m *Metadata) Stat(name string) (DirEntry, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	name = CleanPath(name)
+	if !m.isFile(name) && !m.isDir(name) {
+		return DirEntry{}, false
+	}
+	return m.entryOf(name), true
+}
+
+// entryOf returns the entry of an existing file or directory, the caller must hold the lock.
+func (m *Metadata) entryOf(name string) DirEntry {
+	entry := DirEntry{Name: path.Base(name), IsDir: m.isDir(name), ModTime: m.FileInfo[name].ModTime}
+	for _, blockMeta := range m.FileInfo[name].BlockInfo {
+		entry.Size += blockMeta.BlockSize
+		entry.StoredSize += blockMeta.PhysicalSize()
+	}
+	return entry
+}
+
+// GetFilesUnder returns the files in a directory and its subdirectories.
+func (m *Metadata) GetFilesUnder(dir string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	fileNames := []string{}
+	m.walk(CleanPath(dir), func(name string, isDir bool) {
+		if !isDir {
+			fileNames = append(fileNames, name)
+		}
+	})
+	return fileNames
+}
+
+// CheckFilePath returns an error if a file cannot be created at a path, e.g. the path or one of its parents is a file.
+func (m *Metadata) CheckFilePath(fileName string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	fileName = CleanPath(fileName)
+	if fileName == "" || m.isDir(fileName) {
+		return fmt.Errorf("%s is a directory", fileName)
+	}
+	return m.checkParents(fileName)
+}
+
+// Mkdir creates a directory and its parents, it does nothing if the directory exists.
+func (m *Metadata) Mkdir(dir string, caller string) error {
+	dir = CleanPath(dir)
+	m.mu.RLock()
+	err := m.checkParents(dir)
+	if err == nil && m.isFile(dir) {
+		err = fmt.Errorf("file %s exists", dir)
+	}
+	exists := m.isDir(dir)
+	m.mu.RUnlock()
+	if err != nil || exists {
+		return err
+	}
+	return m.commit(Entry{
+		Op:       OpMkdir,
+		FileName: dir,
+		Caller:   caller,
+	})
+}
+
+// Rename moves a file or directory. An existing file is replaced by a file, and an empty directory by a directory.
+// The replicas of a replaced file become garbage in the same entry.
+func (m *Metadata) Rename(oldName, newName string, caller string) error {
+	oldName, newName = CleanPath(oldName), CleanPath(newName)
+	m.mu.RLock()
+	err := m.checkRename(oldName, newName)
+	m.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	return m.commit(Entry{
+		Op:          OpRename,
+		FileName:    oldName,
+		NewFileName: newName,
+		Caller:      caller,
+	})
+}
+
+// DelDir deletes a directory and everything in it, the replicas of the files become garbage in the same entry.
+func (m *Metadata) DelDir(dir string, caller string) error {
+	dir = CleanPath(dir)
+	if dir == "" {
+		return fmt.Errorf("cannot delete the root directory")
+	}
+	if !m.IsDir(dir) {
+		return fmt.Errorf("directory %s not found", dir)
+	}
+	return m.commit(Entry{
+		Op:       OpDelDir,
+		FileName: dir,
+		Caller:   caller,
+	})
+}
+
+// StorageName returns the name of the block files of a file on the data servers. The name is kept when the file is
+// renamed, so a new file gets a name which is not used by the blocks of any file or garbage.
+func (m *Metadata) StorageName(fileName string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	fileName = CleanPath(fileName)
+	for _, blockMeta := range m.FileInfo[fileName].BlockInfo {
+		return blockMeta.FileName
+	}
+	return m.newStorageName(fileName)
+}
+
+// NewStorageName returns an unused name for the block files of a new version of a file, the blocks of the previous
+// versions are not overwritten.
+func (m *Metadata) NewStorageName(fileName string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.newStorageName(CleanPath(fileName))
+}
+
+func (m *Metadata) newStorageName(fileName string) string {
+	base := url.PathEscape(fileName)
+	name := base
+	for i := 1; m.isStorageNameUsed(name) || m.isStorageNameUsed(ParityStorageName(name)); i++ {
+		name = fmt.Sprintf("%s~%d", base, i)
+	}
+	return name
+}
+
+// ParityStorageName returns the name of the parity block files of a version of an erasure-coded file stored as
+// storageName, "#" is escaped in the storage names of the paths.
+func ParityStorageName(storageName string) string {
+	return storageName + "#parity"
+}
+
+// PathOf returns the path of the file whose blocks are stored as storageName.
+func (m *Metadata) PathOf(storageName string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	fileName, ok := m.blockFiles[storageName]
+	return fileName, ok
+}
+
+// IsLocked returns whether a client other than clientID holds a lease on a file or on a file under a directory.
+func (m *Metadata) IsLocked(name, clientID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	name = CleanPath(name)
+	for fileName, leases := range m.leases {
+		if fileName != name && name != "" && !strings.HasPrefix(fileName, name+"/") {
+			continue
+		}
+		for holder := range leases {
+			if holder != clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *Metadata) isDir(name string) bool {
+	return name == "" || m.Dirs[name]
+}
+
+func (m *Metadata) isFile(name string) bool {
+	_, ok := m.FileInfo[name]
+	return ok
+}
+
+func (m *Metadata) isStorageNameUsed(name string) bool {
+	if _, ok := m.blockFiles[name]; ok {
+		return true
+	}
+	for _, block := range m.garbage {
+		if block.FileName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkParents returns an error if a parent of a path is a file.
+func (m *Metadata) checkParents(name string) error {
+	for dir := parentDir(name); dir != ""; dir = parentDir(dir) {
+		if m.isFile(dir) {
+			return fmt.Errorf("%s is a file", dir)
+		}
+	}
+	return nil
+}
+
+// checkRename returns an error if a file or directory cannot be moved to newName, the caller must hold the lock.
+func (m *Metadata) checkRename(oldName, newName string) error {
+	if oldName == "" || newName == "" {
+		return fmt.Errorf("cannot rename the root directory")
+	}
+	if oldName == newName {
+		return fmt.Errorf("%s is renamed to itself", oldName)
+	}
+	if !m.isFile(oldName) && !m.isDir(oldName) {
+		return fmt.Errorf("%s not found", oldName)
+	}
+	if strings.HasPrefix(newName, oldName+"/") {
+		return fmt.Errorf("cannot move %s into itself", oldName)
+	}
+	if err := m.checkParents(newName); err != nil {
+		return err
+	}
+	switch {
+	case m.isFile(oldName) && m.isDir(newName):
+		return fmt.Errorf("%s is a directory", newName)
+	case m.isDir(oldName) && m.isFile(newName):
+		return fmt.Errorf("%s is a file", newName)
+	case m.isDir(newName) && len(m.children[newName]) != 0:
+		return fmt.Errorf("directory %s is not empty", newName)
+	}
+	return nil
+}
+
+// rebuildTree rebuilds the directory tree and the storage names of the blocks from the files and directories.
+// The caller must hold the lock.
+func (m *Metadata) rebuildTree() {
+	m.children = map[string]map[string]bool{}
+	m.blockFiles = map[string]string{}
+	dirs := []string{}
+	for dir := range m.Dirs {
+		dirs = append(dirs, dir)
+	}
+	for _, dir := range dirs {
+		m.mkdirAll(dir)
+	}
+	for fileName := range m.FileInfo {
+		m.addFile(fileName)
+	}
+}
+
+// mkdirAll creates a directory and its parents, the caller must hold the lock.
+func (m *Metadata) mkdirAll(dir string) {
+	for ; dir != ""; dir = parentDir(dir) {
+		parent := parentDir(dir)
+		if m.children[parent] == nil {
+			m.children[parent] = map[string]bool{}
+		}
+		m.children[parent][path.Base(dir)] = true
+		if m.children[dir] == nil {
+			m.children[dir] = map[string]bool{}
+		}
+		m.Dirs[dir] = true
+	}
+}
+
+// addFile adds a file in FileInfo to the tree, the caller must hold the lock.
+func (m *Metadata) addFile(fileName string) {
+	parent := parentDir(fileName)
+	if err := m.checkParents(fileName); err != nil {
+		// only possible for files put before directories were supported
+		logrus.Warnf("File %s is not in the directory tree: %v", fileName, err)
+	} else {
+		m.mkdirAll(parent)
+		if m.children[parent] == nil {
+			// the root directory
+			m.children[parent] = map[string]bool{}
+		}
+		m.children[parent][path.Base(fileName)] = true
+	}
+	for _, blockMeta := range m.FileInfo[fileName].Blocks() {
+		m.blockFiles[blockMeta.FileName] = fileName
+	}
+}
+
+// removeFile removes a file from FileInfo and the tree, the caller must hold the lock.
+func (m *Metadata) removeFile(fileName string) {
+	for _, blockMeta := range m.FileInfo[fileName].Blocks() {
+		if m.blockFiles[blockMeta.FileName] == fileName {
+			delete(m.blockFiles, blockMeta.FileName)
+		}
+	}
+	delete(m.FileInfo, fileName)
+	delete(m.children[parentDir(fileName)], path.Base(fileName))
+}
+
+// removeDir removes a directory and everything in it, the caller must hold the lock.
+func (m *Metadata) removeDir(dir string) {
+	for child := range m.children[dir] {
+		childPath := path.Join(dir, child)
+		if m.isDir(childPath) {
+			m.removeDir(childPath)
+		} else {
+			m.removeFile(childPath)
+		}
+	}
+	delete(m.Dirs, dir)
+	delete(m.children, dir)
+	delete(m.children[parentDir(dir)], path.Base(dir))
+}
+
+// rename moves a file or directory, the caller must hold the lock.
+func (m *Metadata) rename(oldName, newName string) {
+	if err := m.checkRename(oldName, newName); err != nil {
+		logrus.Errorf("failed to rename %s to %s: %v", oldName, newName, err)
+		return
+	}
+	if m.isFile(oldName) {
+		fileInfo := m.FileInfo[oldName]
+		if replaced, ok := m.FileInfo[newName]; ok {
+			m.addGarbage(GarbageOfFile(replaced))
+		}
+		m.removeFile(newName)
+		m.removeFile(oldName)
+		m.FileInfo[newName] = fileInfo
+		m.addFile(newName)
+		return
+	}
+	dirs := []string{}
+	files := map[string]FileInfo{}
+	m.walk(oldName, func(name string, isDir bool) {
+		if isDir {
+			dirs = append(dirs, name)
+		} else {
+			files[name] = m.FileInfo[name]
+		}
+	})
+	m.removeDir(newName)
+	m.removeDir(oldName)
+	m.mkdirAll(newName)
+	for _, dir := range dirs {
+		m.mkdirAll(newName + strings.TrimPrefix(dir, oldName))
+	}
+	for fileName, fileInfo := range files {
+		fileName = newName + strings.TrimPrefix(fileName, oldName)
+		m.FileInfo[fileName] = fileInfo
+		m.addFile(fileName)
+	}
+}
+
+// walk calls fn for every file and directory under a directory, the caller must hold the lock.
+func (m *Metadata) walk(dir string, fn func(name string, isDir bool)) {
+	for child := range m.children[dir] {
+		childPath := path.Join(dir, child)
+		if m.isDir(childPath) {
+			fn(childPath, true)
+			m.walk(childPath, fn)
+		} else {
+			fn(childPath, false)
+		}
+	}
+}
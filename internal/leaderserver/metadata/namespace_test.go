@@ -0,0 +1,103 @@
+package metadata
+
+import (
+	"sort"
+	"testing"
+)
+
+func putTestFile(t *testing.T, m *Metadata, fileName string) {
+	t.Helper()
+	storageName := m.NewStorageName(fileName)
+	blockInfo := BlockInfo{0: {HostNames: []string{"host1", "host2"}, FileName: storageName, BlockID: 0, BlockSize: 10, Generation: 1}}
+	if err := m.AddOrUpdateBlockInfo(fileName, blockInfo); err != nil {
+		t.Fatalf("put %s: %v", fileName, err)
+	}
+}
+
+func dirNames(t *testing.T, m *Metadata, dir string) []string {
+	t.Helper()
+	entries, err := m.ListDir(dir)
+	if err != nil {
+		t.Fatalf("ListDir(%q): %v", dir, err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNamespaceRootFiles(t *testing.T) {
+	m := NewMetadata()
+	putTestFile(t, m, "/top")
+	putTestFile(t, m, "/dir/inner")
+	if got := dirNames(t, m, "/"); !equalNames(got, []string{"dir", "top"}) {
+		t.Fatalf("root lists %v, want [dir top]", got)
+	}
+
+	if err := m.Rename("/top", "/dir/moved", ""); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if m.IsFileExist("/top") || !m.IsFileExist("/dir/moved") {
+		t.Fatalf("file is not moved from the root")
+	}
+	if got := dirNames(t, m, "/dir"); !equalNames(got, []string{"inner", "moved"}) {
+		t.Errorf("dir lists %v, want [inner moved]", got)
+	}
+	if err := m.Rename("/dir/moved", "/back", ""); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if got := dirNames(t, m, "/"); !equalNames(got, []string{"back", "dir"}) {
+		t.Errorf("root lists %v, want [back dir]", got)
+	}
+
+	if err := m.DelDir("/dir", ""); err != nil {
+		t.Fatalf("DelDir: %v", err)
+	}
+	if got := dirNames(t, m, "/"); !equalNames(got, []string{"back"}) {
+		t.Errorf("root lists %v after deleting dir, want [back]", got)
+	}
+	if m.IsFileExist("/dir/inner") || m.IsDir("/dir") {
+		t.Errorf("directory is not deleted")
+	}
+	if len(m.GetGarbage()) != 2 {
+		t.Errorf("got %d garbage replicas, want the 2 replicas of the deleted file", len(m.GetGarbage()))
+	}
+	if err := m.DelDir("/", ""); err == nil {
+		t.Errorf("root directory is deleted")
+	}
+}
+
+func TestNamespaceRenameReplacesFile(t *testing.T) {
+	m := NewMetadata()
+	putTestFile(t, m, "/a")
+	putTestFile(t, m, "/b")
+	replaced, err := m.GetFile("/b")
+	if err != nil {
+		t.Fatalf("GetFile: %v", err)
+	}
+	if err := m.Rename("/a", "/b", ""); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if got := dirNames(t, m, "/"); !equalNames(got, []string{"b"}) {
+		t.Errorf("root lists %v, want [b]", got)
+	}
+	for _, block := range GarbageOfFile(replaced) {
+		if !m.IsGarbage(block) {
+			t.Errorf("replica %v of the replaced file is not garbage", block)
+		}
+	}
+}
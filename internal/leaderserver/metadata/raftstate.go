@@ -0,0 +1,40 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// RaftState is the state a leader server must persist before answering a Raft RPC.
+type RaftState struct {
+	Term     uint64
+	VotedFor string
+}
+
+// ReadRaftState reads the raft state in dir, an empty state is returned if there is none.
+func ReadRaftState(dir string) (*RaftState, error) {
+	path := filepath.Join(dir, "raft.json")
+	state := &RaftState{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read raft state %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to decode raft state %s: %v", path, err)
+	}
+	return state, nil
+}
+
+// WriteRaftState atomically replaces the raft state in dir.
+func WriteRaftState(dir string, state *RaftState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode raft state: %v", err)
+	}
+	return writeFileAtomic(filepath.Join(dir, "raft.json"), data)
+}
@@ -0,0 +1,100 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Snapshot is the compacted state of the metadata up to Index.
+type Snapshot struct {
+	Index    uint64
+	Term     uint64
+	FileInfo map[string]FileInfo
+	Dirs     map[string]bool
+	Garbage  map[string]GarbageBlock
+	Leases   map[string]map[string]Lease
+	// Generation is the last generation assigned to a write of blocks
+	Generation int64
+}
+
+// readSnapshot reads the snapshot at path, an empty snapshot is returned if there is none.
+func readSnapshot(path string) (*Snapshot, error) {
+	snapshot := &Snapshot{FileInfo: map[string]FileInfo{}, Dirs: map[string]bool{}, Garbage: map[string]GarbageBlock{}, Leases: map[string]map[string]Lease{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return snapshot, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %v", path, err)
+	}
+	snapshot, err = decodeSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %v", path, err)
+	}
+	return snapshot, nil
+}
+
+func decodeSnapshot(data []byte) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %v", err)
+	}
+	if snapshot.FileInfo == nil {
+		snapshot.FileInfo = map[string]FileInfo{}
+	}
+	if snapshot.Dirs == nil {
+		snapshot.Dirs = map[string]bool{}
+	}
+	if snapshot.Garbage == nil {
+		snapshot.Garbage = map[string]GarbageBlock{}
+	}
+	if snapshot.Leases == nil {
+		snapshot.Leases = map[string]map[string]Lease{}
+	}
+	return snapshot, nil
+}
+
+// writeSnapshot atomically replaces the snapshot at path.
+func writeSnapshot(path string, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %v", err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic replaces the file at path with data through a fsync'd temp file.
+func writeFileAtomic(path string, data []byte) error {
+	tempPath := path + ".temp"
+	file, err := os.Create(tempPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %v", tempPath, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %v", tempPath, err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to sync %s: %v", tempPath, err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("failed to rename %s: %v", tempPath, err)
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir fsyncs a directory so that a rename in it is durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,119 @@
+package metadata
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FileVersion is a version of a file.
+type FileVersion struct {
+	Version     int64
+	BlockInfo   BlockInfo
+	Parity      BlockInfo `json:",omitempty"` // parity blocks of the stripes of BlockInfo if it is erasure-coded
+	Policy      string    `json:",omitempty"` // storage policy of BlockInfo, replication if empty
+	Compression string    `json:",omitempty"` // codec of the new blocks of the file, not compressed if empty
+}
+
+// Blocks returns the data and parity blocks of a version.
+func (v FileVersion) Blocks() []BlockMeta {
+	blocks := []BlockMeta{}
+	for _, blockMeta := range v.BlockInfo {
+		blocks = append(blocks, blockMeta)
+	}
+	for _, blockMeta := range v.Parity {
+		blocks = append(blocks, blockMeta)
+	}
+	return blocks
+}
+
+func (v FileVersion) copy() FileVersion {
+	version := v
+	version.BlockInfo = v.BlockInfo.copy()
+	version.Parity = v.Parity.copy()
+	return version
+}
+
+// GetVersion returns a copy of a version of a file, the current version if version is 0.
+func (m *Metadata) GetVersion(fileName string, version int64) (FileVersion, error) {
+	fileVersion, _, err := m.GetVersionWithModIndex(fileName, version)
+	return fileVersion, err
+}
+
+// GetVersionWithModIndex returns a copy of a version of a file and the index of the last change to the data of the file.
+func (m *Metadata) GetVersionWithModIndex(fileName string, version int64) (FileVersion, uint64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	fileName = CleanPath(fileName)
+	fileInfo, ok := m.FileInfo[fileName]
+	if !ok {
+		return FileVersion{}, 0, fmt.Errorf("file %s not found", fileName)
+	}
+	for _, v := range fileInfo.AllVersions() {
+		if version == 0 || v.Version == version {
+			return v.copy(), fileInfo.ModIndex, nil
+		}
+	}
+	return FileVersion{}, 0, fmt.Errorf("version %d of file %s not found", version, fileName)
+}
+
+// ModIndexOf returns the index of the last entry which changed the data of a file, 0 if the file does not exist or the
+// index is unknown. The index is unique to each change, so the blocks of a file are unchanged while it is the same.
+func (m *Metadata) ModIndexOf(fileName string) uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.FileInfo[CleanPath(fileName)].ModIndex
+}
+
+// GetVersions returns the versions of a file which are kept, the newest first.
+func (m *Metadata) GetVersions(fileName string) ([]int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	fileName = CleanPath(fileName)
+	fileInfo, ok := m.FileInfo[fileName]
+	if !ok {
+		return nil, fmt.Errorf("file %s not found", fileName)
+	}
+	versions := []int64{fileInfo.Version}
+	for _, v := range fileInfo.Versions {
+		versions = append(versions, v.Version)
+	}
+	return versions, nil
+}
+
+// PutVersion replaces a file with a new version, the number of version is assigned. The last maxVersions versions
+// including the new one are kept, the replicas of the older versions become garbage. The replication of the file is
+// unchanged if 0.
+func (m *Metadata) PutVersion(fileName string, version FileVersion, maxVersions int, replication int, caller string) error {
+	return m.commit(Entry{
+		Op:          OpPutFile,
+		FileName:    CleanPath(fileName),
+		BlockInfo:   version.BlockInfo,
+		Parity:      version.Parity,
+		Policy:      version.Policy,
+		Compression: version.Compression,
+		MaxVersions: maxVersions,
+		Replication: replication,
+		ModTime:     time.Now().UnixMilli(),
+		Caller:      caller,
+	})
+}
+
+// putVersion moves the current version of a file to its previous versions before it is replaced, the caller must
+// hold the lock. The versions beyond maxVersions are dropped and their replicas become garbage.
+func (m *Metadata) putVersion(fileName string, fileInfo *FileInfo, exists bool, maxVersions int) {
+	fileInfo.MaxVersions = maxVersions
+	if exists {
+		fileInfo.Versions = append([]FileVersion{fileInfo.Current()}, fileInfo.Versions...)
+	}
+	fileInfo.Version++
+	if len(fileInfo.Versions) < maxVersions {
+		return
+	}
+	for _, version := range fileInfo.Versions[maxVersions-1:] {
+		logrus.Infof("Drop version %d of file %s", version.Version, fileName)
+		m.addGarbage(GarbageOfVersion(version))
+	}
+	fileInfo.Versions = fileInfo.Versions[:maxVersions-1]
+}
@@ -0,0 +1,220 @@
+package metadata
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Operation is the type of a metadata mutation.
+type Operation string
+
+const (
+	OpPutFile        Operation = "put_file"        // replace the block info of a file, or put a new version of it
+	OpPutBlock       Operation = "put_block"       // add or update a block of a file
+	OpAppendRecord   Operation = "append_record"   // grow the blocks of a file by an appended record
+	OpDelFile        Operation = "del_file"        // delete a file
+	OpSetReplication Operation = "set_replication" // set the number of replicas of each block of a file
+	OpSetCompression Operation = "set_compression" // set the codec of the new blocks of a file
+	OpNoop           Operation = "noop"            // appended by a new leader to commit the entries of previous terms
+
+	OpNewGeneration Operation = "new_generation" // assign a generation to a write of blocks
+
+	OpAddGarbage Operation = "add_garbage" // add replicas to be removed from the data servers
+	OpDelGarbage Operation = "del_garbage" // drop replicas to be removed from the data servers
+
+	OpPutLease   Operation = "put_lease"   // grant a lease on a file
+	OpRenewLease Operation = "renew_lease" // extend a lease on a file which is still held
+	OpDelLease   Operation = "del_lease"   // release a lease on a file, or reclaim expired leases
+
+	OpMkdir  Operation = "mkdir"   // create a directory and its parents
+	OpRename Operation = "rename"  // move a file or directory to a new path
+	OpDelDir Operation = "del_dir" // delete a directory and everything in it
+)
+
+// Entry is a metadata mutation recorded in the write-ahead log.
+type Entry struct {
+	Index       uint64
+	Term        uint64
+	Op          Operation
+	FileName    string
+	NewFileName string         `json:",omitempty"` // target of a rename
+	BlockInfo   BlockInfo      `json:",omitempty"`
+	Parity      BlockInfo      `json:",omitempty"` // parity blocks of an erasure-coded put
+	Policy      string         `json:",omitempty"` // storage policy of a put, replication if empty
+	Compression string         `json:",omitempty"` // codec of the new blocks of a file, not compressed if empty
+	BlockMeta   BlockMeta      `json:",omitempty"`
+	Garbage     []GarbageBlock `json:",omitempty"`
+	Lease       *Lease         `json:",omitempty"`
+	Leases      []Lease        `json:",omitempty"` // expired leases reclaimed together
+	MaxVersions int            `json:",omitempty"` // versions kept by a put, the file is not versioned if 0
+	Generation  int64          `json:",omitempty"` // assigned generation
+	Replication int            `json:",omitempty"` // replicas of each block of the file, unchanged if 0
+	ModTime     int64          `json:",omitempty"` // time of a put or an append in unix milliseconds
+	Caller      string         `json:",omitempty"` // identity of the user or node which made the change of a file
+}
+
+// WAL is an append-only log of metadata mutations.
+// Each line is "<crc32 in hex> <entry in json>" and every append is fsync'd.
+type WAL struct {
+	path    string
+	file    *os.File
+	offsets []int64 // offset of each entry in the file
+	size    int64
+}
+
+// OpenWAL opens the write-ahead log at path and returns the entries in it.
+// A torn entry at the end of the log (e.g. a crash during append) is truncated, a corrupted entry before the last one
+// is an error since the entries after it are acknowledged.
+func OpenWAL(path string) (*WAL, []Entry, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open wal %s: %v", path, err)
+	}
+	entries := []Entry{}
+	offsets := []int64{}
+	var offset int64 = 0
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) != 0 {
+				logrus.Warnf("Truncating incomplete entry at the end of wal %s", path)
+			}
+			break
+		}
+		if err != nil {
+			file.Close()
+			return nil, nil, fmt.Errorf("failed to read wal %s: %v", path, err)
+		}
+		entry, err := decodeEntry(line)
+		if err != nil {
+			if _, peekErr := reader.Peek(1); peekErr != io.EOF {
+				file.Close()
+				return nil, nil, fmt.Errorf("corrupted entry at offset %d of wal %s: %v", offset, path, err)
+			}
+			logrus.Warnf("Truncating corrupted entry at the end of wal %s: %v", path, err)
+			break
+		}
+		entries = append(entries, entry)
+		offsets = append(offsets, offset)
+		offset += int64(len(line))
+	}
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to truncate wal %s: %v", path, err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to seek wal %s: %v", path, err)
+	}
+	return &WAL{path: path, file: file, offsets: offsets, size: offset}, entries, nil
+}
+
+// Append writes entries to the log and fsyncs them.
+func (w *WAL) Append(entries ...Entry) error {
+	data := []byte{}
+	offsets := []int64{}
+	for _, entry := range entries {
+		line, err := encodeEntry(entry)
+		if err != nil {
+			return err
+		}
+		offsets = append(offsets, w.size+int64(len(data)))
+		data = append(data, line...)
+	}
+	if _, err := w.file.Write(data); err != nil {
+		return fmt.Errorf("failed to append to wal %s: %v", w.path, err)
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync wal %s: %v", w.path, err)
+	}
+	w.offsets = append(w.offsets, offsets...)
+	w.size += int64(len(data))
+	return nil
+}
+
+// TruncateFrom drops the entries from the i-th entry of the log.
+func (w *WAL) TruncateFrom(i int) error {
+	if i >= len(w.offsets) {
+		return nil
+	}
+	offset := w.offsets[i]
+	if err := w.file.Truncate(offset); err != nil {
+		return fmt.Errorf("failed to truncate wal %s: %v", w.path, err)
+	}
+	if _, err := w.file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek wal %s: %v", w.path, err)
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync wal %s: %v", w.path, err)
+	}
+	w.offsets = w.offsets[:i]
+	w.size = offset
+	return nil
+}
+
+// Rewrite atomically replaces the log with entries, called after the log is compacted into a snapshot.
+func (w *WAL) Rewrite(entries []Entry) error {
+	data := []byte{}
+	offsets := []int64{}
+	for _, entry := range entries {
+		line, err := encodeEntry(entry)
+		if err != nil {
+			return err
+		}
+		offsets = append(offsets, int64(len(data)))
+		data = append(data, line...)
+	}
+	if err := writeFileAtomic(w.path, data); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(w.path, os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open wal %s: %v", w.path, err)
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to seek wal %s: %v", w.path, err)
+	}
+	w.file.Close()
+	w.file = file
+	w.offsets = offsets
+	w.size = int64(len(data))
+	return nil
+}
+
+// Close closes the log.
+func (w *WAL) Close() error {
+	return w.file.Close()
+}
+
+func encodeEntry(entry Entry) ([]byte, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode wal entry: %v", err)
+	}
+	return []byte(fmt.Sprintf("%08x %s\n", crc32.ChecksumIEEE(data), data)), nil
+}
+
+func decodeEntry(line []byte) (Entry, error) {
+	entry := Entry{}
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	checksum, data, ok := bytes.Cut(line, []byte(" "))
+	if !ok {
+		return entry, fmt.Errorf("malformed entry")
+	}
+	if fmt.Sprintf("%08x", crc32.ChecksumIEEE(data)) != string(checksum) {
+		return entry, fmt.Errorf("checksum mismatch")
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
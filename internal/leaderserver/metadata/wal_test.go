@@ -0,0 +1,92 @@
+package metadata
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestWAL(t *testing.T, n int) (string, []byte) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "wal.log")
+	wal, _, err := OpenWAL(path)
+	if err != nil {
+		t.Fatalf("OpenWAL: %v", err)
+	}
+	for i := 1; i <= n; i++ {
+		if err := wal.Append(Entry{Index: uint64(i), Term: 1, Op: OpMkdir, FileName: "/dir"}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	wal.Close()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	return path, data
+}
+
+func TestOpenWALTruncatesTornTail(t *testing.T) {
+	tests := []struct {
+		name string
+		tail func(data []byte) []byte
+	}{
+		{"incomplete entry", func(data []byte) []byte {
+			return append(data, []byte(`0badc0de {"Index":4`)...)
+		}},
+		{"corrupted last entry", func(data []byte) []byte {
+			return append(data, []byte("0badc0de {\"Index\":4}\n")...)
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, data := writeTestWAL(t, 3)
+			if err := os.WriteFile(path, test.tail(append([]byte{}, data...)), 0644); err != nil {
+				t.Fatalf("WriteFile: %v", err)
+			}
+			wal, entries, err := OpenWAL(path)
+			if err != nil {
+				t.Fatalf("OpenWAL: %v", err)
+			}
+			defer wal.Close()
+			if len(entries) != 3 {
+				t.Fatalf("got %d entries, want 3", len(entries))
+			}
+			truncated, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			if !bytes.Equal(truncated, data) {
+				t.Errorf("wal is not truncated to the complete entries")
+			}
+			if err := wal.Append(Entry{Index: 4, Term: 1, Op: OpNoop}); err != nil {
+				t.Fatalf("Append: %v", err)
+			}
+			wal.Close()
+			if _, entries, err = OpenWAL(path); err != nil || len(entries) != 4 {
+				t.Errorf("reopened wal with %d entries and error %v, want 4 entries", len(entries), err)
+			}
+		})
+	}
+}
+
+func TestOpenWALRejectsCorruptionBeforeLastEntry(t *testing.T) {
+	path, data := writeTestWAL(t, 3)
+	// flip a bit in the data of the second entry
+	second := bytes.IndexByte(data, '\n') + 1
+	data[second+20] ^= 0x01
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, _, err := OpenWAL(path); err == nil {
+		t.Fatalf("OpenWAL succeeded on a corrupted entry before the last one")
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.Equal(after, data) {
+		t.Errorf("corrupted wal is modified")
+	}
+}
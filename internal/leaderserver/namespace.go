@@ -0,0 +1,72 @@
+package leaderserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// Mkdir creates a directory and its parents through gRPC.
+func (l *LeaderServer) Mkdir(ctx context.Context, in *pb.MkdirRequest) (*pb.MkdirReply, error) {
+	if err := l.metadata.Mkdir(in.GetDirName(), auth.Caller(ctx)); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %v", in.GetDirName(), err)
+	}
+	return &pb.MkdirReply{}, nil
+}
+
+// ListDir returns the files and directories in a directory through gRPC.
+func (l *LeaderServer) ListDir(ctx context.Context, in *pb.ListDirRequest) (*pb.ListDirReply, error) {
+	if l.metadata.IsFileExist(in.GetDirName()) {
+		return &pb.ListDirReply{IsDir: false}, nil
+	}
+	entries, err := l.metadata.ListDir(in.GetDirName())
+	if err != nil {
+		return nil, err
+	}
+	reply := &pb.ListDirReply{IsDir: true}
+	for _, entry := range entries {
+		reply.Entries = append(reply.Entries, &pb.DirEntry{
+			Name:       entry.Name,
+			IsDir:      entry.IsDir,
+			Size:       entry.Size,
+			StoredSize: entry.StoredSize,
+			ModTime:    entry.ModTime,
+		})
+	}
+	return reply, nil
+}
+
+// Stat returns the entry of a file or directory through gRPC.
+func (l *LeaderServer) Stat(ctx context.Context, in *pb.StatRequest) (*pb.StatReply, error) {
+	entry, ok := l.metadata.Stat(in.GetName())
+	if !ok {
+		return &pb.StatReply{Exists: false}, nil
+	}
+	return &pb.StatReply{
+		Exists: true,
+		Entry: &pb.DirEntry{
+			Name:       entry.Name,
+			IsDir:      entry.IsDir,
+			Size:       entry.Size,
+			StoredSize: entry.StoredSize,
+			ModTime:    entry.ModTime,
+		},
+	}, nil
+}
+
+// Rename atomically moves a file or directory through gRPC. Files which are locked by clients cannot be moved.
+func (l *LeaderServer) Rename(ctx context.Context, in *pb.RenameRequest) (*pb.RenameReply, error) {
+	oldName, newName := in.GetFileName(), in.GetNewFileName()
+	if l.metadata.IsLocked(oldName, "") || l.metadata.IsLocked(newName, "") {
+		return nil, fmt.Errorf("cannot rename %s to %s while the files are locked", oldName, newName)
+	}
+	// the replicas of the replaced file are removed from the data servers by collectGarbage
+	if err := l.metadata.Rename(oldName, newName, auth.Caller(ctx)); err != nil {
+		return nil, fmt.Errorf("failed to rename %s to %s: %v", oldName, newName, err)
+	}
+	logrus.Infof("Renamed %s to %s", oldName, newName)
+	return &pb.RenameReply{}, nil
+}
@@ -0,0 +1,189 @@
+package leaderserver
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/memberserver/heartbeat"
+)
+
+// defaultMaxDiskUsage is used if the max disk usage is not set in config.
+const defaultMaxDiskUsage = 0.9
+
+// PlacementPolicy chooses the data servers to store new replicas of a block.
+type PlacementPolicy interface {
+	// ChooseTargets returns at most n of candidates to store new replicas of a block which is stored on existing.
+	// Fewer are returned if there are not enough suitable candidates.
+	ChooseTargets(n int, existing []Node, candidates []Node) []string
+	// ChooseExcess returns n of replicas to be removed from an over-replicated block.
+	ChooseExcess(n int, replicas []Node) []string
+}
+
+// Node is an alive data server.
+type Node struct {
+	HostName  string
+	Zone      string
+	DiskUsage float64 // used fraction of the disk, 0 if not reported yet
+}
+
+// NewPlacementPolicy returns the placement policy in config.
+func NewPlacementPolicy(config config.Placement) (PlacementPolicy, error) {
+	maxDiskUsage := config.MaxDiskUsage
+	if maxDiskUsage <= 0 {
+		maxDiskUsage = defaultMaxDiskUsage
+	}
+	switch config.Policy {
+	case "", "zone":
+		return &ZonePlacementPolicy{maxDiskUsage: maxDiskUsage}, nil
+	case "random":
+		return &RandomPlacementPolicy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown placement policy %s", config.Policy)
+	}
+}
+
+// RandomPlacementPolicy chooses random data servers.
+type RandomPlacementPolicy struct{}
+
+// ChooseTargets returns at most n random candidates.
+func (p *RandomPlacementPolicy) ChooseTargets(n int, existing []Node, candidates []Node) []string {
+	hostNames := []string{}
+	for _, i := range rand.Perm(len(candidates)) {
+		if len(hostNames) == n {
+			break
+		}
+		hostNames = append(hostNames, candidates[i].HostName)
+	}
+	return hostNames
+}
+
+// ChooseExcess returns n random replicas.
+func (p *RandomPlacementPolicy) ChooseExcess(n int, replicas []Node) []string {
+	hostNames := []string{}
+	for _, i := range rand.Perm(len(replicas)) {
+		if len(hostNames) == n {
+			break
+		}
+		hostNames = append(hostNames, replicas[i].HostName)
+	}
+	return hostNames
+}
+
+// ZonePlacementPolicy spreads the replicas of a block across zones and avoids data servers with full disks.
+type ZonePlacementPolicy struct {
+	maxDiskUsage float64
+}
+
+// ChooseTargets returns at most n candidates below the max disk usage. Each one is chosen from the zones with the fewest
+// replicas of the block, preferring the data servers with more free disk.
+func (p *ZonePlacementPolicy) ChooseTargets(n int, existing []Node, candidates []Node) []string {
+	replicasInZone := map[string]int{}
+	for _, node := range existing {
+		replicasInZone[node.Zone]++
+	}
+	available := []Node{}
+	for _, i := range rand.Perm(len(candidates)) {
+		if candidates[i].DiskUsage >= p.maxDiskUsage {
+			logrus.Debugf("Skip data server %s with disk usage %.2f", candidates[i].HostName, candidates[i].DiskUsage)
+			continue
+		}
+		available = append(available, candidates[i])
+	}
+	// the free disk is compared in steps of 10% so that the replicas are not always placed on the emptiest data server
+	sort.SliceStable(available, func(i, j int) bool {
+		return int(available[i].DiskUsage*10) < int(available[j].DiskUsage*10)
+	})
+	hostNames := []string{}
+	for len(hostNames) < n && len(available) > 0 {
+		chosen := 0
+		for i, node := range available {
+			if replicasInZone[node.Zone] < replicasInZone[available[chosen].Zone] {
+				chosen = i
+			}
+		}
+		hostNames = append(hostNames, available[chosen].HostName)
+		replicasInZone[available[chosen].Zone]++
+		available = append(available[:chosen], available[chosen+1:]...)
+	}
+	return hostNames
+}
+
+// ChooseExcess returns n replicas, each one is chosen from the zones with the most replicas of the block,
+// preferring the data servers with less free disk.
+func (p *ZonePlacementPolicy) ChooseExcess(n int, replicas []Node) []string {
+	replicasInZone := map[string]int{}
+	for _, node := range replicas {
+		replicasInZone[node.Zone]++
+	}
+	remaining := append([]Node{}, replicas...)
+	hostNames := []string{}
+	for len(hostNames) < n && len(remaining) > 0 {
+		chosen := 0
+		for i, node := range remaining {
+			if replicasInZone[node.Zone] > replicasInZone[remaining[chosen].Zone] ||
+				replicasInZone[node.Zone] == replicasInZone[remaining[chosen].Zone] && node.DiskUsage > remaining[chosen].DiskUsage {
+				chosen = i
+			}
+		}
+		hostNames = append(hostNames, remaining[chosen].HostName)
+		replicasInZone[remaining[chosen].Zone]--
+		remaining = append(remaining[:chosen], remaining[chosen+1:]...)
+	}
+	return hostNames
+}
+
+// aliveNodes returns the alive data servers with their zones and disk usage.
+func (l *LeaderServer) aliveNodes() ([]Node, error) {
+	heartbeat, err := heartbeat.GetInstance()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get heartbeat instance: %v", err)
+	}
+	membership := heartbeat.GetMembership()
+	if membership == nil {
+		return nil, fmt.Errorf("failed to get membership instance")
+	}
+	nodes := []Node{}
+	for _, member := range membership.GetAliveMembers() {
+		nodes = append(nodes, Node{
+			HostName:  member.GetName(),
+			Zone:      l.zones[member.GetName()],
+			DiskUsage: l.blockReports.getDiskUsage(member.GetName()),
+		})
+	}
+	return nodes, nil
+}
+
+// placeReplicas chooses at most n of nodes by the placement policy to store new replicas of a block which is stored on existing.
+func (l *LeaderServer) placeReplicas(nodes []Node, n int, existing []string) []string {
+	if n <= 0 {
+		return []string{}
+	}
+	existingNodes := []Node{}
+	candidates := []Node{}
+	for _, node := range nodes {
+		if containsHost(existing, node.HostName) {
+			existingNodes = append(existingNodes, node)
+		} else {
+			candidates = append(candidates, node)
+		}
+	}
+	for _, hostName := range existing {
+		// replicas on the data servers which are not alive still count for their zones
+		if !containsNode(existingNodes, hostName) {
+			existingNodes = append(existingNodes, Node{HostName: hostName, Zone: l.zones[hostName]})
+		}
+	}
+	return l.placementPolicy.ChooseTargets(n, existingNodes, candidates)
+}
+
+func containsNode(nodes []Node, hostName string) bool {
+	for _, node := range nodes {
+		if node.HostName == hostName {
+			return true
+		}
+	}
+	return false
+}
@@ -25,17 +25,33 @@ type LeaderServerClient interface {
 	GetLeader(ctx context.Context, in *GetLeaderRequest, opts ...grpc.CallOption) (*GetLeaderReply, error)
 	GetBlockInfo(ctx context.Context, in *GetBlockInfoRequest, opts ...grpc.CallOption) (*GetBlockInfoReply, error)
 	GetFileOK(ctx context.Context, in *GetFileOKRequest, opts ...grpc.CallOption) (*GetFileOKReply, error)
+	GetVersions(ctx context.Context, in *GetVersionsRequest, opts ...grpc.CallOption) (*GetVersionsReply, error)
 	PutBlockInfo(ctx context.Context, in *PutBlockInfoRequest, opts ...grpc.CallOption) (*PutBlockInfoReply, error)
 	PutFileOK(ctx context.Context, in *PutFileOKRequest, opts ...grpc.CallOption) (*PutFileOKReply, error)
+	SetReplication(ctx context.Context, in *SetReplicationRequest, opts ...grpc.CallOption) (*SetReplicationReply, error)
 	DelFile(ctx context.Context, in *DelFileRequest, opts ...grpc.CallOption) (*DelFileReply, error)
+	Mkdir(ctx context.Context, in *MkdirRequest, opts ...grpc.CallOption) (*MkdirReply, error)
+	ListDir(ctx context.Context, in *ListDirRequest, opts ...grpc.CallOption) (*ListDirReply, error)
+	Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatReply, error)
+	Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*RenameReply, error)
 	AppendBlockInfo(ctx context.Context, in *AppendBlockInfoRequest, opts ...grpc.CallOption) (*AppendBlockInfoReply, error)
 	AppendFileOK(ctx context.Context, in *AppendFileOKRequest, opts ...grpc.CallOption) (*AppendFileOKReply, error)
+	AddBlock(ctx context.Context, in *AddBlockRequest, opts ...grpc.CallOption) (*AddBlockReply, error)
+	AppendRecordInfo(ctx context.Context, in *AppendRecordInfoRequest, opts ...grpc.CallOption) (*AppendRecordInfoReply, error)
+	AppendRecordOK(ctx context.Context, in *AppendRecordOKRequest, opts ...grpc.CallOption) (*AppendRecordOKReply, error)
 	GetMetadata(ctx context.Context, in *GetMetadataRequest, opts ...grpc.CallOption) (*GetMetadataReply, error)
-	SetLeader(ctx context.Context, in *SetLeaderRequest, opts ...grpc.CallOption) (*SetLeaderReply, error)
 	AcquireReadLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*AcquireLockReply, error)
 	ReleaseReadLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*ReleaseLockReply, error)
 	AcquireWriteLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*AcquireLockReply, error)
 	ReleaseWriteLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*ReleaseLockReply, error)
+	RenewLock(ctx context.Context, in *RenewLockRequest, opts ...grpc.CallOption) (*RenewLockReply, error)
+	ListLocks(ctx context.Context, in *ListLocksRequest, opts ...grpc.CallOption) (*ListLocksReply, error)
+	BlockReport(ctx context.Context, in *BlockReportRequest, opts ...grpc.CallOption) (*BlockReportReply, error)
+	ReportBadBlock(ctx context.Context, in *ReportBadBlockRequest, opts ...grpc.CallOption) (*ReportBadBlockReply, error)
+	Balance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceReply, error)
+	RequestVote(ctx context.Context, in *RequestVoteRequest, opts ...grpc.CallOption) (*RequestVoteReply, error)
+	AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesReply, error)
+	InstallSnapshot(ctx context.Context, in *InstallSnapshotRequest, opts ...grpc.CallOption) (*InstallSnapshotReply, error)
 }
 
 type leaderServerClient struct {
@@ -73,6 +89,15 @@ func (c *leaderServerClient) GetFileOK(ctx context.Context, in *GetFileOKRequest
 	return out, nil
 }
 
+func (c *leaderServerClient) GetVersions(ctx context.Context, in *GetVersionsRequest, opts ...grpc.CallOption) (*GetVersionsReply, error) {
+	out := new(GetVersionsReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/GetVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *leaderServerClient) PutBlockInfo(ctx context.Context, in *PutBlockInfoRequest, opts ...grpc.CallOption) (*PutBlockInfoReply, error) {
 	out := new(PutBlockInfoReply)
 	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/PutBlockInfo", in, out, opts...)
@@ -91,6 +116,15 @@ func (c *leaderServerClient) PutFileOK(ctx context.Context, in *PutFileOKRequest
 	return out, nil
 }
 
+func (c *leaderServerClient) SetReplication(ctx context.Context, in *SetReplicationRequest, opts ...grpc.CallOption) (*SetReplicationReply, error) {
+	out := new(SetReplicationReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/SetReplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *leaderServerClient) DelFile(ctx context.Context, in *DelFileRequest, opts ...grpc.CallOption) (*DelFileReply, error) {
 	out := new(DelFileReply)
 	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/DelFile", in, out, opts...)
@@ -100,6 +134,42 @@ func (c *leaderServerClient) DelFile(ctx context.Context, in *DelFileRequest, op
 	return out, nil
 }
 
+func (c *leaderServerClient) Mkdir(ctx context.Context, in *MkdirRequest, opts ...grpc.CallOption) (*MkdirReply, error) {
+	out := new(MkdirReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/Mkdir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) ListDir(ctx context.Context, in *ListDirRequest, opts ...grpc.CallOption) (*ListDirReply, error) {
+	out := new(ListDirReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/ListDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatReply, error) {
+	out := new(StatReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/Stat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*RenameReply, error) {
+	out := new(RenameReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/Rename", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *leaderServerClient) AppendBlockInfo(ctx context.Context, in *AppendBlockInfoRequest, opts ...grpc.CallOption) (*AppendBlockInfoReply, error) {
 	out := new(AppendBlockInfoReply)
 	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/AppendBlockInfo", in, out, opts...)
@@ -118,18 +188,36 @@ func (c *leaderServerClient) AppendFileOK(ctx context.Context, in *AppendFileOKR
 	return out, nil
 }
 
-func (c *leaderServerClient) GetMetadata(ctx context.Context, in *GetMetadataRequest, opts ...grpc.CallOption) (*GetMetadataReply, error) {
-	out := new(GetMetadataReply)
-	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/GetMetadata", in, out, opts...)
+func (c *leaderServerClient) AddBlock(ctx context.Context, in *AddBlockRequest, opts ...grpc.CallOption) (*AddBlockReply, error) {
+	out := new(AddBlockReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/AddBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) AppendRecordInfo(ctx context.Context, in *AppendRecordInfoRequest, opts ...grpc.CallOption) (*AppendRecordInfoReply, error) {
+	out := new(AppendRecordInfoReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/AppendRecordInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) AppendRecordOK(ctx context.Context, in *AppendRecordOKRequest, opts ...grpc.CallOption) (*AppendRecordOKReply, error) {
+	out := new(AppendRecordOKReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/AppendRecordOK", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *leaderServerClient) SetLeader(ctx context.Context, in *SetLeaderRequest, opts ...grpc.CallOption) (*SetLeaderReply, error) {
-	out := new(SetLeaderReply)
-	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/SetLeader", in, out, opts...)
+func (c *leaderServerClient) GetMetadata(ctx context.Context, in *GetMetadataRequest, opts ...grpc.CallOption) (*GetMetadataReply, error) {
+	out := new(GetMetadataReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/GetMetadata", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +260,78 @@ func (c *leaderServerClient) ReleaseWriteLock(ctx context.Context, in *ReleaseLo
 	return out, nil
 }
 
+func (c *leaderServerClient) RenewLock(ctx context.Context, in *RenewLockRequest, opts ...grpc.CallOption) (*RenewLockReply, error) {
+	out := new(RenewLockReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/RenewLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) ListLocks(ctx context.Context, in *ListLocksRequest, opts ...grpc.CallOption) (*ListLocksReply, error) {
+	out := new(ListLocksReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/ListLocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) BlockReport(ctx context.Context, in *BlockReportRequest, opts ...grpc.CallOption) (*BlockReportReply, error) {
+	out := new(BlockReportReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/BlockReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) ReportBadBlock(ctx context.Context, in *ReportBadBlockRequest, opts ...grpc.CallOption) (*ReportBadBlockReply, error) {
+	out := new(ReportBadBlockReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/ReportBadBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) Balance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceReply, error) {
+	out := new(BalanceReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/Balance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) RequestVote(ctx context.Context, in *RequestVoteRequest, opts ...grpc.CallOption) (*RequestVoteReply, error) {
+	out := new(RequestVoteReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/RequestVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesReply, error) {
+	out := new(AppendEntriesReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/AppendEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderServerClient) InstallSnapshot(ctx context.Context, in *InstallSnapshotRequest, opts ...grpc.CallOption) (*InstallSnapshotReply, error) {
+	out := new(InstallSnapshotReply)
+	err := c.cc.Invoke(ctx, "/leaderserver.LeaderServer/InstallSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LeaderServerServer is the server API for LeaderServer service.
 // All implementations must embed UnimplementedLeaderServerServer
 // for forward compatibility
@@ -179,17 +339,33 @@ type LeaderServerServer interface {
 	GetLeader(context.Context, *GetLeaderRequest) (*GetLeaderReply, error)
 	GetBlockInfo(context.Context, *GetBlockInfoRequest) (*GetBlockInfoReply, error)
 	GetFileOK(context.Context, *GetFileOKRequest) (*GetFileOKReply, error)
+	GetVersions(context.Context, *GetVersionsRequest) (*GetVersionsReply, error)
 	PutBlockInfo(context.Context, *PutBlockInfoRequest) (*PutBlockInfoReply, error)
 	PutFileOK(context.Context, *PutFileOKRequest) (*PutFileOKReply, error)
+	SetReplication(context.Context, *SetReplicationRequest) (*SetReplicationReply, error)
 	DelFile(context.Context, *DelFileRequest) (*DelFileReply, error)
+	Mkdir(context.Context, *MkdirRequest) (*MkdirReply, error)
+	ListDir(context.Context, *ListDirRequest) (*ListDirReply, error)
+	Stat(context.Context, *StatRequest) (*StatReply, error)
+	Rename(context.Context, *RenameRequest) (*RenameReply, error)
 	AppendBlockInfo(context.Context, *AppendBlockInfoRequest) (*AppendBlockInfoReply, error)
 	AppendFileOK(context.Context, *AppendFileOKRequest) (*AppendFileOKReply, error)
+	AddBlock(context.Context, *AddBlockRequest) (*AddBlockReply, error)
+	AppendRecordInfo(context.Context, *AppendRecordInfoRequest) (*AppendRecordInfoReply, error)
+	AppendRecordOK(context.Context, *AppendRecordOKRequest) (*AppendRecordOKReply, error)
 	GetMetadata(context.Context, *GetMetadataRequest) (*GetMetadataReply, error)
-	SetLeader(context.Context, *SetLeaderRequest) (*SetLeaderReply, error)
 	AcquireReadLock(context.Context, *AcquireLockRequest) (*AcquireLockReply, error)
 	ReleaseReadLock(context.Context, *ReleaseLockRequest) (*ReleaseLockReply, error)
 	AcquireWriteLock(context.Context, *AcquireLockRequest) (*AcquireLockReply, error)
 	ReleaseWriteLock(context.Context, *ReleaseLockRequest) (*ReleaseLockReply, error)
+	RenewLock(context.Context, *RenewLockRequest) (*RenewLockReply, error)
+	ListLocks(context.Context, *ListLocksRequest) (*ListLocksReply, error)
+	BlockReport(context.Context, *BlockReportRequest) (*BlockReportReply, error)
+	ReportBadBlock(context.Context, *ReportBadBlockRequest) (*ReportBadBlockReply, error)
+	Balance(context.Context, *BalanceRequest) (*BalanceReply, error)
+	RequestVote(context.Context, *RequestVoteRequest) (*RequestVoteReply, error)
+	AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesReply, error)
+	InstallSnapshot(context.Context, *InstallSnapshotRequest) (*InstallSnapshotReply, error)
 	mustEmbedUnimplementedLeaderServerServer()
 }
 
@@ -206,27 +382,51 @@ func (UnimplementedLeaderServerServer) GetBlockInfo(context.Context, *GetBlockIn
 func (UnimplementedLeaderServerServer) GetFileOK(context.Context, *GetFileOKRequest) (*GetFileOKReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFileOK not implemented")
 }
+func (UnimplementedLeaderServerServer) GetVersions(context.Context, *GetVersionsRequest) (*GetVersionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersions not implemented")
+}
 func (UnimplementedLeaderServerServer) PutBlockInfo(context.Context, *PutBlockInfoRequest) (*PutBlockInfoReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutBlockInfo not implemented")
 }
 func (UnimplementedLeaderServerServer) PutFileOK(context.Context, *PutFileOKRequest) (*PutFileOKReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutFileOK not implemented")
 }
+func (UnimplementedLeaderServerServer) SetReplication(context.Context, *SetReplicationRequest) (*SetReplicationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReplication not implemented")
+}
 func (UnimplementedLeaderServerServer) DelFile(context.Context, *DelFileRequest) (*DelFileReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DelFile not implemented")
 }
+func (UnimplementedLeaderServerServer) Mkdir(context.Context, *MkdirRequest) (*MkdirReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Mkdir not implemented")
+}
+func (UnimplementedLeaderServerServer) ListDir(context.Context, *ListDirRequest) (*ListDirReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDir not implemented")
+}
+func (UnimplementedLeaderServerServer) Stat(context.Context, *StatRequest) (*StatReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
+}
+func (UnimplementedLeaderServerServer) Rename(context.Context, *RenameRequest) (*RenameReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rename not implemented")
+}
 func (UnimplementedLeaderServerServer) AppendBlockInfo(context.Context, *AppendBlockInfoRequest) (*AppendBlockInfoReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AppendBlockInfo not implemented")
 }
 func (UnimplementedLeaderServerServer) AppendFileOK(context.Context, *AppendFileOKRequest) (*AppendFileOKReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AppendFileOK not implemented")
 }
+func (UnimplementedLeaderServerServer) AddBlock(context.Context, *AddBlockRequest) (*AddBlockReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBlock not implemented")
+}
+func (UnimplementedLeaderServerServer) AppendRecordInfo(context.Context, *AppendRecordInfoRequest) (*AppendRecordInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendRecordInfo not implemented")
+}
+func (UnimplementedLeaderServerServer) AppendRecordOK(context.Context, *AppendRecordOKRequest) (*AppendRecordOKReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendRecordOK not implemented")
+}
 func (UnimplementedLeaderServerServer) GetMetadata(context.Context, *GetMetadataRequest) (*GetMetadataReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetadata not implemented")
 }
-func (UnimplementedLeaderServerServer) SetLeader(context.Context, *SetLeaderRequest) (*SetLeaderReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetLeader not implemented")
-}
 func (UnimplementedLeaderServerServer) AcquireReadLock(context.Context, *AcquireLockRequest) (*AcquireLockReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AcquireReadLock not implemented")
 }
@@ -239,6 +439,30 @@ func (UnimplementedLeaderServerServer) AcquireWriteLock(context.Context, *Acquir
 func (UnimplementedLeaderServerServer) ReleaseWriteLock(context.Context, *ReleaseLockRequest) (*ReleaseLockReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReleaseWriteLock not implemented")
 }
+func (UnimplementedLeaderServerServer) RenewLock(context.Context, *RenewLockRequest) (*RenewLockReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewLock not implemented")
+}
+func (UnimplementedLeaderServerServer) ListLocks(context.Context, *ListLocksRequest) (*ListLocksReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLocks not implemented")
+}
+func (UnimplementedLeaderServerServer) BlockReport(context.Context, *BlockReportRequest) (*BlockReportReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockReport not implemented")
+}
+func (UnimplementedLeaderServerServer) ReportBadBlock(context.Context, *ReportBadBlockRequest) (*ReportBadBlockReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportBadBlock not implemented")
+}
+func (UnimplementedLeaderServerServer) Balance(context.Context, *BalanceRequest) (*BalanceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Balance not implemented")
+}
+func (UnimplementedLeaderServerServer) RequestVote(context.Context, *RequestVoteRequest) (*RequestVoteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (UnimplementedLeaderServerServer) AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendEntries not implemented")
+}
+func (UnimplementedLeaderServerServer) InstallSnapshot(context.Context, *InstallSnapshotRequest) (*InstallSnapshotReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstallSnapshot not implemented")
+}
 func (UnimplementedLeaderServerServer) mustEmbedUnimplementedLeaderServerServer() {}
 
 // UnsafeLeaderServerServer may be embedded to opt out of forward compatibility for this service.
@@ -306,6 +530,24 @@ func _LeaderServer_GetFileOK_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LeaderServer_GetVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).GetVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/GetVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).GetVersions(ctx, req.(*GetVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LeaderServer_PutBlockInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PutBlockInfoRequest)
 	if err := dec(in); err != nil {
@@ -342,6 +584,24 @@ func _LeaderServer_PutFileOK_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LeaderServer_SetReplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).SetReplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/SetReplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).SetReplication(ctx, req.(*SetReplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LeaderServer_DelFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DelFileRequest)
 	if err := dec(in); err != nil {
@@ -360,6 +620,78 @@ func _LeaderServer_DelFile_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LeaderServer_Mkdir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MkdirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).Mkdir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/Mkdir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).Mkdir(ctx, req.(*MkdirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_ListDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).ListDir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/ListDir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).ListDir(ctx, req.(*ListDirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).Stat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/Stat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).Stat(ctx, req.(*StatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_Rename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).Rename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/Rename",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).Rename(ctx, req.(*RenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LeaderServer_AppendBlockInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AppendBlockInfoRequest)
 	if err := dec(in); err != nil {
@@ -396,38 +728,74 @@ func _LeaderServer_AppendFileOK_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LeaderServer_GetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetMetadataRequest)
+func _LeaderServer_AddBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBlockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LeaderServerServer).GetMetadata(ctx, in)
+		return srv.(LeaderServerServer).AddBlock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/leaderserver.LeaderServer/GetMetadata",
+		FullMethod: "/leaderserver.LeaderServer/AddBlock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LeaderServerServer).GetMetadata(ctx, req.(*GetMetadataRequest))
+		return srv.(LeaderServerServer).AddBlock(ctx, req.(*AddBlockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LeaderServer_SetLeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetLeaderRequest)
+func _LeaderServer_AppendRecordInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendRecordInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LeaderServerServer).SetLeader(ctx, in)
+		return srv.(LeaderServerServer).AppendRecordInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/leaderserver.LeaderServer/SetLeader",
+		FullMethod: "/leaderserver.LeaderServer/AppendRecordInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LeaderServerServer).SetLeader(ctx, req.(*SetLeaderRequest))
+		return srv.(LeaderServerServer).AppendRecordInfo(ctx, req.(*AppendRecordInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_AppendRecordOK_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendRecordOKRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).AppendRecordOK(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/AppendRecordOK",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).AppendRecordOK(ctx, req.(*AppendRecordOKRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_GetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).GetMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/GetMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).GetMetadata(ctx, req.(*GetMetadataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -504,6 +872,150 @@ func _LeaderServer_ReleaseWriteLock_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LeaderServer_RenewLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).RenewLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/RenewLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).RenewLock(ctx, req.(*RenewLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_ListLocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).ListLocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/ListLocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).ListLocks(ctx, req.(*ListLocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_BlockReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).BlockReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/BlockReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).BlockReport(ctx, req.(*BlockReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_ReportBadBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportBadBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).ReportBadBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/ReportBadBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).ReportBadBlock(ctx, req.(*ReportBadBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_Balance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).Balance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/Balance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).Balance(ctx, req.(*BalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/RequestVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).RequestVote(ctx, req.(*RequestVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_AppendEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).AppendEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/AppendEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).AppendEntries(ctx, req.(*AppendEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderServer_InstallSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderServerServer).InstallSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/leaderserver.LeaderServer/InstallSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderServerServer).InstallSnapshot(ctx, req.(*InstallSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LeaderServer_ServiceDesc is the grpc.ServiceDesc for LeaderServer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -523,6 +1035,10 @@ var LeaderServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFileOK",
 			Handler:    _LeaderServer_GetFileOK_Handler,
 		},
+		{
+			MethodName: "GetVersions",
+			Handler:    _LeaderServer_GetVersions_Handler,
+		},
 		{
 			MethodName: "PutBlockInfo",
 			Handler:    _LeaderServer_PutBlockInfo_Handler,
@@ -531,10 +1047,30 @@ var LeaderServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PutFileOK",
 			Handler:    _LeaderServer_PutFileOK_Handler,
 		},
+		{
+			MethodName: "SetReplication",
+			Handler:    _LeaderServer_SetReplication_Handler,
+		},
 		{
 			MethodName: "DelFile",
 			Handler:    _LeaderServer_DelFile_Handler,
 		},
+		{
+			MethodName: "Mkdir",
+			Handler:    _LeaderServer_Mkdir_Handler,
+		},
+		{
+			MethodName: "ListDir",
+			Handler:    _LeaderServer_ListDir_Handler,
+		},
+		{
+			MethodName: "Stat",
+			Handler:    _LeaderServer_Stat_Handler,
+		},
+		{
+			MethodName: "Rename",
+			Handler:    _LeaderServer_Rename_Handler,
+		},
 		{
 			MethodName: "AppendBlockInfo",
 			Handler:    _LeaderServer_AppendBlockInfo_Handler,
@@ -544,12 +1080,20 @@ var LeaderServer_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _LeaderServer_AppendFileOK_Handler,
 		},
 		{
-			MethodName: "GetMetadata",
-			Handler:    _LeaderServer_GetMetadata_Handler,
+			MethodName: "AddBlock",
+			Handler:    _LeaderServer_AddBlock_Handler,
+		},
+		{
+			MethodName: "AppendRecordInfo",
+			Handler:    _LeaderServer_AppendRecordInfo_Handler,
 		},
 		{
-			MethodName: "SetLeader",
-			Handler:    _LeaderServer_SetLeader_Handler,
+			MethodName: "AppendRecordOK",
+			Handler:    _LeaderServer_AppendRecordOK_Handler,
+		},
+		{
+			MethodName: "GetMetadata",
+			Handler:    _LeaderServer_GetMetadata_Handler,
 		},
 		{
 			MethodName: "AcquireReadLock",
@@ -567,6 +1111,38 @@ var LeaderServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseWriteLock",
 			Handler:    _LeaderServer_ReleaseWriteLock_Handler,
 		},
+		{
+			MethodName: "RenewLock",
+			Handler:    _LeaderServer_RenewLock_Handler,
+		},
+		{
+			MethodName: "ListLocks",
+			Handler:    _LeaderServer_ListLocks_Handler,
+		},
+		{
+			MethodName: "BlockReport",
+			Handler:    _LeaderServer_BlockReport_Handler,
+		},
+		{
+			MethodName: "ReportBadBlock",
+			Handler:    _LeaderServer_ReportBadBlock_Handler,
+		},
+		{
+			MethodName: "Balance",
+			Handler:    _LeaderServer_Balance_Handler,
+		},
+		{
+			MethodName: "RequestVote",
+			Handler:    _LeaderServer_RequestVote_Handler,
+		},
+		{
+			MethodName: "AppendEntries",
+			Handler:    _LeaderServer_AppendEntries_Handler,
+		},
+		{
+			MethodName: "InstallSnapshot",
+			Handler:    _LeaderServer_InstallSnapshot_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "leaderserver.proto",
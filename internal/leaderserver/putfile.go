@@ -2,84 +2,180 @@ package leaderserver
 
 import (
 	"context"
-	"math/rand"
+	"fmt"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/compress"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
-	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/memberserver/heartbeat"
 )
 
 // PutBlockInfo handles the request to choose the block to put the file
 func (l *LeaderServer) PutBlockInfo(ctx context.Context, in *pb.PutBlockInfoRequest) (*pb.PutBlockInfoReply, error) {
-	blockInfo, err := l.putBlockInfo(in.FileName, in.FileSize)
+	if err := l.metadata.CheckFilePath(in.FileName); err != nil {
+		return nil, err
+	}
+	if l.records.hasPending(metadata.CleanPath(in.FileName)) {
+		return nil, fmt.Errorf("file %s is being appended with records", in.FileName)
+	}
+	policy, err := l.getPolicy(in.FileName, in.GetPolicy())
 	if err != nil {
 		return nil, err
 	}
-	putBlockInfoReplyBlockMeta := map[int64]*pb.BlockMeta{}
-	for blockID, blockMeta := range blockInfo {
-		putBlockInfoReplyBlockMeta[blockID] = &pb.BlockMeta{
-			HostNames: blockMeta.HostNames,
-			FileName:  blockMeta.FileName,
-			BlockID:   blockMeta.BlockID,
-			BlockSize: blockMeta.BlockSize,
-		}
+	compression, err := l.getCompression(in.FileName, in.GetCompression(), policy)
+	if err != nil {
+		return nil, err
+	}
+	generation, err := l.metadata.NewGeneration()
+	if err != nil {
+		return nil, err
+	}
+	var blockInfo, parity metadata.BlockInfo
+	if policy.IsErasureCoded() {
+		blockInfo, parity, err = l.putStripes(in.FileName, in.FileSize, generation, policy)
+	} else {
+		blockInfo, err = l.putBlockInfo(in.FileName, in.FileSize, generation, l.getReplication(in.FileName, int(in.GetReplication())))
+	}
+	if err != nil {
+		return nil, err
 	}
 	return &pb.PutBlockInfoReply{
-		BlockInfo: putBlockInfoReplyBlockMeta,
+		BlockInfo:   toProtoBlockInfo(blockInfo),
+		Parity:      toProtoBlockInfo(parity),
+		Policy:      policy.String(),
+		Compression: compression.String(),
 	}, nil
 }
 
-// putBlockInfo select the block to put the file
-func (l *LeaderServer) putBlockInfo(fileName string, fileSize int64) (metadata.BlockInfo, error) {
+// putBlockInfo select the block to put a new version of the file, the blocks of the previous versions are kept
+func (l *LeaderServer) putBlockInfo(fileName string, fileSize int64, generation int64, replication int) (metadata.BlockInfo, error) {
 	blocksNum := fileSize / l.blockSize
 	if fileSize%l.blockSize != 0 {
 		blocksNum++
 	}
+	storageName := l.metadata.NewStorageName(fileName)
 	blockInfo := map[int64]metadata.BlockMeta{}
 	for i := int64(0); i < blocksNum; i++ {
+		hostNames, err := l.selectBlockHosts(replication)
+		if err != nil {
+			return nil, err
+		}
 		blockInfo[i] = metadata.BlockMeta{
-			HostNames: l.selectBlockHosts(),
-			FileName:  fileName,
-			BlockID:   i,
-			BlockSize: 0, // should be updated by client after put
+			HostNames:  hostNames,
+			FileName:   storageName,
+			BlockID:    i,
+			BlockSize:  0, // should be updated by client after put
+			Generation: generation,
 		}
 	}
 	return blockInfo, nil
 }
 
-// selectBlockHosts randomly select replicationFactor hosts
-func (l *LeaderServer) selectBlockHosts() []string {
-	heartbeat, err := heartbeat.GetInstance()
+// AddBlock handles the request to choose the hosts of one more block of a version being written as a stream, the size of
+// which is unknown to PutBlockInfo and AppendBlockInfo. The block is committed with the others by PutFileOK or AppendFileOK.
+func (l *LeaderServer) AddBlock(ctx context.Context, in *pb.AddBlockRequest) (*pb.AddBlockReply, error) {
+	if err := l.metadata.CheckFilePath(in.FileName); err != nil {
+		return nil, err
+	}
+	if in.GetStorageName() == "" || in.GetBlockID() < 0 {
+		return nil, fmt.Errorf("invalid block %d of storage %q to add to file %s", in.GetBlockID(), in.GetStorageName(), in.FileName)
+	}
+	hostNames, err := l.selectBlockHosts(l.getReplication(in.FileName, int(in.GetReplication())))
 	if err != nil {
-		panic(err)
-	}
-	// Assuming that the alive members are more than replicationFactor
-	membership := heartbeat.GetMembership()
-	if membership == nil {
-		logrus.Errorf("failed to get membership instance")
-		return []string{}
-	}
-	members := membership.GetAliveMembers()
-	hostnames := []string{}
-	for _, member := range members {
-		hostnames = append(hostnames, member.GetName())
-	}
-	// randomly select replicationFactor hosts
-	rand.Shuffle(len(hostnames), func(i, j int) { hostnames[i], hostnames[j] = hostnames[j], hostnames[i] })
-	return hostnames[:l.replicationFactor]
+		return nil, err
+	}
+	return &pb.AddBlockReply{
+		BlockMeta: &pb.BlockMeta{
+			HostNames:  hostNames,
+			FileName:   in.GetStorageName(),
+			BlockID:    in.GetBlockID(),
+			Generation: in.GetGeneration(),
+		},
+	}, nil
+}
+
+// selectBlockHosts selects the hosts of the replicas of a new block by the placement policy. If fewer hosts than
+// replication are available, the block is placed on them and recoverReplica adds the missing replicas later.
+func (l *LeaderServer) selectBlockHosts(replication int) ([]string, error) {
+	nodes, err := l.aliveNodes()
+	if err != nil {
+		return nil, err
+	}
+	hostnames := l.placeReplicas(nodes, replication, nil)
+	if len(hostnames) == 0 {
+		return nil, fmt.Errorf("no data server available to store the block")
+	}
+	if len(hostnames) < replication {
+		logrus.Warnf("Only %d data servers available for %d replicas", len(hostnames), replication)
+	}
+	return hostnames, nil
 }
 
 func (l *LeaderServer) PutFileOK(ctx context.Context, in *pb.PutFileOKRequest) (*pb.PutFileOKReply, error) {
-	blockInfo := metadata.BlockInfo{}
-	for blockID, blockMeta := range in.BlockInfo {
-		blockInfo[blockID] = metadata.BlockMeta{
-			HostNames: blockMeta.HostNames,
-			FileName:  blockMeta.FileName,
-			BlockID:   blockMeta.BlockID,
-			BlockSize: blockMeta.BlockSize,
-		}
+	if err := l.metadata.CheckFilePath(in.FileName); err != nil {
+		return nil, err
+	}
+	policy, err := erasure.ParsePolicy(in.GetPolicy())
+	if err != nil {
+		return nil, err
+	}
+	compression, err := compress.Parse(in.GetCompression())
+	if err != nil {
+		return nil, err
+	}
+	version := metadata.FileVersion{
+		BlockInfo:   fromProtoBlockInfo(in.GetBlockInfo()),
+		Parity:      fromProtoBlockInfo(in.GetParity()),
+		Policy:      policy.String(),
+		Compression: compression.String(),
+	}
+	if !policy.IsErasureCoded() {
+		version.Parity = nil
+	}
+	// the replicas of the versions beyond maxVersions are removed from the data servers by collectGarbage
+	if err := l.metadata.PutVersion(in.FileName, version, l.getMaxVersions(in.FileName, int(in.GetMaxVersions())), int(in.GetReplication()), auth.Caller(ctx)); err != nil {
+		return nil, err
 	}
-	l.metadata.AddOrUpdateBlockInfo(in.FileName, blockInfo)
 	return &pb.PutFileOKReply{}, nil
 }
+
+// getMaxVersions returns the number of versions kept of a file, the one in the request, the one set for the file,
+// or the default in config.
+func (l *LeaderServer) getMaxVersions(fileName string, requested int) int {
+	if requested > 0 {
+		return requested
+	}
+	if fileInfo, err := l.metadata.GetFile(fileName); err == nil && fileInfo.MaxVersions > 0 {
+		return fileInfo.MaxVersions
+	}
+	if l.maxVersions > 0 {
+		return l.maxVersions
+	}
+	return 1
+}
+
+// getReplication returns the number of replicas of each block of a file, the one in the request, the one set for the
+// file, or the default in config.
+func (l *LeaderServer) getReplication(fileName string, requested int) int {
+	if requested > 0 {
+		return requested
+	}
+	if fileInfo, err := l.metadata.GetFile(fileName); err == nil && fileInfo.Replication > 0 {
+		return fileInfo.Replication
+	}
+	return l.replicationFactor
+}
+
+// replicationOf returns the number of replicas of each block of a version of a file, the default in config unless set
+// for the file. Each block of an erasure-coded version has one replica.
+func (l *LeaderServer) replicationOf(fileInfo metadata.FileInfo, version metadata.FileVersion) int {
+	if l.policyOf(version).IsErasureCoded() {
+		return 1
+	}
+	if fileInfo.Replication > 0 {
+		return fileInfo.Replication
+	}
+	return l.replicationFactor
+}
@@ -0,0 +1,358 @@
+package leaderserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// maxAppendEntries is the max number of entries sent in an AppendEntries.
+const maxAppendEntries = 64
+
+// proposeTimeout is the max time to wait for a proposed entry to be committed.
+const proposeTimeout = time.Second * 10
+
+type raftRole int
+
+const (
+	roleFollower raftRole = iota
+	roleCandidate
+	roleLeader
+)
+
+// Raft replicates the metadata log among the leader servers of all machines.
+type Raft struct {
+	hostname string
+	port     string
+	dir      string
+	peers    []string
+	metadata *metadata.Metadata
+	log      *metadata.Log
+
+	// persisted in dir before answering any RPC
+	term     uint64
+	votedFor string
+
+	role            raftRole
+	leader          string
+	commitIndex     uint64
+	lastContact     time.Time // last time heard from the leader or granted a vote
+	electionTimeout time.Duration
+	nextIndex       map[string]uint64    // next entry to send to each peer
+	matchIndex      map[string]uint64    // last entry replicated on each peer
+	lastAck         map[string]time.Time // last time each peer answered the leader
+	replicating     map[string]bool      // peers with an ongoing AppendEntries
+	waiters         map[uint64]waiter    // proposed entries waiting to be applied
+	onBecomeLeader  func()               // called once the entries of previous terms are applied
+
+	baseElectionTimeout time.Duration
+	heartbeatInterval   time.Duration
+	raftTicker          *time.Ticker
+	raftTickerDone      chan bool
+
+	mu sync.Mutex
+}
+
+type waiter struct {
+	term uint64
+	done chan error
+}
+
+// NewRaft creates the raft of the leader server, the log and state are persisted with the metadata.
+func NewRaft(config *config.Config, hostname string, m *metadata.Metadata) (*Raft, error) {
+	state, err := metadata.ReadRaftState(config.Metadata.Dir)
+	if err != nil {
+		return nil, err
+	}
+	appliedIndex, appliedTerm := m.Applied()
+	log, err := metadata.OpenLog(config.Metadata.Dir, appliedIndex, appliedTerm)
+	if err != nil {
+		return nil, err
+	}
+	peers := []string{}
+	for _, machine := range config.Machines {
+		if machine.Hostname != hostname {
+			peers = append(peers, machine.Hostname)
+		}
+	}
+	r := &Raft{
+		hostname:            hostname,
+		port:                config.LeaderServerPort,
+		dir:                 config.Metadata.Dir,
+		peers:               peers,
+		metadata:            m,
+		log:                 log,
+		term:                state.Term,
+		votedFor:            state.VotedFor,
+		role:                roleFollower,
+		commitIndex:         appliedIndex,
+		lastContact:         time.Now(),
+		nextIndex:           map[string]uint64{},
+		matchIndex:          map[string]uint64{},
+		lastAck:             map[string]time.Time{},
+		replicating:         map[string]bool{},
+		waiters:             map[uint64]waiter{},
+		baseElectionTimeout: config.Raft.ElectionTimeout,
+		heartbeatInterval:   config.Raft.HeartbeatInterval,
+		mu:                  sync.Mutex{},
+	}
+	r.resetElectionTimer()
+	m.SetProposer(r.propose)
+	return r, nil
+}
+
+func (r *Raft) start() {
+	logrus.Infof("Start raft at term %d with peers %v", r.term, r.peers)
+	r.raftTicker = time.NewTicker(r.heartbeatInterval)
+	defer r.raftTicker.Stop()
+	for {
+		select {
+		case <-r.raftTickerDone:
+			return
+		case <-r.raftTicker.C:
+			r.tick()
+		}
+	}
+}
+
+func (r *Raft) stop() {
+	r.raftTickerDone <- true
+}
+
+// tick sends heartbeats as the leader, or starts an election if the leader is not heard for the election timeout.
+func (r *Raft) tick() {
+	r.mu.Lock()
+	if r.role == roleLeader {
+		// a leader partitioned from the majority must not keep acting as the leader
+		if !r.hasQuorum() {
+			logrus.Warnf("Lost contact with the majority, stepping down from the leader of term %d", r.term)
+			r.stepDown(r.term)
+			r.mu.Unlock()
+			return
+		}
+		r.mu.Unlock()
+		r.broadcast()
+		return
+	}
+	timeout := time.Since(r.lastContact) > r.electionTimeout
+	r.mu.Unlock()
+	if timeout {
+		r.startElection()
+	}
+}
+
+// getLeader returns the leader known by this server.
+func (r *Raft) getLeader() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.leader
+}
+
+// getEpoch returns the current term, the epoch of the leader known by this server.
+func (r *Raft) getEpoch() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.term
+}
+
+// propose appends the entry to the log and waits until it is committed and applied.
+func (r *Raft) propose(entry metadata.Entry) error {
+	r.mu.Lock()
+	if r.role != roleLeader {
+		r.mu.Unlock()
+		return fmt.Errorf("%s is not the leader", r.hostname)
+	}
+	entry.Index = r.log.LastIndex() + 1
+	entry.Term = r.term
+	if err := r.log.Append(entry); err != nil {
+		r.mu.Unlock()
+		return err
+	}
+	done := make(chan error, 1)
+	r.waiters[entry.Index] = waiter{term: entry.Term, done: done}
+	r.advanceCommitIndex()
+	r.mu.Unlock()
+	r.broadcast()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(proposeTimeout):
+		r.mu.Lock()
+		delete(r.waiters, entry.Index)
+		r.mu.Unlock()
+		return fmt.Errorf("timed out waiting for entry %d to be committed", entry.Index)
+	}
+}
+
+// snapshot writes the applied metadata to the snapshot and compacts the log.
+func (r *Raft) snapshot() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	index, err := r.metadata.Snapshot()
+	if err != nil {
+		return err
+	}
+	return r.log.Compact(index)
+}
+
+// becomeLeader is called with the lock held after winning an election.
+func (r *Raft) becomeLeader() {
+	logrus.Infof("Became the leader of term %d", r.term)
+	r.role = roleLeader
+	r.setLeader(r.hostname)
+	lastIndex := r.log.LastIndex()
+	for _, peer := range r.peers {
+		r.nextIndex[peer] = lastIndex + 1
+		r.matchIndex[peer] = 0
+		r.lastAck[peer] = time.Now()
+	}
+	// entries of previous terms are committed by an entry of the current term
+	err := r.log.Append(metadata.Entry{
+		Index: lastIndex + 1,
+		Term:  r.term,
+		Op:    metadata.OpNoop,
+	})
+	if err != nil {
+		logrus.Errorf("failed to append noop entry: %v", err)
+		r.stepDown(r.term)
+		return
+	}
+	r.advanceCommitIndex()
+	go r.broadcast()
+}
+
+// stepDown is called with the lock held when a higher term is seen or the leadership is lost.
+func (r *Raft) stepDown(term uint64) {
+	if term > r.term {
+		r.term = term
+		r.votedFor = ""
+		if err := r.persist(); err != nil {
+			logrus.Errorf("failed to persist raft state: %v", err)
+		}
+		r.setLeader("")
+	}
+	if r.role == roleLeader {
+		r.setLeader("")
+		for index, w := range r.waiters {
+			w.done <- fmt.Errorf("%s is no longer the leader", r.hostname)
+			delete(r.waiters, index)
+		}
+	}
+	r.role = roleFollower
+}
+
+func (r *Raft) setLeader(leader string) {
+	if leader != r.leader {
+		logrus.Infof("leader changed from %s to %s", r.leader, leader)
+	}
+	r.leader = leader
+}
+
+// advanceCommitIndex commits the entries of the current term replicated on the majority.
+func (r *Raft) advanceCommitIndex() {
+	matchIndex := []uint64{}
+	for _, peer := range r.peers {
+		matchIndex = append(matchIndex, r.matchIndex[peer])
+	}
+	r.commitIndex = r.log.CommitIndex(r.commitIndex, r.term, matchIndex)
+	r.applyCommitted()
+}
+
+// applyCommitted applies the committed entries to the metadata and notifies the proposers.
+func (r *Raft) applyCommitted() {
+	appliedIndex, _ := r.metadata.Applied()
+	for index := appliedIndex + 1; index <= r.commitIndex; index++ {
+		entry, err := r.log.Entry(index)
+		if err != nil {
+			logrus.Errorf("failed to apply entry %d: %v", index, err)
+			return
+		}
+		r.metadata.Apply(entry)
+		if w, ok := r.waiters[index]; ok {
+			if w.term == entry.Term {
+				w.done <- nil
+			} else {
+				w.done <- fmt.Errorf("entry %d is overwritten by another leader", index)
+			}
+			delete(r.waiters, index)
+		}
+		if entry.Op == metadata.OpNoop && entry.Term == r.term && r.role == roleLeader && r.onBecomeLeader != nil {
+			go r.onBecomeLeader()
+		}
+	}
+}
+
+func (r *Raft) majority() int {
+	return (len(r.peers)+1)/2 + 1
+}
+
+// hasQuorum returns whether the majority answered the leader within the election timeout.
+func (r *Raft) hasQuorum() bool {
+	count := 1
+	for _, peer := range r.peers {
+		if time.Since(r.lastAck[peer]) < r.baseElectionTimeout {
+			count++
+		}
+	}
+	return count >= r.majority()
+}
+
+func (r *Raft) resetElectionTimer() {
+	r.lastContact = time.Now()
+	r.electionTimeout = r.baseElectionTimeout + time.Duration(rand.Int63n(int64(r.baseElectionTimeout)+1))
+}
+
+func (r *Raft) persist() error {
+	return metadata.WriteRaftState(r.dir, &metadata.RaftState{
+		Term:     r.term,
+		VotedFor: r.votedFor,
+	})
+}
+
+// conn returns the shared connection to the leader server of a peer, the caller must close it.
+func (r *Raft) conn(peer string) (*connpool.Conn, error) {
+	conn, err := connpool.Get(peer + ":" + r.port)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to %s leaderServer: %v", peer, err)
+	}
+	return conn, nil
+}
+
+func encodeLogEntries(entries []metadata.Entry) ([]*pb.LogEntry, error) {
+	pbEntries := []*pb.LogEntry{}
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode entry %d: %v", entry.Index, err)
+		}
+		pbEntries = append(pbEntries, &pb.LogEntry{
+			Index: entry.Index,
+			Term:  entry.Term,
+			Data:  data,
+		})
+	}
+	return pbEntries, nil
+}
+
+func decodeLogEntries(pbEntries []*pb.LogEntry) ([]metadata.Entry, error) {
+	entries := []metadata.Entry{}
+	for _, pbEntry := range pbEntries {
+		entry := metadata.Entry{}
+		if err := json.Unmarshal(pbEntry.GetData(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode entry %d: %v", pbEntry.GetIndex(), err)
+		}
+		entry.Index = pbEntry.GetIndex()
+		entry.Term = pbEntry.GetTerm()
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
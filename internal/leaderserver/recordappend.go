@@ -0,0 +1,446 @@
+package leaderserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/compress"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// recordTimeout is how long a reserved record may wait to be committed, and how long an idle file is kept open for records.
+const recordTimeout = time.Second * 30
+
+// Records assigns the offsets of the records appended to files without the write lock. The records of a file are written
+// in place to the blocks opened for them and committed in the order of their offsets, so that a committed record is
+// never behind a hole. The open files are not replicated, a new leader opens them again from metadata.
+// The lock of Records only guards the map of the open files. Each file has its own lock, which is never held while an
+// entry is committed, so a record is reserved while the record before it is committed.
+type Records struct {
+	files map[string]*recordFile
+	mu    sync.Mutex
+}
+
+// recordFile is a file open for records.
+type recordFile struct {
+	name        string
+	opened      bool // whether the file is opened from metadata
+	storageName string
+	base        metadata.BlockInfo // blocks of the file when it is opened
+	blocks      map[int64]*recordBlock
+	reserved    int64                   // end of the reserved records
+	committed   int64                   // end of the committed records
+	pending     map[int64]pendingRecord // reserved records by their offsets
+	lastActive  time.Time
+	committing  bool          // whether the record at committed is being committed
+	closed      bool          // no record is reserved or committed, dropped from Records once nothing is committing
+	changed     chan struct{} // closed when a record is committed or the file is closed
+	mu          sync.Mutex
+}
+
+// recordBlock is a block open for records, written in place with a new generation.
+type recordBlock struct {
+	meta      metadata.BlockMeta
+	base      *metadata.BlockMeta // the committed block the new generation is created from, nil for a new block
+	committed bool                // whether a record is committed to the new generation
+	ready     chan struct{}       // closed once the generation and the replicas of the block are assigned
+	err       error               // why the block cannot be opened, set before ready is closed
+}
+
+type pendingRecord struct {
+	size       int64
+	reservedAt time.Time
+}
+
+// NewRecords returns a new Records
+func NewRecords() *Records {
+	return &Records{
+		files: map[string]*recordFile{},
+		mu:    sync.Mutex{},
+	}
+}
+
+// AppendRecordInfo reserves the offset of a record at the end of a file and returns the blocks to write it to.
+func (l *LeaderServer) AppendRecordInfo(ctx context.Context, in *pb.AppendRecordInfoRequest) (*pb.AppendRecordInfoReply, error) {
+	if err := l.metadata.CheckFilePath(in.FileName); err != nil {
+		return nil, err
+	}
+	fileName := metadata.CleanPath(in.FileName)
+	size := in.GetSize()
+	if size <= 0 || size > l.blockSize {
+		return nil, fmt.Errorf("record size %d is not between 1 and the block size %d", size, l.blockSize)
+	}
+	if l.isWriteLocked(fileName) {
+		return nil, fmt.Errorf("file %s is being written", fileName)
+	}
+
+	file, err := l.openRecordFile(ctx, fileName)
+	if err != nil {
+		return nil, err
+	}
+	offset := file.reserved
+	end := offset + size
+	blocks, opening := []*recordBlock{}, map[int64]*recordBlock{}
+	for blockID := offset / l.blockSize; blockID <= (end-1)/l.blockSize; blockID++ {
+		block, ok := file.blocks[blockID]
+		if !ok {
+			block = &recordBlock{ready: make(chan struct{})}
+			file.blocks[blockID] = block
+			opening[blockID] = block
+		}
+		blocks = append(blocks, block)
+	}
+	file.reserved = end
+	file.pending[offset] = pendingRecord{size: size, reservedAt: time.Now()}
+	file.lastActive = time.Now()
+	file.mu.Unlock()
+
+	// the new generations are committed without the lock, the blocks are ready for the records after this one when done
+	for blockID, block := range opening {
+		block.err = l.openRecordBlock(fileName, file, blockID, block)
+		close(block.ready)
+	}
+	for _, block := range blocks {
+		select {
+		case <-block.ready:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if err == nil {
+			err = block.err
+		}
+		if err != nil {
+			file.mu.Lock()
+			file.close("failed to open the blocks of the record at offset %d: %v", offset, err)
+			file.mu.Unlock()
+			return nil, err
+		}
+	}
+
+	file.mu.Lock()
+	defer file.mu.Unlock()
+	reply := &pb.AppendRecordInfoReply{Offset: offset}
+	for _, block := range blocks {
+		// the piece of the record in the block
+		start, pieceStart, pieceEnd := block.meta.BlockID*l.blockSize, offset, end
+		if pieceStart < start {
+			pieceStart = start
+		}
+		if pieceEnd > start+l.blockSize {
+			pieceEnd = start + l.blockSize
+		}
+		recordBlock := &pb.RecordBlock{
+			BlockMeta: &pb.BlockMeta{
+				HostNames:  block.meta.HostNames,
+				FileName:   block.meta.FileName,
+				BlockID:    block.meta.BlockID,
+				Generation: block.meta.Generation,
+			},
+			Offset: pieceStart - start,
+			Size:   pieceEnd - pieceStart,
+		}
+		if block.base != nil {
+			recordBlock.BaseGeneration = block.base.Generation
+			recordBlock.BaseSize = block.base.BlockSize
+		}
+		reply.Blocks = append(reply.Blocks, recordBlock)
+	}
+	return reply, nil
+}
+
+// openRecordFile returns the file open for records with its lock held, or opens it at the end of its current version.
+// An idle file is opened again if it is changed by others. A closed file is opened again once its record being
+// committed is committed, so that it is never opened from stale metadata.
+func (l *LeaderServer) openRecordFile(ctx context.Context, fileName string) (*recordFile, error) {
+	r := l.records
+	for {
+		r.mu.Lock()
+		file, ok := r.files[fileName]
+		if !ok {
+			file = &recordFile{name: fileName, changed: make(chan struct{})}
+			r.files[fileName] = file
+		}
+		file.mu.Lock()
+		if file.closed && !file.committing {
+			delete(r.files, fileName)
+			file.mu.Unlock()
+			r.mu.Unlock()
+			continue
+		}
+		r.mu.Unlock()
+		if file.closed {
+			changed := file.changed
+			file.mu.Unlock()
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return nil, fmt.Errorf("failed to open file %s for records: %v", fileName, ctx.Err())
+			}
+			continue
+		}
+		if len(file.pending) > 0 {
+			if head := file.pending[file.committed]; file.committing || time.Since(head.reservedAt) <= recordTimeout {
+				return file, nil
+			}
+			file.close("record at offset %d is not committed in time", file.committed)
+			file.mu.Unlock()
+			continue
+		}
+		if err := l.openRecordFileFromMetadata(fileName, file); err != nil {
+			file.close("%v", err)
+			file.mu.Unlock()
+			return nil, err
+		}
+		return file, nil
+	}
+}
+
+// openRecordFileFromMetadata opens a file without reserved records at the end of its current version, unless it is
+// recently appended and not changed by others. The caller must hold the lock of the file.
+func (l *LeaderServer) openRecordFileFromMetadata(fileName string, file *recordFile) error {
+	// the file is read under the lock of the file while no record is being committed, so that the records committed
+	// before are in it
+	fileInfo, err := l.metadata.GetFile(fileName)
+	exists := err == nil
+	if exists && l.policyOf(fileInfo.Current()).IsErasureCoded() {
+		return fmt.Errorf("cannot append to erasure-coded file %s, set its policy to %s first", fileName, erasure.Replication)
+	}
+	if exists && fileInfo.Compression != "" {
+		// the data servers append the records in place by their offsets in the data of the blocks
+		return fmt.Errorf("cannot append records to compressed file %s, set its compression to %s first", fileName, string(compress.None))
+	}
+	storageName, size := "", int64(0)
+	for _, blockMeta := range fileInfo.BlockInfo {
+		storageName = blockMeta.FileName
+		size += blockMeta.BlockSize
+	}
+	if file.opened && time.Since(file.lastActive) <= recordTimeout && exists && file.storageName == storageName && file.committed == size {
+		return nil
+	}
+	if storageName == "" {
+		storageName = l.metadata.NewStorageName(fileName)
+	}
+	file.opened = true
+	file.storageName = storageName
+	file.base = fileInfo.BlockInfo
+	file.blocks = map[int64]*recordBlock{}
+	file.reserved = size
+	file.committed = size
+	file.pending = map[int64]pendingRecord{}
+	file.lastActive = time.Now()
+	return nil
+}
+
+// AppendRecordOK commits a record after the records before it, or closes the file if the record failed to be written so
+// that the next record is written to new generations of the blocks.
+func (l *LeaderServer) AppendRecordOK(ctx context.Context, in *pb.AppendRecordOKRequest) (*pb.AppendRecordOKReply, error) {
+	fileName := metadata.CleanPath(in.FileName)
+	r := l.records
+	r.mu.Lock()
+	file := r.files[fileName]
+	r.mu.Unlock()
+	if file == nil {
+		return nil, fmt.Errorf("no record reserved at offset %d of file %s", in.GetOffset(), fileName)
+	}
+	file.mu.Lock()
+	defer file.mu.Unlock()
+	record, ok := file.pending[in.GetOffset()]
+	if file.closed || !ok {
+		return nil, fmt.Errorf("no record reserved at offset %d of file %s", in.GetOffset(), fileName)
+	}
+	if in.GetFailed() {
+		file.close("record at offset %d failed to be written", in.GetOffset())
+		return &pb.AppendRecordOKReply{}, nil
+	}
+	for file.committed != in.GetOffset() {
+		if head, ok := file.pending[file.committed]; !file.committing && (!ok || time.Since(head.reservedAt) > recordTimeout) {
+			file.close("record at offset %d is not committed in time", file.committed)
+		}
+		if file.closed {
+			return nil, fmt.Errorf("file %s is closed before the record at offset %d is committed", fileName, in.GetOffset())
+		}
+		changed := file.changed
+		file.mu.Unlock()
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			// the record before may not be committed in time
+		case <-ctx.Done():
+			file.mu.Lock()
+			return nil, fmt.Errorf("failed to commit record at offset %d of file %s: %v", in.GetOffset(), fileName, ctx.Err())
+		}
+		file.mu.Lock()
+	}
+	if file.closed {
+		return nil, fmt.Errorf("file %s is closed before the record at offset %d is committed", fileName, in.GetOffset())
+	}
+
+	if err := l.checkRecordFile(fileName, file); err != nil {
+		file.close("%v", err)
+		return nil, err
+	}
+	end := in.GetOffset() + record.size
+	blockInfo := metadata.BlockInfo{}
+	garbage := []metadata.GarbageBlock{}
+	for blockID := in.GetOffset() / l.blockSize; blockID <= (end-1)/l.blockSize; blockID++ {
+		block := file.blocks[blockID]
+		blockMeta := block.meta
+		blockMeta.BlockSize = end - blockID*l.blockSize
+		if blockMeta.BlockSize > l.blockSize {
+			blockMeta.BlockSize = l.blockSize
+		}
+		blockInfo[blockID] = blockMeta
+		if !block.committed && block.base != nil {
+			// the replicas of the previous generation are removed by collectGarbage once no client reads the file
+			garbage = append(garbage, metadata.GarbageOf(metadata.BlockInfo{blockID: *block.base}, metadata.BlockInfo{blockID: blockMeta})...)
+		}
+	}
+	// the file is not opened again while the record is committed without the lock
+	file.committing = true
+	file.mu.Unlock()
+	err := l.metadata.AppendRecord(fileName, blockInfo, garbage, auth.Caller(ctx))
+	file.mu.Lock()
+	file.committing = false
+	if err != nil {
+		file.close("%v", err)
+		return nil, err
+	}
+	for blockID, blockMeta := range blockInfo {
+		block := file.blocks[blockID]
+		block.meta.BlockSize = blockMeta.BlockSize
+		block.committed = true
+	}
+	delete(file.pending, in.GetOffset())
+	file.committed = end
+	file.lastActive = time.Now()
+	file.notify()
+	return &pb.AppendRecordOKReply{}, nil
+}
+
+// openRecordBlock assigns a new generation and the replicas to a block open for records of a file, without the lock of
+// the file. The last partial block is written with a new generation on its alive replicas, created from its committed
+// data, and a new block is placed on replication data servers.
+func (l *LeaderServer) openRecordBlock(fileName string, file *recordFile, blockID int64, block *recordBlock) error {
+	generation, err := l.metadata.NewGeneration()
+	if err != nil {
+		return err
+	}
+	block.meta = metadata.BlockMeta{
+		FileName:   file.storageName,
+		BlockID:    blockID,
+		Generation: generation,
+	}
+	if current, ok := file.base[blockID]; ok && current.FileName == file.storageName {
+		if current.Compression != "" {
+			return fmt.Errorf("cannot append records to compressed block %d of file %s", blockID, fileName)
+		}
+		nodes, err := l.aliveNodes()
+		if err != nil {
+			return err
+		}
+		for _, hostName := range current.HostNames {
+			if containsNode(nodes, hostName) {
+				block.meta.HostNames = append(block.meta.HostNames, hostName)
+			}
+		}
+		if len(block.meta.HostNames) == 0 {
+			return fmt.Errorf("no alive replica of block %d of file %s to append to", blockID, fileName)
+		}
+		block.meta.BlockSize = current.BlockSize
+		block.base = &current
+		return nil
+	}
+	block.meta.HostNames, err = l.selectBlockHosts(l.getReplication(fileName, 0))
+	return err
+}
+
+// checkRecordFile returns an error if the blocks of a file are changed by others since the file is opened for records,
+// the caller must hold the lock of the file.
+func (l *LeaderServer) checkRecordFile(fileName string, file *recordFile) error {
+	blockInfo, err := l.metadata.GetBlockInfo(fileName)
+	if err != nil {
+		blockInfo = metadata.BlockInfo{}
+	}
+	for blockID, block := range file.blocks {
+		select {
+		case <-block.ready:
+		default:
+			// the block is being opened for a record after the ones being committed
+			continue
+		}
+		if block.err != nil {
+			continue
+		}
+		current, ok := blockInfo[blockID]
+		if !ok {
+			if block.base != nil || block.committed {
+				return fmt.Errorf("block %d of file %s is removed since it is opened for records", blockID, fileName)
+			}
+			continue
+		}
+		if current.FileName != file.storageName ||
+			(current.Generation != block.meta.Generation && (block.base == nil || current.Generation != block.base.Generation)) {
+			return fmt.Errorf("block %d of file %s is written by others since it is opened for records", blockID, fileName)
+		}
+	}
+	return nil
+}
+
+// isWriteLocked returns whether a client holds the write lock of a file.
+func (l *LeaderServer) isWriteLocked(fileName string) bool {
+	for _, lease := range l.metadata.GetLeases(fileName) {
+		if lease.Mode == metadata.WriteLock {
+			return true
+		}
+	}
+	return false
+}
+
+// close drops a file open for records with its reserved records, the caller must hold the lock of the file.
+func (f *recordFile) close(format string, args ...interface{}) {
+	if f.closed {
+		return
+	}
+	logrus.Warnf("Close file %s for records: %s", f.name, fmt.Sprintf(format, args...))
+	f.closed = true
+	f.notify()
+}
+
+// notify wakes up the records waiting for the file, the caller must hold the lock of the file.
+func (f *recordFile) notify() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// isOpen returns whether a file has reserved records or is recently appended, the blocks of the file may be changed.
+func (r *Records) isOpen(fileName string) bool {
+	return r.check(fileName, func(file *recordFile) bool {
+		return len(file.pending) > 0 || time.Since(file.lastActive) <= recordTimeout
+	})
+}
+
+// hasPending returns whether a file has reserved records which are not committed.
+func (r *Records) hasPending(fileName string) bool {
+	return r.check(fileName, func(file *recordFile) bool {
+		return len(file.pending) > 0
+	})
+}
+
+// check returns whether a file is open for records and satisfies cond, which is called with the lock of the file.
+func (r *Records) check(fileName string, cond func(*recordFile) bool) bool {
+	r.mu.Lock()
+	file, ok := r.files[fileName]
+	r.mu.Unlock()
+	if !ok {
+		return false
+	}
+	file.mu.Lock()
+	defer file.mu.Unlock()
+	return file.opened && (!file.closed || file.committing) && cond(file)
+}
@@ -7,20 +7,20 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	dataServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
-	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
-	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/memberserver/heartbeat"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ToReplicates []ToReplicate
 
 type ToReplicate struct {
-	FileName string
-	BlockID  int64
-	From     string
-	To       string
+	FileName   string
+	BlockID    int64
+	Generation int64
+	From       string
+	To         string
 }
 
 func (l *LeaderServer) startRecoveringReplica() {
@@ -33,6 +33,7 @@ func (l *LeaderServer) startRecoveringReplica() {
 			return
 		case <-l.recoverReplicaTicker.C:
 			l.recoverReplica()
+			l.trimReplicas()
 		}
 	}
 }
@@ -47,73 +48,73 @@ func (l *LeaderServer) recoverReplica() {
 	if leader != l.hostname {
 		return
 	}
-	heartbeat, err := heartbeat.GetInstance()
+	nodes, err := l.aliveNodes()
 	if err != nil {
-		logrus.Errorf("Failed to get heartbeat instance: %v", err)
+		logrus.Errorf("Failed to get alive data servers: %v", err)
 		return
 	}
-	membership := heartbeat.GetMembership()
-	if membership == nil {
-		logrus.Errorf("Failed to get membership instance")
-		return
-	}
-	members := membership.GetAliveMembers()
-	hostnames := make([]string, 0, len(members))
-	hostnameSet := make(map[string]struct{}, len(members))
-	for _, member := range members {
-		hostnames = append(hostnames, member.GetName())
-		hostnameSet[member.GetName()] = struct{}{}
+	hostnameSet := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		hostnameSet[node.HostName] = struct{}{}
 	}
 	// scan all file blocks and check if the replica hostname is in the member list
 	toReclicates := make(ToReplicates, 0)
+	toReconstructs := []ToReconstruct{}
 	for fileName, fileInfo := range l.metadata.GetFileInfo() {
-		for blockID, blockMeta := range fileInfo.BlockInfo {
-			alivedHostnames := []string{}
-			alivedHostnamesSet := make(map[string]struct{})
-			for _, hostname := range blockMeta.HostNames {
-				if _, ok := hostnameSet[hostname]; ok {
-					alivedHostnames = append(alivedHostnames, hostname)
-					alivedHostnamesSet[hostname] = struct{}{}
+		// the blocks being appended with records are written in place, a copy of them would miss the next records
+		if l.records.isOpen(fileName) {
+			continue
+		}
+		for _, version := range fileInfo.AllVersions() {
+			replication := l.replicationOf(fileInfo, version)
+			policy := l.policyOf(version)
+			for _, blockMeta := range version.Blocks() {
+				blockID := blockMeta.BlockID
+				alivedHostnames := []string{}
+				for _, hostname := range blockMeta.HostNames {
+					if _, ok := hostnameSet[hostname]; ok {
+						alivedHostnames = append(alivedHostnames, hostname)
+					}
 				}
-			}
-			l.metadata.AddOrUpdateBlockMeta(fileName, metadata.BlockMeta{
-				HostNames: alivedHostnames,
-				FileName:  fileName,
-				BlockID:   blockID,
-				BlockSize: blockMeta.BlockSize,
-			})
-
-			// if the alivedHostnames is less than replicaFactor, randomly select the hostname from the member list
-			newHostnames := make(map[string]struct{})
-			if len(alivedHostnamesSet) < l.replicationFactor {
-				for i := len(alivedHostnamesSet); i < l.replicationFactor; i++ {
-					var randomHostname string
-					for {
-						randomHostname = hostnames[rand.Intn(len(hostnames))]
-						if _, ok := alivedHostnamesSet[randomHostname]; ok {
-							continue
-						}
-						if _, ok := newHostnames[randomHostname]; ok {
-							continue
-						}
-						newHostnames[randomHostname] = struct{}{}
-						break
+				// keep the locations if no replica is alive, the hosts may come back after a restart. The lost blocks of
+				// erasure-coded versions are reconstructed from their stripes.
+				if len(alivedHostnames) == 0 {
+					if !policy.IsErasureCoded() {
+						logrus.Warnf("No alive replica of block %d of file %s", blockID, fileName)
 					}
+					continue
+				}
+				if len(alivedHostnames) != len(blockMeta.HostNames) {
+					updated := blockMeta
+					updated.HostNames = alivedHostnames
+					err := l.metadata.UpdateBlockMeta(updated)
+					if err != nil {
+						logrus.Errorf("Failed to update block meta of block %d of file %s: %v", blockID, fileName, err)
+						continue
+					}
+				}
+
+				// if the alivedHostnames is less than the replication of the file, select the new hosts by the placement policy
+				newHostnames := l.placeReplicas(nodes, replication-len(alivedHostnames), alivedHostnames)
+				if len(alivedHostnames)+len(newHostnames) < replication {
+					logrus.Debugf("Not enough data servers for %d replicas of block %d of file %s", replication, blockID, fileName)
+				}
+				for _, hostname := range newHostnames {
+					toReclicates = append(toReclicates, ToReplicate{
+						FileName:   blockMeta.FileName,
+						BlockID:    blockID,
+						Generation: blockMeta.Generation,
+						From:       alivedHostnames[rand.Intn(len(alivedHostnames))],
+						To:         hostname,
+					})
 				}
 			}
-			if len(alivedHostnames) == 0 {
-				continue
-			}
-			for hostname := range newHostnames {
-				toReclicates = append(toReclicates, ToReplicate{
-					FileName: fileName,
-					BlockID:  blockID,
-					From:     alivedHostnames[rand.Intn(len(alivedHostnames))],
-					To:       hostname,
-				})
+			if policy.IsErasureCoded() {
+				toReconstructs = append(toReconstructs, l.lostStripeBlocks(fileName, version, policy, nodes, hostnameSet)...)
 			}
 		}
 	}
+	l.reconstructBlocks(toReconstructs)
 
 	if len(toReclicates) == 0 {
 		return
@@ -128,6 +129,11 @@ func (l *LeaderServer) recoverReplica() {
 			err := l.replicate(toReplicate)
 			if err != nil {
 				logrus.Errorf("Failed to replicate %+v: %v", toReplicate, err)
+				if status.Code(err) == codes.DataLoss {
+					if err := l.removeBadReplica(toReplicate.FileName, toReplicate.BlockID, toReplicate.Generation, toReplicate.From); err != nil {
+						logrus.Errorf("Failed to remove corrupted replica %+v: %v", toReplicate, err)
+					}
+				}
 				return
 			}
 			logrus.Infof("Replicated %+v", toReplicate)
@@ -137,12 +143,16 @@ func (l *LeaderServer) recoverReplica() {
 				logrus.Errorf("Failed to get block meta %+v: %v", toReplicate, err)
 				return
 			}
-			l.metadata.AddOrUpdateBlockMeta(toReplicate.FileName, metadata.BlockMeta{
-				HostNames: append(blockInfo.HostNames, toReplicate.To),
-				FileName:  toReplicate.FileName,
-				BlockID:   toReplicate.BlockID,
-				BlockSize: blockInfo.BlockSize,
-			})
+			if blockInfo.Generation != toReplicate.Generation {
+				// the copy is an orphan and removed by the garbage collector
+				logrus.Warnf("Block is written again while replicating %+v", toReplicate)
+				return
+			}
+			blockInfo.HostNames = append(blockInfo.HostNames, toReplicate.To)
+			err = l.metadata.UpdateBlockMeta(blockInfo)
+			if err != nil {
+				logrus.Errorf("Failed to update block meta %+v: %v", toReplicate, err)
+			}
 		}(toReplicate)
 	}
 	wg.Wait()
@@ -151,7 +161,7 @@ func (l *LeaderServer) recoverReplica() {
 }
 
 func (l *LeaderServer) replicate(toReplicate ToReplicate) error {
-	conn, err := grpc.Dial(toReplicate.From+":"+l.dataServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := connpool.Get(toReplicate.From + ":" + l.dataServerPort)
 	if err != nil {
 		return err
 	}
@@ -160,9 +170,10 @@ func (l *LeaderServer) replicate(toReplicate ToReplicate) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
 	defer cancel()
 	_, err = client.ReplicateFileBlock(ctx, &dataServerProto.ReplicateFileBlockRequest{
-		FileName: toReplicate.FileName,
-		BlockID:  toReplicate.BlockID,
-		To:       toReplicate.To,
+		FileName:   toReplicate.FileName,
+		BlockID:    toReplicate.BlockID,
+		To:         toReplicate.To,
+		Generation: toReplicate.Generation,
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,272 @@
+package leaderserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// AppendEntries handles the log entries or heartbeat from the leader through gRPC.
+func (l *LeaderServer) AppendEntries(ctx context.Context, in *pb.AppendEntriesRequest) (*pb.AppendEntriesReply, error) {
+	return l.raft.handleAppendEntries(in)
+}
+
+// InstallSnapshot handles the metadata snapshot from the leader through gRPC.
+func (l *LeaderServer) InstallSnapshot(ctx context.Context, in *pb.InstallSnapshotRequest) (*pb.InstallSnapshotReply, error) {
+	return l.raft.handleInstallSnapshot(in)
+}
+
+// broadcast replicates the log to all peers.
+func (r *Raft) broadcast() {
+	for _, peer := range r.peers {
+		go r.replicate(peer)
+	}
+}
+
+// replicate sends the entries a peer is missing until it catches up with the log.
+func (r *Raft) replicate(peer string) {
+	r.mu.Lock()
+	if r.role != roleLeader || r.replicating[peer] {
+		r.mu.Unlock()
+		return
+	}
+	r.replicating[peer] = true
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.replicating[peer] = false
+		r.mu.Unlock()
+	}()
+	for {
+		more, err := r.replicateOnce(peer)
+		if err != nil {
+			logrus.Debugf("failed to replicate log to %s: %v", peer, err)
+			return
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+// replicateOnce sends one AppendEntries, or the snapshot if the entries are compacted, and returns whether there are more to send.
+func (r *Raft) replicateOnce(peer string) (bool, error) {
+	r.mu.Lock()
+	if r.role != roleLeader {
+		r.mu.Unlock()
+		return false, nil
+	}
+	term := r.term
+	nextIndex := r.nextIndex[peer]
+	if nextIndex <= r.log.SnapshotIndex() {
+		r.mu.Unlock()
+		return r.sendSnapshot(peer, term)
+	}
+	prevLogIndex := nextIndex - 1
+	prevLogTerm, err := r.log.Term(prevLogIndex)
+	if err != nil {
+		r.mu.Unlock()
+		return false, err
+	}
+	entries, err := r.log.Entries(nextIndex, maxAppendEntries)
+	if err != nil {
+		r.mu.Unlock()
+		return false, err
+	}
+	pbEntries, err := encodeLogEntries(entries)
+	if err != nil {
+		r.mu.Unlock()
+		return false, err
+	}
+	request := &pb.AppendEntriesRequest{
+		Term:         term,
+		Leader:       r.hostname,
+		PrevLogIndex: prevLogIndex,
+		PrevLogTerm:  prevLogTerm,
+		Entries:      pbEntries,
+		LeaderCommit: r.commitIndex,
+	}
+	r.mu.Unlock()
+
+	reply, err := r.appendEntries(peer, request)
+	if err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if reply.GetTerm() > r.term {
+		r.stepDown(reply.GetTerm())
+		return false, nil
+	}
+	if r.role != roleLeader || r.term != term {
+		return false, nil
+	}
+	r.lastAck[peer] = time.Now()
+	if !reply.GetSuccess() {
+		// retry from the index the peer is consistent with
+		r.nextIndex[peer] = reply.GetConflictIndex()
+		if r.nextIndex[peer] < 1 {
+			r.nextIndex[peer] = 1
+		}
+		return true, nil
+	}
+	matchIndex := prevLogIndex + uint64(len(entries))
+	if matchIndex > r.matchIndex[peer] {
+		r.matchIndex[peer] = matchIndex
+	}
+	r.nextIndex[peer] = matchIndex + 1
+	r.advanceCommitIndex()
+	return r.nextIndex[peer] <= r.log.LastIndex(), nil
+}
+
+// sendSnapshot sends the applied metadata to a peer which is behind the compacted log.
+func (r *Raft) sendSnapshot(peer string, term uint64) (bool, error) {
+	data, index, _, err := r.metadata.EncodeSnapshot()
+	if err != nil {
+		return false, err
+	}
+	logrus.Infof("Sending metadata snapshot at index %d to %s", index, peer)
+	reply, err := r.installSnapshot(peer, &pb.InstallSnapshotRequest{
+		Term:     term,
+		Leader:   r.hostname,
+		Snapshot: data,
+	})
+	if err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if reply.GetTerm() > r.term {
+		r.stepDown(reply.GetTerm())
+		return false, nil
+	}
+	if r.role != roleLeader || r.term != term {
+		return false, nil
+	}
+	r.lastAck[peer] = time.Now()
+	if index > r.matchIndex[peer] {
+		r.matchIndex[peer] = index
+	}
+	r.nextIndex[peer] = index + 1
+	r.advanceCommitIndex()
+	return r.nextIndex[peer] <= r.log.LastIndex(), nil
+}
+
+// handleAppendEntries appends the entries from the leader if the log matches at prevLogIndex.
+func (r *Raft) handleAppendEntries(in *pb.AppendEntriesRequest) (*pb.AppendEntriesReply, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if in.GetTerm() > r.term || (in.GetTerm() == r.term && r.role != roleFollower) {
+		r.stepDown(in.GetTerm())
+	}
+	reply := &pb.AppendEntriesReply{Term: r.term}
+	if in.GetTerm() < r.term {
+		return reply, nil
+	}
+	r.resetElectionTimer()
+	r.setLeader(in.GetLeader())
+
+	snapshotIndex, lastIndex := r.log.SnapshotIndex(), r.log.LastIndex()
+	if in.GetPrevLogIndex() > lastIndex {
+		reply.ConflictIndex = lastIndex + 1
+		return reply, nil
+	}
+	if in.GetPrevLogIndex() < snapshotIndex {
+		// entries up to the snapshot are committed and must match
+		reply.ConflictIndex = snapshotIndex + 1
+		return reply, nil
+	}
+	prevLogTerm, err := r.log.Term(in.GetPrevLogIndex())
+	if err != nil {
+		return nil, err
+	}
+	if prevLogTerm != in.GetPrevLogTerm() {
+		// skip all entries of the conflicting term
+		conflictIndex := in.GetPrevLogIndex()
+		for conflictIndex > snapshotIndex+1 {
+			term, err := r.log.Term(conflictIndex - 1)
+			if err != nil || term != prevLogTerm {
+				break
+			}
+			conflictIndex--
+		}
+		reply.ConflictIndex = conflictIndex
+		return reply, nil
+	}
+
+	entries, err := decodeLogEntries(in.GetEntries())
+	if err != nil {
+		return nil, err
+	}
+	if err := r.log.Merge(entries); err != nil {
+		return nil, err
+	}
+	commitIndex := in.GetLeaderCommit()
+	if lastNewIndex := in.GetPrevLogIndex() + uint64(len(in.GetEntries())); commitIndex > lastNewIndex {
+		commitIndex = lastNewIndex
+	}
+	if commitIndex > r.commitIndex {
+		r.commitIndex = commitIndex
+		r.applyCommitted()
+	}
+	reply.Success = true
+	return reply, nil
+}
+
+// handleInstallSnapshot replaces the metadata with the snapshot from the leader.
+func (r *Raft) handleInstallSnapshot(in *pb.InstallSnapshotRequest) (*pb.InstallSnapshotReply, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if in.GetTerm() > r.term || (in.GetTerm() == r.term && r.role != roleFollower) {
+		r.stepDown(in.GetTerm())
+	}
+	reply := &pb.InstallSnapshotReply{Term: r.term}
+	if in.GetTerm() < r.term {
+		return reply, nil
+	}
+	r.resetElectionTimer()
+	r.setLeader(in.GetLeader())
+
+	index, term, err := r.metadata.RestoreSnapshot(in.GetSnapshot())
+	if err != nil {
+		return nil, err
+	}
+	// keep the entries after the snapshot if the log contains the last entry of it
+	if logTerm, err := r.log.Term(index); err == nil && logTerm == term {
+		err = r.log.Compact(index)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := r.log.Reset(index, term); err != nil {
+		return nil, err
+	}
+	if index > r.commitIndex {
+		r.commitIndex = index
+	}
+	logrus.Infof("Installed metadata snapshot at index %d from %s", index, in.GetLeader())
+	return reply, nil
+}
+
+func (r *Raft) appendEntries(peer string, request *pb.AppendEntriesRequest) (*pb.AppendEntriesReply, error) {
+	conn, err := r.conn(peer)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), r.baseElectionTimeout/2)
+	defer cancel()
+	return pb.NewLeaderServerClient(conn).AppendEntries(ctx, request)
+}
+
+func (r *Raft) installSnapshot(peer string, request *pb.InstallSnapshotRequest) (*pb.InstallSnapshotReply, error) {
+	conn, err := r.conn(peer)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	return pb.NewLeaderServerClient(conn).InstallSnapshot(ctx, request)
+}
@@ -0,0 +1,19 @@
+package leaderserver
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
+	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// SetReplication changes the number of replicas of each block of a file, the replicas are added by recoverReplica or
+// removed by trimReplicas in the background.
+func (l *LeaderServer) SetReplication(ctx context.Context, in *pb.SetReplicationRequest) (*pb.SetReplicationReply, error) {
+	if err := l.metadata.SetReplication(in.GetFileName(), int(in.GetReplication()), auth.Caller(ctx)); err != nil {
+		return nil, err
+	}
+	logrus.Infof("Set replication of file %s to %d", in.GetFileName(), in.GetReplication())
+	return &pb.SetReplicationReply{}, nil
+}
@@ -0,0 +1,38 @@
+package leaderserver
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (l *LeaderServer) startSnapshottingMetadata() {
+	if l.snapshotInterval <= 0 {
+		logrus.Warn("Snapshot interval is not set, the metadata write-ahead log will not be compacted")
+		return
+	}
+	logrus.Info("Start snapshotting metadata")
+	l.snapshotMetadataTicker = time.NewTicker(l.snapshotInterval)
+	defer l.snapshotMetadataTicker.Stop()
+	for {
+		select {
+		case <-l.snapshotMetadataTickerDone:
+			return
+		case <-l.snapshotMetadataTicker.C:
+			l.snapshotMetadata()
+		}
+	}
+}
+
+func (l *LeaderServer) stopSnapshottingMetadata() {
+	l.snapshotMetadataTickerDone <- true
+}
+
+// snapshotMetadata compacts the metadata log into a snapshot.
+func (l *LeaderServer) snapshotMetadata() {
+	if err := l.raft.snapshot(); err != nil {
+		logrus.Errorf("failed to snapshot metadata: %v", err)
+		return
+	}
+	logrus.Debugf("Snapshotted metadata")
+}
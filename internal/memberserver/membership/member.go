@@ -4,11 +4,35 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+// failureHandlers are called with the name of a member once it is marked FAILED.
+var failureHandlers = struct {
+	sync.Mutex
+	handlers []func(name string)
+}{}
+
+// OnFailure registers handler to be called with the name of each member once it is marked FAILED, e.g. so that the
+// clients in this process stop waiting for the calls to it.
+func OnFailure(handler func(name string)) {
+	failureHandlers.Lock()
+	defer failureHandlers.Unlock()
+	failureHandlers.handlers = append(failureHandlers.handlers, handler)
+}
+
+func notifyFailure(name string) {
+	failureHandlers.Lock()
+	handlers := append([]func(string){}, failureHandlers.handlers...)
+	failureHandlers.Unlock()
+	for _, handler := range handlers {
+		handler(name)
+	}
+}
+
 // Member is a struct
 type Member struct {
 	ID             string
@@ -35,6 +59,9 @@ func NewMemberSelf() (*Member, error) {
 
 func (m *Member) UpdateState(heartbeat int, state State) {
 	m.logChange(state, heartbeat, m.Incarnation)
+	if state == FAILED && m.State != FAILED {
+		notifyFailure(m.GetName())
+	}
 	m.Heartbeat = heartbeat
 	m.State = state
 	m.LastUpdateTime = time.Now().UnixMilli()
@@ -12,6 +12,7 @@ type Job struct {
 	jobID    string
 	jobType  string
 	params   []string
+	caller   string // identity the job is run for
 	stream   pb.Scheduler_PutJobServer
 	finished chan<- bool
 
@@ -8,20 +8,23 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"sync"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/enums"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/memberserver/heartbeat"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/scheduler/proto"
 	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
 	taskManagerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/taskmanager/proto"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/utils"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Scheduler struct {
@@ -62,7 +65,11 @@ func (s *Scheduler) Run() {
 		return
 	}
 	defer listen.Close()
-	grpcServer := grpc.NewServer()
+	grpcServer, err := auth.NewServer()
+	if err != nil {
+		logrus.Fatalf("failed to create gRPC server: %v", err)
+		return
+	}
 	pb.RegisterSchedulerServer(grpcServer, s)
 	logrus.Infof("Scheduler listening on port %s", s.port)
 	if err := grpcServer.Serve(listen); err != nil {
@@ -71,17 +78,24 @@ func (s *Scheduler) Run() {
 	}
 }
 
+// PutJob runs a job for the caller through gRPC, the tasks of the job read and write SDFS as the caller.
 func (s *Scheduler) PutJob(in *pb.PutJobRequest, stream pb.Scheduler_PutJobServer) error {
+	ctx := stream.Context()
+	if err := checkJob(in.GetJobID(), in.GetType(), in.GetParams()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid job %s: %v", in.GetJobID(), err)
+	}
 	fin := make(chan bool)
 	job := &Job{
 		jobID:    in.GetJobID(),
 		jobType:  in.GetType(),
 		params:   in.GetParams(),
+		caller:   auth.Caller(ctx),
 		stream:   stream,
 		finished: fin,
 	}
-	s.jobs.Store(job.jobID, job)
-	ctx := stream.Context()
+	if _, loaded := s.jobs.LoadOrStore(job.jobID, job); loaded {
+		return status.Errorf(codes.AlreadyExists, "job %s exists", job.jobID)
+	}
 
 	// on processing the job
 	go s.processJob(job)
@@ -100,6 +114,36 @@ func (s *Scheduler) PutJob(in *pb.PutJobRequest, stream pb.Scheduler_PutJobServe
 	}
 }
 
+// checkJob checks the parameters of a job. The task managers download the executable and the input files of the tasks
+// and write the output files in their working directories under the names of the files in SDFS, so each of them must be
+// a local path.
+func checkJob(jobID, jobType string, params []string) error {
+	names := []string{jobID}
+	switch jobType {
+	case enums.MAPLE:
+		if len(params) < 4 {
+			return fmt.Errorf("maple job requires at least 4 parameters")
+		}
+		names = append(names, params[0], params[2], params[3])
+	case enums.JUICE:
+		if len(params) < 6 {
+			return fmt.Errorf("juice job requires at least 6 parameters")
+		}
+		names = append(names, params[0], params[2], params[3])
+	default:
+		return fmt.Errorf("unknown job type %s", jobType)
+	}
+	if tasks, err := strconv.Atoi(params[1]); err != nil || tasks <= 0 {
+		return fmt.Errorf("number of tasks %s is not positive", params[1])
+	}
+	for _, name := range names {
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("%s is not a local file name", name)
+		}
+	}
+	return nil
+}
+
 func (s *Scheduler) processJob(job *Job) {
 	job.Logf("Job Received, %+v", job)
 	// wait for the job to be processed
@@ -133,7 +177,7 @@ func (s *Scheduler) processMapleJob(job *Job) error {
 	mapleExeParams := job.params[4:]
 
 	// get the files that prefix with 'sdfs_src_directory-' from sdfs
-	sdfsClient, err := client.NewClient(s.configPath)
+	sdfsClient, err := client.NewClientAs(s.configPath, job.caller)
 	if err != nil {
 		return err
 	}
@@ -244,7 +288,7 @@ func (s *Scheduler) processJuiceJob(job *Job) error {
 	juiceExeParams := job.params[6:]
 
 	// get the files that prefix with 'sdfsIntermediateFilenamePrefix' from sdfs
-	sdfsClient, err := client.NewClient(s.configPath)
+	sdfsClient, err := client.NewClientAs(s.configPath, job.caller)
 	if err != nil {
 		return err
 	}
@@ -357,14 +401,14 @@ func (s *Scheduler) scheduleTask(job *Job, task *Task) error {
 
 func (s *Scheduler) putTask(job *Job, task *Task, worker string) error {
 	job.Logf("Sending Task %s to Worker %s", task.taskID, worker)
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%s", worker, s.config.TaskManager.Port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := connpool.Get(fmt.Sprintf("%s:%s", worker, s.config.TaskManager.Port))
 	if err != nil {
 		return err
 	}
 	defer conn.Close()
 
 	client := taskManagerProto.NewTaskManagerClient(conn)
-	stream, err := client.PutTask(context.Background(), &taskManagerProto.PutTaskRequest{
+	stream, err := client.PutTask(auth.WithCaller(context.Background(), job.caller), &taskManagerProto.PutTaskRequest{
 		TaskID:         task.taskID,
 		TaskType:       task.taskType,
 		ExeFilename:    task.exeFilename,
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
@@ -14,12 +15,30 @@ import (
 	"golang.org/x/sync/semaphore"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/checksum"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
+// AppendFile appends a local file to a file in SDFS.
 func (c *Client) AppendFile(localfilename, sdfsfilename string) error {
+	return c.AppendFileWithReplication(localfilename, sdfsfilename, 0)
+}
+
+// AppendOptions are the settings of a file in SDFS changed by an append, the ones set for the file are used if 0.
+type AppendOptions struct {
+	Replication int    // replicas of each block, the default in config if the file has none
+	Compression string // codec of the written blocks and the ones written later, the blocks before keep theirs
+}
+
+// AppendFileWithReplication appends a local file to a file in SDFS and sets the replicas of each block of the file,
+// the number set for the file or the default in config is used if replication is 0.
+func (c *Client) AppendFileWithReplication(localfilename, sdfsfilename string, replication int) error {
+	return c.AppendFileWithOptions(localfilename, sdfsfilename, AppendOptions{Replication: replication})
+}
+
+// AppendFileWithOptions appends a local file to a file in SDFS with the settings of the file in options.
+func (c *Client) AppendFileWithOptions(localfilename, sdfsfilename string, options AppendOptions) error {
 	localfile, err := os.Open(localfilename)
 	if err != nil {
 		return fmt.Errorf("cannot open local file %s: %v", localfilename, err)
@@ -42,14 +61,14 @@ func (c *Client) AppendFile(localfilename, sdfsfilename string) error {
 	logrus.Infof("Leader is %s", leader)
 
 	// acquire write lock
-	err = c.acquireFileWriteLock(leader, sdfsfilename)
+	lease, err := c.acquireFileWriteLock(leader, sdfsfilename)
 	if err != nil {
 		return err
 	}
-	defer c.releaseFileWriteLock(leader, sdfsfilename)
+	defer c.releaseFileLock(lease)
 	logrus.Infof("Acquired write lock of file %s", sdfsfilename)
 
-	blockInfo, err := c.appendBlockInfo(leader, sdfsfilename, fileInfo.Size())
+	blockInfo, generation, compression, err := c.appendBlockInfo(leader, sdfsfilename, fileInfo.Size(), options)
 	if err != nil {
 		return err
 	}
@@ -80,9 +99,12 @@ func (c *Client) AppendFile(localfilename, sdfsfilename string) error {
 				// get the first block file from multiple servers concurrently
 				for _, hostName := range hostNames {
 					logrus.Infof("Getting block %d of file %s from data server %s", blockMeta.BlockID, blockMeta.FileName, hostName)
-					firstBlockData, err = c.getFileBlock(hostName, blockMeta.FileName, blockMeta.BlockID)
+					firstBlockData, err = c.getFileBlock(hostName, blockMeta)
 					if err != nil {
 						logrus.Infof("Failed to get block %d of file %s from data server %s with error %s", blockMeta.BlockID, blockMeta.FileName, hostName, err)
+						if errors.Is(err, checksum.ErrMismatch) {
+							c.reportBadBlock(leader, blockMeta.FileName, blockMeta.BlockID, blockMeta.Generation, hostName)
+						}
 						continue
 					}
 					if len(firstBlockData) < int(firstBlock.BlockSize) {
@@ -92,7 +114,7 @@ func (c *Client) AppendFile(localfilename, sdfsfilename string) error {
 					logrus.Infof("Got block %d of file %s from data server %s", blockMeta.BlockID, blockMeta.FileName, hostName)
 					return nil
 				}
-				return nil
+				return fmt.Errorf("failed to get block %d of file %s from all data servers %v", blockMeta.BlockID, blockMeta.FileName, hostNames)
 			})
 		}(firstBlock)
 		if err := eg.Wait(); err != nil {
@@ -149,16 +171,18 @@ func (c *Client) AppendFile(localfilename, sdfsfilename string) error {
 					n += len(firstBlockData)
 				}
 
-				for _, hostname := range blockInfo[blockID].HostNames {
-					// send the block to the data server
-					_, err = c.putFileBlock(hostname, sdfsfilename, blockID, block[:n])
-					if err != nil {
-						return fmt.Errorf("Failed to put block %d of file %s to data server %s with error %w", blockID, sdfsfilename, hostname, err)
-					}
-					logrus.Infof("Put block %d of file %s with size %d to data server %s", blockID, sdfsfilename, n, hostname)
-				}
+				// send the block to the data servers, the first block is rewritten as a new generation with the codec
 				blockMeta := blockInfo[blockID]
-				blockMeta.BlockSize = int64(n)
+				stored, err := compressBlock(&blockMeta, compression, block[:n])
+				if err != nil {
+					return err
+				}
+				hostNames, err := c.putFileBlockToReplicas(blockMeta.HostNames, blockMeta.FileName, blockID, generation, stored)
+				if err != nil {
+					return err
+				}
+				blockMeta.HostNames = hostNames
+				blockMeta.Generation = generation
 				blockInfo[blockID] = blockMeta
 				return nil
 			})
@@ -167,7 +191,7 @@ func (c *Client) AppendFile(localfilename, sdfsfilename string) error {
 	if err := eg.Wait(); err != nil {
 		return fmt.Errorf("Failed to put file %s to SDFS: %w", sdfsfilename, err)
 	}
-	err = c.appendFileOK(leader, sdfsfilename, blockInfo)
+	err = c.appendFileOK(leader, sdfsfilename, blockInfo, options.Replication, compression)
 	if err != nil {
 		return fmt.Errorf("Failed to append file %s OK to leader %s: %w", sdfsfilename, leader, err)
 	}
@@ -175,60 +199,47 @@ func (c *Client) AppendFile(localfilename, sdfsfilename string) error {
 	return nil
 }
 
-// appendBlockInfo gets the block info for appending to a file from the leader server.
-func (c *Client) appendBlockInfo(leader, fileName string, fileSize int64) (metadata.BlockInfo, error) {
-	conn, err := grpc.Dial(leader+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// appendBlockInfo gets the block info for appending to a file, the generation and the codec of the appended blocks from
+// the leader server.
+func (c *Client) appendBlockInfo(leader, fileName string, fileSize int64, options AppendOptions) (metadata.BlockInfo, int64, string, error) {
+	conn, err := connpool.Get(leader + ":" + c.leaderServerPort)
 	if err != nil {
-		return nil, fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
+		return nil, 0, "", fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
 	}
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*5)
 	defer cancel()
 	r, err := client.AppendBlockInfo(ctx, &leaderServerProto.AppendBlockInfoRequest{
-		FileName: fileName,
-		FileSize: fileSize,
+		FileName:    fileName,
+		FileSize:    fileSize,
+		Replication: int32(options.Replication),
+		Compression: options.Compression,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block info: %v", err)
-	}
-	appendBlockInfoReply := r.GetBlockInfo()
-	blockInfo := metadata.BlockInfo{}
-	for blockID, blockMeta := range appendBlockInfoReply {
-		blockInfo[blockID] = metadata.BlockMeta{
-			HostNames: blockMeta.HostNames,
-			FileName:  blockMeta.FileName,
-			BlockID:   blockMeta.BlockID,
-			BlockSize: blockMeta.BlockSize,
-		}
+		return nil, 0, "", fmt.Errorf("failed to get block info: %v", err)
 	}
-	return blockInfo, nil
+	return fromProtoBlockInfo(r.GetBlockInfo()), r.GetGeneration(), r.GetCompression(), nil
 }
 
-func (c *Client) appendFileOK(hostname, fileName string, blockInfo metadata.BlockInfo) error {
-	conn, err := grpc.Dial(hostname+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func (c *Client) appendFileOK(hostname, fileName string, blockInfo metadata.BlockInfo, replication int, compression string) error {
+	conn, err := connpool.Get(hostname + ":" + c.leaderServerPort)
 	if err != nil {
 		return fmt.Errorf("cannot connect to %s leaderServer: %v", hostname, err)
 	}
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*5)
 	defer cancel()
-
-	appendFileOKRequestBlockMeta := map[int64]*leaderServerProto.BlockMeta{}
-	for blockID, blockMeta := range blockInfo {
-		appendFileOKRequestBlockMeta[blockID] = &leaderServerProto.BlockMeta{
-			HostNames: blockMeta.HostNames,
-			FileName:  blockMeta.FileName,
-			BlockID:   blockMeta.BlockID,
-			BlockSize: blockMeta.BlockSize,
-		}
-	}
+	// the blocks may be committed even if the call fails
+	defer c.cache.invalidatePath(fileName)
 	_, err = client.AppendFileOK(ctx, &leaderServerProto.AppendFileOKRequest{
-		FileName:  fileName,
-		BlockInfo: appendFileOKRequestBlockMeta,
+		FileName:    fileName,
+		BlockInfo:   toProtoBlockInfo(blockInfo),
+		Replication: int32(replication),
+		Compression: compression,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to append file OK: %v", err)
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// reportBadBlock tells the leader that the replica of a generation of a block on a data server is corrupted, the leader
+// re-replicates the block.
+func (c *Client) reportBadBlock(leader, fileName string, blockID int64, generation int64, hostName string) {
+	logrus.Warnf("Reporting corrupted block %d of file %s on data server %s", blockID, fileName, hostName)
+	err := c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		_, err := client.ReportBadBlock(ctx, &leaderServerProto.ReportBadBlockRequest{
+			FileName:   fileName,
+			BlockID:    blockID,
+			Generation: generation,
+			HostName:   hostName,
+		})
+		return err
+	})
+	if err != nil {
+		logrus.Errorf("failed to report corrupted block %d of file %s on %s: %v", blockID, fileName, hostName, err)
+	}
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
+	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// Balance asks the leader to trim the excess replicas and move blocks until the disk usage of each data server is
+// within threshold of the average.
+func (c *Client) Balance(threshold float64) (string, error) {
+	leader, err := c.getLeader()
+	if err != nil {
+		return "", err
+	}
+	conn, err := connpool.Get(leader + ":" + c.leaderServerPort)
+	if err != nil {
+		return "", fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
+	}
+	defer conn.Close()
+
+	client := leaderServerProto.NewLeaderServerClient(conn)
+	// moving blocks at a limited bandwidth may take long, the balancer stops if the command is interrupted
+	ctx, cancel := context.WithTimeout(c.context(), time.Hour*24)
+	defer cancel()
+	r, err := client.Balance(ctx, &leaderServerProto.BalanceRequest{Threshold: threshold})
+	if err != nil {
+		return "", fmt.Errorf("failed to balance: %v", err)
+	}
+	re := fmt.Sprintf("trimmed %d excess replicas\n", r.GetTrimmedReplicas())
+	re += fmt.Sprintf("moved %d blocks with %d bytes\n", r.GetMovedBlocks(), r.GetMovedBytes())
+	re += fmt.Sprintf("average disk usage %.1f%%\n", r.GetAverageUsage()*100)
+	return re, nil
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// leaderCacheTTL is how long the leader is cached before the local leader server is asked again.
+const leaderCacheTTL = time.Second * 10
+
+// maxCachedFiles is the max number of versions of files in the cache.
+const maxCachedFiles = 1024
+
+// ErrStaleBlock is returned when a data server rejects a read of a block generation which is replaced, the blocks of
+// the file must be got from the leader again.
+var ErrStaleBlock = errors.New("stale block")
+
+// fileTag identifies the state of a file at the leader, the blocks of the file are unchanged while the tag is the same.
+type fileTag struct {
+	epoch    uint64 // term of the leader
+	modIndex uint64 // index of the last change to the data of the file, 0 if unknown
+}
+
+// metadataCache caches the leader and the blocks of the files read by a client, shared by its sessions. A version of a
+// file is cached with the tag it is got at, and is used only if the read lock of the file is granted with the same tag.
+// The hosts of the cached blocks may be stale, the version is got again if a block cannot be read.
+type metadataCache struct {
+	mu       sync.Mutex
+	leader   string
+	leaderAt time.Time
+	files    map[fileKey]cachedVersion
+}
+
+type cachedVersion struct {
+	tag     fileTag
+	version metadata.FileVersion
+}
+
+func newMetadataCache() *metadataCache {
+	return &metadataCache{files: map[fileKey]cachedVersion{}}
+}
+
+// getLeader returns the cached leader if it is cached within leaderCacheTTL.
+func (m *metadataCache) getLeader() (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.leader == "" || time.Since(m.leaderAt) > leaderCacheTTL {
+		return "", false
+	}
+	return m.leader, true
+}
+
+func (m *metadataCache) putLeader(leader string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.leader = leader
+	m.leaderAt = time.Now()
+}
+
+// invalidateLeader drops the cached leader if it is leader, e.g. after a call to it failed.
+func (m *metadataCache) invalidateLeader(leader string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.leader == leader {
+		m.leader = ""
+	}
+}
+
+// isLeaderError returns whether a call to the leader failed because it is unreachable or no longer the leader, the
+// cached leader is dropped after such an error.
+func isLeaderError(err error) bool {
+	if err == nil {
+		return false
+	}
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded || strings.Contains(err.Error(), "is not the leader")
+}
+
+// getFile returns a copy of a cached version of a file if it is cached with tag.
+func (m *metadataCache) getFile(fileName string, version int64, tag fileTag) (metadata.FileVersion, bool) {
+	if tag.modIndex == 0 {
+		return metadata.FileVersion{}, false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cached, ok := m.files[newFileKey(fileName, version)]
+	if !ok || cached.tag != tag {
+		return metadata.FileVersion{}, false
+	}
+	return copyFileVersion(cached.version), true
+}
+
+// putFile caches a version of a file got at tag, a version with an unknown tag is not cached.
+func (m *metadataCache) putFile(fileName string, version int64, tag fileTag, fileVersion metadata.FileVersion) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := newFileKey(fileName, version)
+	if tag.modIndex == 0 {
+		delete(m.files, key)
+		return
+	}
+	if _, ok := m.files[key]; !ok && len(m.files) >= maxCachedFiles {
+		// drop any one of the cached versions
+		for k := range m.files {
+			delete(m.files, k)
+			break
+		}
+	}
+	m.files[key] = cachedVersion{tag: tag, version: copyFileVersion(fileVersion)}
+}
+
+// invalidateFile drops a cached version of a file.
+func (m *metadataCache) invalidateFile(fileName string, version int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.files, newFileKey(fileName, version))
+}
+
+// invalidatePath drops the cached versions of a file, or of the files under a directory, after it is written to, deleted
+// or renamed by this client.
+func (m *metadataCache) invalidatePath(name string) {
+	name = metadata.CleanPath(name)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.files {
+		if key.name == name || name == "" || strings.HasPrefix(key.name, name+"/") {
+			delete(m.files, key)
+		}
+	}
+}
+
+// fileKey identifies a version of a file in the cache.
+type fileKey struct {
+	name    string
+	version int64
+}
+
+func newFileKey(fileName string, version int64) fileKey {
+	return fileKey{name: metadata.CleanPath(fileName), version: version}
+}
+
+// copyFileVersion returns a copy of a version, the hosts of the blocks are not shared since the readers shuffle them.
+func copyFileVersion(version metadata.FileVersion) metadata.FileVersion {
+	copyBlockInfo := func(blockInfo metadata.BlockInfo) metadata.BlockInfo {
+		if blockInfo == nil {
+			return nil
+		}
+		copied := metadata.BlockInfo{}
+		for blockID, blockMeta := range blockInfo {
+			blockMeta.HostNames = append([]string{}, blockMeta.HostNames...)
+			copied[blockID] = blockMeta
+		}
+		return copied
+	}
+	version.BlockInfo = copyBlockInfo(version.BlockInfo)
+	version.Parity = copyBlockInfo(version.Parity)
+	return version
+}
+
+// getCachedBlockInfo returns a version of a file from the cache if it is cached with tag, or gets it from the leader
+// server. It returns whether the version is from the cache.
+func (c *Client) getCachedBlockInfo(leader, fileName string, version int64, tag fileTag) (metadata.FileVersion, bool, error) {
+	if fileVersion, ok := c.cache.getFile(fileName, version, tag); ok {
+		return fileVersion, true, nil
+	}
+	fileVersion, err := c.getBlockInfo(leader, fileName, version)
+	return fileVersion, false, err
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"testing"
+
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+)
+
+func TestInvalidatePath(t *testing.T) {
+	tag := fileTag{epoch: 1, modIndex: 1}
+	names := []string{"dir/a", "dir/sub/b", "dir2/c", "d"}
+	tests := []struct {
+		path string
+		kept []string
+	}{
+		{"dir/a", []string{"dir/sub/b", "dir2/c", "d"}},
+		{"/dir/", []string{"dir2/c", "d"}},
+		{"dir/sub", []string{"dir/a", "dir2/c", "d"}},
+		{"di", []string{"dir/a", "dir/sub/b", "dir2/c", "d"}},
+		{"", []string{}},
+	}
+	for _, test := range tests {
+		cache := newMetadataCache()
+		for _, name := range names {
+			cache.putFile(name, 0, tag, metadata.FileVersion{})
+			cache.putFile(name, 2, tag, metadata.FileVersion{})
+		}
+		cache.invalidatePath(test.path)
+		kept := map[string]bool{}
+		for _, name := range test.kept {
+			kept[name] = true
+		}
+		for _, name := range names {
+			for _, version := range []int64{0, 2} {
+				if _, ok := cache.getFile(name, version, tag); ok != kept[name] {
+					t.Errorf("invalidatePath(%q): version %d of %s is cached %v, want %v", test.path, version, name, ok, kept[name])
+				}
+			}
+		}
+	}
+}
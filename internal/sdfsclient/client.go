@@ -4,13 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"sync/atomic"
 	"time"
 
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
 	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Client handles file operations to SDFS.
@@ -18,53 +20,103 @@ type Client struct {
 	leaderServerPort string
 	dataServerPort   string
 	blockSize        int64
-
-	fileReadLocks  map[string]bool
-	fileWriteLocks map[string]bool
+	pipelineWrite    bool   // send blocks through a pipeline of the replicas
+	clientID         string // identifies the leases of this client
+	caller           string // user a node acts for, forwarded to the servers
+	cache            *metadataCache
+	replicas         *replicaStats // latencies of the data servers for choosing and hedging the replicas read
 }
 
 // NewClient creates a new Client.
 func NewClient(configPath string) (*Client, error) {
+	return NewClientAs(configPath, "")
+}
+
+// NewClientAs creates a new Client which acts for caller, e.g. a user whose command or job a node runs. The servers
+// record the changes it makes as made by caller instead of the node.
+func NewClientAs(configPath string, caller string) (*Client, error) {
 	config, err := config.NewConfig(configPath)
 	if err != nil {
 		return nil, err
 	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
 	return &Client{
 		leaderServerPort: config.LeaderServerPort,
 		dataServerPort:   config.DataServerPort,
 		blockSize:        config.BlockSize,
-		fileReadLocks:    map[string]bool{},
-		fileWriteLocks:   map[string]bool{},
+		pipelineWrite:    config.PipelineWrite,
+		clientID:         fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixNano()),
+		caller:           caller,
+		cache:            newMetadataCache(),
+		replicas:         newReplicaStats(hostname, config.HedgedRead),
 	}, nil
 }
 
-// getLeader from local leader server through gRPC.
+// sessions counts the sessions created by Session.
+var sessions atomic.Int64
+
+// Session returns a client with the settings, the cache and the replica latencies of c and its own client ID. A server
+// which handles concurrent requests uses a session for each of them, so that the leases of each request are listed
+// under their own client.
+func (c *Client) Session() *Client {
+	return &Client{
+		leaderServerPort: c.leaderServerPort,
+		dataServerPort:   c.dataServerPort,
+		blockSize:        c.blockSize,
+		pipelineWrite:    c.pipelineWrite,
+		clientID:         fmt.Sprintf("%s-%d", c.clientID, sessions.Add(1)),
+		caller:           c.caller,
+		cache:            c.cache,
+		replicas:         c.replicas,
+	}
+}
+
+// context returns the context of the calls of the client, which carries the caller it acts for.
+func (c *Client) context() context.Context {
+	return auth.WithCaller(context.Background(), c.caller)
+}
+
+// getLeader returns the cached leader, or gets it from the local leader server.
 func (c *Client) getLeader() (string, error) {
-	conn, err := grpc.Dial("localhost:"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if leader, ok := c.cache.getLeader(); ok {
+		return leader, nil
+	}
+	return c.refreshLeader()
+}
+
+// refreshLeader gets the leader from local leader server through gRPC and caches it.
+func (c *Client) refreshLeader() (string, error) {
+	conn, err := connpool.Get("localhost:" + c.leaderServerPort)
 	if err != nil {
 		return "", fmt.Errorf("cannot connect to %s leaderServer: %v", "localhost", err)
 	}
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*5)
 	defer cancel()
 	r, err := client.GetLeader(ctx, &leaderServerProto.GetLeaderRequest{})
 	if err != nil {
 		return "", fmt.Errorf("failed to get leader: %v", err)
 	}
+	if r.GetLeader() != "" {
+		c.cache.putLeader(r.GetLeader())
+	}
 	return r.GetLeader(), nil
 }
 
 func (c *Client) getMetadata(leader string) (*metadata.Metadata, error) {
-	conn, err := grpc.Dial(leader+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := connpool.Get(leader + ":" + c.leaderServerPort)
 	if err != nil {
 		return nil, fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
 	}
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*5)
 	defer cancel()
 	r, err := client.GetMetadata(ctx, &leaderServerProto.GetMetadataRequest{})
 	if err != nil {
@@ -75,12 +127,7 @@ func (c *Client) getMetadata(leader string) (*metadata.Metadata, error) {
 	for fileName, fileInfo := range r.GetMetadata().GetFileInfo() {
 		newBlockInfo := metadata.BlockInfo{}
 		for blockID, blockMeta := range fileInfo.GetBlockInfo().GetBlockInfo() {
-			newBlockInfo[blockID] = metadata.BlockMeta{
-				HostNames: blockMeta.HostNames,
-				FileName:  blockMeta.FileName,
-				BlockID:   blockMeta.BlockID,
-				BlockSize: blockMeta.BlockSize,
-			}
+			newBlockInfo[blockID] = fromProtoBlockMeta(blockMeta)
 		}
 		newMetadata.AddOrUpdateBlockInfo(fileName, newBlockInfo)
 	}
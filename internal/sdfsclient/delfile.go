@@ -6,9 +6,8 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // DelFile deletes a file from SDFS.
@@ -20,14 +19,14 @@ func (c *Client) DelFile(sdfsfilename string) error {
 	logrus.Infof("Leader is %s", leader)
 
 	// acquire write lock
-	err = c.acquireFileWriteLock(leader, sdfsfilename)
+	lease, err := c.acquireFileWriteLock(leader, sdfsfilename)
 	if err != nil {
 		return err
 	}
-	defer c.releaseFileWriteLock(leader, sdfsfilename)
+	defer c.releaseFileLock(lease)
 	logrus.Infof("Acquired write lock of file %s", sdfsfilename)
 
-	err = c.delFile(leader, sdfsfilename)
+	err = c.delFile(leader, sdfsfilename, lease.holder)
 	if err != nil {
 		return err
 	}
@@ -35,19 +34,21 @@ func (c *Client) DelFile(sdfsfilename string) error {
 	return nil
 }
 
-// delFile from local leader server through gRPC.
-func (c *Client) delFile(leader, sdfsfilename string) error {
-	conn, err := grpc.Dial(leader+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// delFile from local leader server through gRPC, holder holds the write lock of the file.
+func (c *Client) delFile(leader, sdfsfilename, holder string) error {
+	conn, err := connpool.Get(leader + ":" + c.leaderServerPort)
 	if err != nil {
 		return fmt.Errorf("cannot dial leader server %s: %v", leader, err)
 	}
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*5)
 	defer cancel()
+	defer c.cache.invalidatePath(sdfsfilename)
 	_, err = client.DelFile(ctx, &leaderServerProto.DelFileRequest{
 		FileName: sdfsfilename,
+		ClientID: holder,
 	})
 	if err != nil {
 		return fmt.Errorf("cannot delete file %s: %v", sdfsfilename, err)
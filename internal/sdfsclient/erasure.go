@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/checksum"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+// SetPolicy changes the storage policy of a file in SDFS, "replication" or "RS-<data shards>-<parity shards>".
+// The file is read and written again as a new version with the policy, under the write lock so that no write in
+// between is lost.
+func (c *Client) SetPolicy(sdfsfilename string, policyName string) error {
+	policy, err := erasure.ParsePolicy(policyName)
+	if err != nil {
+		return err
+	}
+	leader, err := c.getLeader()
+	if err != nil {
+		return err
+	}
+	lease, err := c.acquireFileWriteLock(leader, sdfsfilename)
+	if err != nil {
+		return err
+	}
+	defer c.releaseFileLock(lease)
+	fileVersion, err := c.getBlockInfo(leader, sdfsfilename, 0)
+	if err != nil {
+		return err
+	}
+	if fileVersion.Policy == policy.String() {
+		logrus.Infof("Policy of file %s is already %s", sdfsfilename, policyName)
+		return nil
+	}
+	tempFile, err := os.CreateTemp("", "sdfs-setpolicy-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+	if err := c.getFileBlocks(leader, fileVersion, tempFile); err != nil {
+		return fmt.Errorf("failed to get file %s from SDFS: %w", sdfsfilename, err)
+	}
+	// the replication policy is sent by name since an empty policy keeps the one of the file
+	if err := c.putFile(leader, tempFile, sdfsfilename, PutOptions{Policy: policyName}); err != nil {
+		return err
+	}
+	logrus.Infof("Set policy of file %s to %s", sdfsfilename, policyName)
+	return nil
+}
+
+// putStripes sends the data blocks of each stripe of a file and the parity blocks encoded from them to the data servers,
+// and sets the sizes of the blocks.
+func (c *Client) putStripes(localfile *os.File, fileVersion metadata.FileVersion, policy erasure.Policy) error {
+	blocksNum := int64(len(fileVersion.BlockInfo))
+	mu := sync.Mutex{}
+	// each stripe holds its blocks in memory
+	writeSem := semaphore.NewWeighted(2)
+	eg, _ := errgroup.WithContext(context.Background())
+	for stripe := int64(0); stripe < policy.Stripes(blocksNum); stripe++ {
+		func(stripe int64) {
+			eg.Go(func() error {
+				err := writeSem.Acquire(context.Background(), 1)
+				defer writeSem.Release(1)
+				if err != nil {
+					return fmt.Errorf("failed to acquire semaphore: %v", err)
+				}
+				data := [][]byte{}
+				for _, blockID := range policy.DataBlockIDs(stripe, blocksNum) {
+					block := make([]byte, c.blockSize)
+					n, err := localfile.ReadAt(block, blockID*c.blockSize)
+					if err != nil && err != io.EOF {
+						return fmt.Errorf("cannot read local file %s: %v", localfile.Name(), err)
+					}
+					data = append(data, block[:n])
+					mu.Lock()
+					blockMeta := fileVersion.BlockInfo[blockID]
+					mu.Unlock()
+					if _, err := c.putFileBlockToReplicas(blockMeta.HostNames, blockMeta.FileName, blockID, blockMeta.Generation, block[:n]); err != nil {
+						return err
+					}
+					blockMeta.BlockSize = int64(n)
+					mu.Lock()
+					fileVersion.BlockInfo[blockID] = blockMeta
+					mu.Unlock()
+				}
+				parity, err := policy.Encode(data)
+				if err != nil {
+					return err
+				}
+				for i, blockID := range policy.ParityBlockIDs(stripe) {
+					mu.Lock()
+					blockMeta := fileVersion.Parity[blockID]
+					mu.Unlock()
+					if _, err := c.putFileBlockToReplicas(blockMeta.HostNames, blockMeta.FileName, blockID, blockMeta.Generation, parity[i]); err != nil {
+						return err
+					}
+					blockMeta.BlockSize = int64(len(parity[i]))
+					mu.Lock()
+					fileVersion.Parity[blockID] = blockMeta
+					mu.Unlock()
+				}
+				logrus.Infof("Put stripe %d of file %s with policy %s", stripe, localfile.Name(), policy)
+				return nil
+			})
+		}(stripe)
+	}
+	return eg.Wait()
+}
+
+// reconstructFileBlock reconstructs a data block of an erasure-coded file from the other blocks of its stripe.
+func (c *Client) reconstructFileBlock(leader string, fileVersion metadata.FileVersion, policy erasure.Policy, blockID int64) ([]byte, error) {
+	return reconstructBlock(fileVersion, policy, blockID, func(blockMeta metadata.BlockMeta) ([]byte, error) {
+		var lastErr error = fmt.Errorf("no replica of block %d of file %s", blockMeta.BlockID, blockMeta.FileName)
+		for _, hostName := range blockMeta.HostNames {
+			data, err := c.getFileBlock(hostName, blockMeta)
+			if err != nil {
+				logrus.Infof("Failed to get block %d of file %s from data server %s with error %s", blockMeta.BlockID, blockMeta.FileName, hostName, err)
+				if errors.Is(err, checksum.ErrMismatch) {
+					c.reportBadBlock(leader, blockMeta.FileName, blockMeta.BlockID, blockMeta.Generation, hostName)
+				}
+				lastErr = err
+				continue
+			}
+			return data, nil
+		}
+		return nil, lastErr
+	})
+}
+
+// reconstructBlock reconstructs a data block of an erasure-coded file from the other blocks of its stripe, which are
+// read by getBlock.
+func reconstructBlock(fileVersion metadata.FileVersion, policy erasure.Policy, blockID int64, getBlock func(blockMeta metadata.BlockMeta) ([]byte, error)) ([]byte, error) {
+	stripe := blockID / int64(policy.DataShards)
+	index := int(blockID % int64(policy.DataShards))
+	// the data blocks followed by the parity blocks, the data blocks beyond the end of the file are empty
+	blocks := make([]metadata.BlockMeta, policy.Shards())
+	for i, dataBlockID := range policy.DataBlockIDs(stripe, int64(len(fileVersion.BlockInfo))) {
+		blocks[i] = fileVersion.BlockInfo[dataBlockID]
+	}
+	for i, parityBlockID := range policy.ParityBlockIDs(stripe) {
+		blocks[policy.DataShards+i] = fileVersion.Parity[parityBlockID]
+	}
+	shards := make([][]byte, len(blocks))
+	var shardSize int64 = 0
+	available := 0
+	for i, blockMeta := range blocks {
+		if blockMeta.BlockSize > shardSize {
+			shardSize = blockMeta.BlockSize
+		}
+		if blockMeta.FileName == "" {
+			shards[i] = []byte{}
+			available++
+		}
+	}
+	for i, blockMeta := range blocks {
+		if available >= policy.DataShards {
+			break
+		}
+		if i == index || blockMeta.FileName == "" {
+			continue
+		}
+		data, err := getBlock(blockMeta)
+		if err != nil {
+			continue
+		}
+		shards[i] = data
+		available++
+	}
+	lost := blocks[index]
+	if err := policy.Reconstruct(shards, int(shardSize)); err != nil {
+		return nil, fmt.Errorf("failed to reconstruct block %d of file %s: %v", blockID, lost.FileName, err)
+	}
+	logrus.Infof("Reconstructed block %d of file %s from stripe %d", blockID, lost.FileName, stripe)
+	return shards[index][:lost.BlockSize], nil
+}
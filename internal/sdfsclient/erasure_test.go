@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+)
+
+// testStripes returns a file of fileSize bytes in blocks of blockSize encoded with policy, and the data of its data and
+// parity blocks by block key.
+func testStripes(t *testing.T, policy erasure.Policy, fileSize, blockSize int) (metadata.FileVersion, []byte, map[string][]byte) {
+	t.Helper()
+	file := make([]byte, fileSize)
+	rand.New(rand.NewSource(int64(fileSize))).Read(file)
+	fileVersion := metadata.FileVersion{BlockInfo: metadata.BlockInfo{}, Parity: metadata.BlockInfo{}, Policy: policy.String()}
+	stored := map[string][]byte{}
+	blocksNum := int64((fileSize + blockSize - 1) / blockSize)
+	for blockID := int64(0); blockID < blocksNum; blockID++ {
+		end := int(blockID+1) * blockSize
+		if end > fileSize {
+			end = fileSize
+		}
+		data := file[int(blockID)*blockSize : end]
+		fileVersion.BlockInfo[blockID] = metadata.BlockMeta{HostNames: []string{"host"}, FileName: "data", BlockID: blockID, BlockSize: int64(len(data))}
+		stored[fmt.Sprintf("data_%d", blockID)] = data
+	}
+	for stripe := int64(0); stripe < policy.Stripes(blocksNum); stripe++ {
+		data := [][]byte{}
+		for _, blockID := range policy.DataBlockIDs(stripe, blocksNum) {
+			data = append(data, stored[fmt.Sprintf("data_%d", blockID)])
+		}
+		parity, err := policy.Encode(data)
+		if err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+		for i, blockID := range policy.ParityBlockIDs(stripe) {
+			fileVersion.Parity[blockID] = metadata.BlockMeta{HostNames: []string{"host"}, FileName: "parity", BlockID: blockID, BlockSize: int64(len(parity[i]))}
+			stored[fmt.Sprintf("parity_%d", blockID)] = parity[i]
+		}
+	}
+	return fileVersion, file, stored
+}
+
+func TestReconstructBlock(t *testing.T) {
+	policy := erasure.Policy{DataShards: 3, ParityShards: 2}
+	tests := []struct {
+		name    string
+		size    int      // size of the file in blocks of 100 bytes
+		blockID int64    // data block to reconstruct
+		lost    []string // other blocks which cannot be read
+		wantErr bool
+	}{
+		{"full stripe", 900, 1, nil, false},
+		{"full stripe with a lost parity block", 900, 4, []string{"parity_2"}, false},
+		{"shorter last block", 750, 7, []string{"data_6"}, false},
+		{"short last stripe", 450, 4, nil, false},
+		{"short last stripe with a lost parity block", 450, 3, []string{"parity_2"}, false},
+		{"one block in the last stripe", 310, 3, []string{"parity_3"}, false},
+		{"too many blocks lost", 900, 0, []string{"data_1", "parity_0"}, true},
+		{"short last stripe with all parity blocks lost", 450, 4, []string{"parity_2", "parity_3"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fileVersion, file, stored := testStripes(t, policy, test.size, 100)
+			lost := map[string]bool{fmt.Sprintf("data_%d", test.blockID): true}
+			for _, key := range test.lost {
+				lost[key] = true
+			}
+			data, err := reconstructBlock(fileVersion, policy, test.blockID, func(blockMeta metadata.BlockMeta) ([]byte, error) {
+				key := fmt.Sprintf("%s_%d", blockMeta.FileName, blockMeta.BlockID)
+				if lost[key] {
+					return nil, fmt.Errorf("block %s is lost", key)
+				}
+				return stored[key], nil
+			})
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("reconstructed block %d with too many blocks lost", test.blockID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("reconstructBlock: %v", err)
+			}
+			start := int(test.blockID) * 100
+			end := start + 100
+			if end > len(file) {
+				end = len(file)
+			}
+			if !bytes.Equal(data, file[start:end]) {
+				t.Errorf("block %d is reconstructed to %d bytes which do not match the %d bytes of the file", test.blockID, len(data), end-start)
+			}
+		})
+	}
+}
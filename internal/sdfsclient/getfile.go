@@ -4,23 +4,31 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math/rand"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/checksum"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/compress"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	dataServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
 	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // GetFile gets a file from SDFS.
 func (c *Client) GetFile(sdfsfilename, localfilename string) error {
+	return c.GetFileVersion(sdfsfilename, localfilename, 0)
+}
+
+// GetFileVersion gets a version of a file from SDFS, the current version if version is 0.
+func (c *Client) GetFileVersion(sdfsfilename, localfilename string, version int64) error {
 	// get leader
 	leader, err := c.getLeader()
 	if err != nil {
@@ -29,32 +37,64 @@ func (c *Client) GetFile(sdfsfilename, localfilename string) error {
 	logrus.Infof("Leader is %s", leader)
 
 	// acquire read lock
-	err = c.acquireFileReadLock(leader, sdfsfilename)
+	lease, tag, err := c.acquireFileReadLock(leader, sdfsfilename)
 	if err != nil {
 		return err
 	}
-	defer c.releaseFileReadLock(leader, sdfsfilename)
+	defer c.releaseFileLock(lease)
 	logrus.Infof("Acquired read lock of file %s", sdfsfilename)
 
-	// get blockInfo from leader
-	blockInfo, err := c.getBlockInfo(leader, sdfsfilename)
+	// get blockInfo from the cache or leader
+	fileVersion, cached, err := c.getCachedBlockInfo(leader, sdfsfilename, version, tag)
 	if err != nil {
 		return err
 	}
-	logrus.Infof("Got blockInfo %+v", blockInfo)
+	logrus.Infof("Got blockInfo %+v, cached %v", fileVersion.BlockInfo, cached)
 
 	// get file blocks from data servers
-	mu := sync.Mutex{}
-	getSem := semaphore.NewWeighted(10)
 	tempFileName := fmt.Sprintf("%s.temp", localfilename)
 	file, err := os.Create(tempFileName)
 	if err != nil {
 		return fmt.Errorf("failed to create temp file %s: %v", tempFileName, err)
 	}
 	defer os.Remove(tempFileName)
+	err = c.getFileBlocks(leader, fileVersion, file)
+	if err != nil && cached {
+		// the cached hosts of the blocks may be stale
+		logrus.Infof("Failed to get file %s with cached blockInfo, getting blockInfo again: %v", sdfsfilename, err)
+		c.cache.invalidateFile(sdfsfilename, version)
+		fileVersion, err = c.getBlockInfo(leader, sdfsfilename, version)
+		if err != nil {
+			return err
+		}
+		err = c.getFileBlocks(leader, fileVersion, file)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to get file %s from SDFS: %w", sdfsfilename, err)
+	}
+	logrus.Infof("Got all blocks of file %s from SDFS", sdfsfilename)
+	// Move the temp file to the local file
+	file.Close()
+	err = os.Rename(tempFileName, localfilename)
+	if err != nil {
+		return fmt.Errorf("failed to rename temp file %s to local file %s: %v", tempFileName, localfilename, err)
+	}
+	logrus.Infof("Got file %s from SDFS to %s", sdfsfilename, localfilename)
+	return nil
+}
+
+// getFileBlocks gets the blocks of a version of a file from the data servers and writes them to file.
+func (c *Client) getFileBlocks(leader string, fileVersion metadata.FileVersion, file *os.File) error {
+	policy, err := erasure.ParsePolicy(fileVersion.Policy)
+	if err != nil {
+		return err
+	}
+	tempFileName := file.Name()
+	mu := sync.Mutex{}
+	getSem := semaphore.NewWeighted(10)
 	// get the block file from multiple servers concurrently
 	eg, _ := errgroup.WithContext(context.Background())
-	for _, blockMeta := range blockInfo {
+	for _, blockMeta := range fileVersion.BlockInfo {
 		func(blockMeta metadata.BlockMeta) {
 			eg.Go(func() error {
 				// acquire a semaphore
@@ -63,117 +103,204 @@ func (c *Client) GetFile(sdfsfilename, localfilename string) error {
 				if err != nil {
 					return err
 				}
-				// random order
-				hostNames := blockMeta.HostNames
-				rand.Shuffle(len(hostNames), func(i, j int) {
-					hostNames[i], hostNames[j] = hostNames[j], hostNames[i]
-				})
-				// try the first hostname, and the second ..., if all fail, return error
-				for _, hostName := range hostNames {
-					logrus.Infof("Getting block %d of file %s from data server %s", blockMeta.BlockID, blockMeta.FileName, hostName)
-					data, err := c.getFileBlock(hostName, blockMeta.FileName, blockMeta.BlockID)
-					if err != nil {
-						logrus.Infof("Failed to get block %d of file %s from data server %s with error %s", blockMeta.BlockID, blockMeta.FileName, hostName, err)
-						continue
+				// the nearest and least loaded replicas first, hedged to another one if slow
+				data, err := c.readReplicas(leader, blockMeta, 0, 0)
+				if err != nil {
+					if !policy.IsErasureCoded() {
+						return err
 					}
-					logrus.Infof("Got block %d of file %s from data server %s", blockMeta.BlockID, blockMeta.FileName, hostName)
-					// Write the block to the local temp file
-					mu.Lock()
-					_, err = file.WriteAt(data, blockMeta.BlockID*c.blockSize)
-					mu.Unlock()
+					// a block of an erasure-coded file is reconstructed from the other blocks of its stripe
+					data, err = c.reconstructFileBlock(leader, fileVersion, policy, blockMeta.BlockID)
 					if err != nil {
-						logrus.Infof("Failed to write block %d of file %s to local temp file %s with error %s", blockMeta.BlockID, blockMeta.FileName, tempFileName, err)
-						continue
+						return err
 					}
-					logrus.Infof("Wrote block %d of file %s from data server %s", blockMeta.BlockID, blockMeta.FileName, hostName)
-					return nil
+				}
+				// the records appended after the block info is got are not written
+				if int64(len(data)) > blockMeta.BlockSize {
+					data = data[:blockMeta.BlockSize]
+				}
+				// Write the block to the local temp file
+				mu.Lock()
+				_, err = file.WriteAt(data, blockMeta.BlockID*c.blockSize)
+				mu.Unlock()
+				if err != nil {
+					return fmt.Errorf("failed to write block %d of file %s to local temp file %s: %v", blockMeta.BlockID, blockMeta.FileName, tempFileName, err)
 				}
 				return nil
 			})
 		}(blockMeta)
 	}
-	if err := eg.Wait(); err != nil {
-		return fmt.Errorf("Failed to get file %s from SDFS: %w", sdfsfilename, err)
-	}
-	logrus.Infof("Got all blocks of file %s from SDFS", sdfsfilename)
-	// Move the temp file to the local file
-	file.Close()
-	err = os.Rename(tempFileName, localfilename)
-	if err != nil {
-		return fmt.Errorf("failed to rename temp file %s to local file %s: %v", tempFileName, localfilename, err)
-	}
-	logrus.Infof("Got file %s from SDFS to %s", sdfsfilename, localfilename)
-	return nil
+	return eg.Wait()
 }
 
-// getBlockInfo gets the block info of a file from the leader server.
-func (c *Client) getBlockInfo(leader, fileName string) (metadata.BlockInfo, error) {
-	conn, err := grpc.Dial(leader+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// getBlockInfo gets the blocks of a version of a file from the leader server, the current version if version is 0.
+func (c *Client) getBlockInfo(leader, fileName string, version int64) (metadata.FileVersion, error) {
+	conn, err := connpool.Get(leader + ":" + c.leaderServerPort)
 	if err != nil {
-		return nil, fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
+		return metadata.FileVersion{}, fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
 	}
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*5)
 	defer cancel()
 	r, err := client.GetBlockInfo(ctx, &leaderServerProto.GetBlockInfoRequest{
 		FileName: fileName,
+		Version:  version,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block info: %v", err)
+		if isLeaderError(err) {
+			c.cache.invalidateLeader(leader)
+		}
+		return metadata.FileVersion{}, fmt.Errorf("failed to get block info: %v", err)
+	}
+	fileVersion := metadata.FileVersion{
+		Version:   r.GetVersion(),
+		BlockInfo: fromProtoBlockInfo(r.GetBlockInfo()),
+		Parity:    fromProtoBlockInfo(r.GetParity()),
+		Policy:    r.GetPolicy(),
+	}
+	c.cache.putFile(fileName, version, fileTag{epoch: r.GetEpoch(), modIndex: r.GetModIndex()}, fileVersion)
+	return fileVersion, nil
+}
+
+// toProtoBlockInfo converts the blocks in metadata to the ones in gRPC messages.
+func toProtoBlockInfo(blockInfo metadata.BlockInfo) map[int64]*leaderServerProto.BlockMeta {
+	protoBlockInfo := map[int64]*leaderServerProto.BlockMeta{}
+	for blockID, blockMeta := range blockInfo {
+		protoBlockInfo[blockID] = toProtoBlockMeta(blockMeta)
 	}
-	getBlockInforReplyBlockMeta := r.GetBlockInfo()
+	return protoBlockInfo
+}
+
+// fromProtoBlockInfo converts the blocks in gRPC messages to the ones in metadata.
+func fromProtoBlockInfo(protoBlockInfo map[int64]*leaderServerProto.BlockMeta) metadata.BlockInfo {
 	blockInfo := metadata.BlockInfo{}
-	for blockID, blockMeta := range getBlockInforReplyBlockMeta {
-		blockInfo[blockID] = metadata.BlockMeta{
-			HostNames: blockMeta.HostNames,
-			FileName:  blockMeta.FileName,
-			BlockID:   blockMeta.BlockID,
-			BlockSize: blockMeta.BlockSize,
-		}
+	for blockID, blockMeta := range protoBlockInfo {
+		blockInfo[blockID] = fromProtoBlockMeta(blockMeta)
+	}
+	return blockInfo
+}
+
+func toProtoBlockMeta(blockMeta metadata.BlockMeta) *leaderServerProto.BlockMeta {
+	return &leaderServerProto.BlockMeta{
+		HostNames:   blockMeta.HostNames,
+		FileName:    blockMeta.FileName,
+		BlockID:     blockMeta.BlockID,
+		BlockSize:   blockMeta.BlockSize,
+		Generation:  blockMeta.Generation,
+		Compression: blockMeta.Compression,
+		StoredSize:  blockMeta.StoredSize,
+	}
+}
+
+func fromProtoBlockMeta(blockMeta *leaderServerProto.BlockMeta) metadata.BlockMeta {
+	return metadata.BlockMeta{
+		HostNames:   blockMeta.GetHostNames(),
+		FileName:    blockMeta.GetFileName(),
+		BlockID:     blockMeta.GetBlockID(),
+		BlockSize:   blockMeta.GetBlockSize(),
+		Generation:  blockMeta.GetGeneration(),
+		Compression: blockMeta.GetCompression(),
+		StoredSize:  blockMeta.GetStoredSize(),
 	}
-	return blockInfo, nil
 }
 
-// getFileBlock gets a block of a file from the data server.
-func (c *Client) getFileBlock(hostname, filename string, blockID int64) ([]byte, error) {
-	conn, err := grpc.Dial(hostname+":"+c.dataServerPort, grpc.WithInitialWindowSize(1024*1024*1024),
-		grpc.WithInitialConnWindowSize(1024*1024*1024),
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+// getFileBlock gets a generation of a block of a file from the data server and verifies the checksums of each chunk.
+func (c *Client) getFileBlock(hostname string, blockMeta metadata.BlockMeta) ([]byte, error) {
+	return c.readFileBlockRange(c.context(), hostname, blockMeta, 0, 0, nil)
+}
+
+// readFileBlockRange gets length bytes from offset of a generation of a block of a file from the data server, to the end
+// of the block if length is 0, and verifies the checksums of each chunk. The range of a compressed block is in its data,
+// the frames holding it are decompressed. It stops once ctx is done, started is called once the first chunk arrives
+// unless it is nil.
+func (c *Client) readFileBlockRange(ctx context.Context, hostname string, blockMeta metadata.BlockMeta, offset int64, length int64, started func()) ([]byte, error) {
+	codec, err := compress.Parse(blockMeta.Compression)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := connpool.Get(hostname + ":" + c.dataServerPort)
 	if err != nil {
 		return nil, fmt.Errorf("cannot connect to dataServer: %v", err)
 	}
 	defer conn.Close()
 
 	client := dataServerProto.NewDataServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	ctx, cancel := context.WithTimeout(ctx, time.Second*60)
 	defer cancel()
-	stream, err := client.GetFileBlock(ctx, &dataServerProto.GetFileBlockRequest{FileName: filename, BlockID: blockID})
+	stream, err := client.GetFileBlock(ctx, &dataServerProto.GetFileBlockRequest{
+		FileName:   blockMeta.FileName,
+		BlockID:    blockMeta.BlockID,
+		Generation: blockMeta.Generation,
+		Offset:     offset,
+		Length:     length,
+		Framed:     codec.IsCompressed(),
+	})
 	if err != nil {
 		return nil, err
 	}
 	buffer := make([]byte, 0)
+	// the chunks start at the beginning of the checksum covering offset
+	var start int64 = -1
 	var fileSize int64 = 0
+	var frames *dataServerProto.GetFileBlockReply
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
 			break
 		}
+		if status.Code(err) == codes.DataLoss {
+			return nil, fmt.Errorf("%w: %v", checksum.ErrMismatch, err)
+		}
+		if status.Code(err) == codes.FailedPrecondition {
+			return nil, fmt.Errorf("%w: %v", ErrStaleBlock, err)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to receive chunk from server: %v", err)
 		}
 		chunk := req.GetChunk()
+		if start < 0 {
+			start = req.GetOffset()
+			frames = req
+			if started != nil {
+				started()
+			}
+		}
+		// a block written without checksums is not verified
+		if checksums := req.GetChecksums(); len(checksums) > 0 {
+			if err := checksum.Verify(chunk, checksums); err != nil {
+				return nil, fmt.Errorf("received corrupted chunk at offset %d: %w", start+fileSize, err)
+			}
+		}
 		fileSize += int64(len(chunk))
 		logrus.Debugf("received a chunk with size %v", len(chunk))
 		buffer = append(buffer, chunk...)
 	}
+	if start >= 0 && codec.IsCompressed() {
+		frameOffset, frameEnd := frames.GetFrameOffset(), frames.GetFrameEnd()
+		if frameOffset < start || frameOffset > frameEnd || frameEnd-start > int64(len(buffer)) {
+			return nil, fmt.Errorf("received %d bytes at offset %d for frames from %d to %d", len(buffer), start, frameOffset, frameEnd)
+		}
+		buffer, err = codec.Decode(buffer[frameOffset-start : frameEnd-start])
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to decompress block %d of file %s: %v", checksum.ErrMismatch, blockMeta.BlockID, blockMeta.FileName, err)
+		}
+		start = frames.GetFrameStart()
+	}
+	if start < 0 || start > offset || offset-start > int64(len(buffer)) {
+		return nil, fmt.Errorf("received %d bytes at offset %d for offset %d", len(buffer), start, offset)
+	}
+	buffer = buffer[offset-start:]
+	if length > 0 && int64(len(buffer)) > length {
+		buffer = buffer[:length]
+	}
 	return buffer, nil
 }
 
 // getFileOK tells the leader server that the client has got the file.
 func (c *Client) getFileOK(hostname, fileName string) {
-	conn, err := grpc.Dial(hostname+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := connpool.Get(hostname + ":" + c.leaderServerPort)
 	if err != nil {
 		logrus.Errorf("failed to get file OK: %v", err)
 		return
@@ -181,7 +308,7 @@ func (c *Client) getFileOK(hostname, fileName string) {
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*5)
 	defer cancel()
 	_, err = client.GetFileOK(ctx, &leaderServerProto.GetFileOKRequest{
 		FileName: fileName,
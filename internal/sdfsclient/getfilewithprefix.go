@@ -8,28 +8,26 @@ import (
 )
 
 func (c *Client) GetFileWithPrefix(prefix string) ([]string, error) {
-	metadata, err := c.GetMetadata()
+	matched, err := c.ListFilesWithPrefix(prefix)
 	if err != nil {
 		return nil, err
 	}
 	mutex := sync.Mutex{}
 	fileNames := []string{}
 	eg, _ := errgroup.WithContext(context.Background())
-	for fileName := range metadata.GetFileInfo() {
-		if len(fileName) >= len(prefix) && fileName[:len(prefix)] == prefix {
-			func(fileName string) {
-				eg.Go(func() error {
-					err := c.GetFile(fileName, fileName)
-					if err != nil {
-						return err
-					}
-					mutex.Lock()
-					defer mutex.Unlock()
-					fileNames = append(fileNames, fileName)
-					return nil
-				})
-			}(fileName)
-		}
+	for _, fileName := range matched {
+		func(fileName string) {
+			eg.Go(func() error {
+				err := c.GetFile(fileName, fileName)
+				if err != nil {
+					return err
+				}
+				mutex.Lock()
+				defer mutex.Unlock()
+				fileNames = append(fileNames, fileName)
+				return nil
+			})
+		}(fileName)
 	}
 	if err := eg.Wait(); err != nil {
 		return nil, err
@@ -0,0 +1,232 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/checksum"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+)
+
+const (
+	// defaultHedgePercentile is the percentile of the recent latencies waited for before hedging if none is set.
+	defaultHedgePercentile = 95
+	// defaultHedgeDelay is waited for before hedging until minHedgeSamples latencies are seen.
+	defaultHedgeDelay = time.Millisecond * 100
+	minHedgeSamples   = 10
+	// maxHedgeSamples is the number of the recent latencies kept for the percentile.
+	maxHedgeSamples = 200
+	// failureLatency is counted as the latency of a data server which fails a read.
+	failureLatency = time.Second
+)
+
+// replicaStats keeps the recent latencies to the first chunk of a block from the data servers, shared by the sessions
+// of a client. The replicas of a block are read from the local data server first, then the least loaded ones.
+type replicaStats struct {
+	mu     sync.Mutex
+	local  string
+	hedge  config.HedgedRead
+	hosts  map[string]*hostStats
+	recent []time.Duration // latencies of the recent reads from all data servers
+	next   int             // index in recent of the next latency once it is full
+}
+
+type hostStats struct {
+	latency  time.Duration // moving average of the latencies to the first chunk
+	inFlight int           // number of reads in flight
+}
+
+func newReplicaStats(local string, hedge config.HedgedRead) *replicaStats {
+	if hedge.Percentile <= 0 || hedge.Percentile > 100 {
+		hedge.Percentile = defaultHedgePercentile
+	}
+	return &replicaStats{local: local, hedge: hedge, hosts: map[string]*hostStats{}}
+}
+
+// order returns the hosts of a block in the order they are read, the local host first and then by the average latency
+// times the reads in flight. A host without latencies is tried before the others, the ties are in random order.
+func (s *replicaStats) order(hostNames []string) []string {
+	ordered := append([]string{}, hostNames...)
+	rand.Shuffle(len(ordered), func(i, j int) {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	load := func(hostName string) time.Duration {
+		host, ok := s.hosts[hostName]
+		if !ok {
+			return 0
+		}
+		return host.latency * time.Duration(host.inFlight+1)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if (ordered[i] == s.local) != (ordered[j] == s.local) {
+			return ordered[i] == s.local
+		}
+		return load(ordered[i]) < load(ordered[j])
+	})
+	return ordered
+}
+
+// hedgeDelay returns how long a read waits for the first chunk of a block before another replica is requested, 0 if
+// hedged reads are disabled.
+func (s *replicaStats) hedgeDelay() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.hedge.Enabled {
+		return 0
+	}
+	if len(s.recent) < minHedgeSamples {
+		return maxDuration(defaultHedgeDelay, s.hedge.MinDelay)
+	}
+	sorted := append([]time.Duration{}, s.recent...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	return maxDuration(sorted[int(s.hedge.Percentile/100*float64(len(sorted)-1))], s.hedge.MinDelay)
+}
+
+func (s *replicaStats) host(hostName string) *hostStats {
+	host, ok := s.hosts[hostName]
+	if !ok {
+		host = &hostStats{}
+		s.hosts[hostName] = host
+	}
+	return host
+}
+
+// start counts a read from a host in flight.
+func (s *replicaStats) start(hostName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.host(hostName).inFlight++
+}
+
+// end counts a read from a host done.
+func (s *replicaStats) end(hostName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.host(hostName).inFlight--
+}
+
+// observe adds a latency of a host, a sample is also kept for the hedge delay.
+func (s *replicaStats) observe(hostName string, latency time.Duration, sample bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	host := s.host(hostName)
+	if host.latency == 0 {
+		host.latency = latency
+	} else {
+		host.latency = (host.latency*4 + latency) / 5
+	}
+	if !sample {
+		return
+	}
+	if len(s.recent) < maxHedgeSamples {
+		s.recent = append(s.recent, latency)
+		return
+	}
+	s.recent[s.next] = latency
+	s.next = (s.next + 1) % maxHedgeSamples
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// readReplicas reads length bytes at offset of a block from its replicas, to the end of the block if length is 0. The
+// replicas are tried in the order of c.replicas. If the block has not started arriving within the hedge delay, it is
+// also requested from the next replica and the first one to finish is used; a replica which fails is replaced by the
+// next one right away. A replica which sends corrupted chunks is reported to the leader.
+func (c *Client) readReplicas(leader string, blockMeta metadata.BlockMeta, offset, length int64) ([]byte, error) {
+	hostNames := c.replicas.order(blockMeta.HostNames)
+	if len(hostNames) == 0 {
+		return nil, fmt.Errorf("no replica of block %d of file %s", blockMeta.BlockID, blockMeta.FileName)
+	}
+	ctx, cancel := context.WithCancel(c.context())
+	defer cancel()
+	type result struct {
+		hostName string
+		data     []byte
+		err      error
+	}
+	results := make(chan result, len(hostNames))
+	started := make(chan struct{}, len(hostNames))
+	next, pending := 0, 0
+	read := func() {
+		hostName := hostNames[next]
+		next++
+		pending++
+		go func() {
+			data, err := c.readReplica(ctx, hostName, blockMeta, offset, length, started)
+			results <- result{hostName: hostName, data: data, err: err}
+		}()
+	}
+	read()
+	var hedge <-chan time.Time
+	if delay := c.replicas.hedgeDelay(); delay > 0 && len(hostNames) > 1 {
+		hedge = time.After(delay)
+	}
+	var lastErr error
+	for pending > 0 {
+		select {
+		case <-started:
+			hedge = nil
+		case <-hedge:
+			hedge = nil
+			if next < len(hostNames) {
+				logrus.Debugf("Block %d of file %s has not started arriving, also getting it from data server %s", blockMeta.BlockID, blockMeta.FileName, hostNames[next])
+				read()
+			}
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				logrus.Debugf("Got block %d of file %s from data server %s", blockMeta.BlockID, blockMeta.FileName, r.hostName)
+				return r.data, nil
+			}
+			logrus.Infof("Failed to get block %d of file %s from data server %s with error %s", blockMeta.BlockID, blockMeta.FileName, r.hostName, r.err)
+			if errors.Is(r.err, checksum.ErrMismatch) {
+				c.reportBadBlock(leader, blockMeta.FileName, blockMeta.BlockID, blockMeta.Generation, r.hostName)
+			}
+			lastErr = r.err
+			if next < len(hostNames) {
+				read()
+			}
+		}
+	}
+	return nil, fmt.Errorf("failed to get block %d of file %s from all data servers %v: %w", blockMeta.BlockID, blockMeta.FileName, hostNames, lastErr)
+}
+
+// readReplica reads a range of a block from a data server and keeps the latency of the host, started is sent to once
+// the block starts arriving.
+func (c *Client) readReplica(ctx context.Context, hostName string, blockMeta metadata.BlockMeta, offset, length int64, started chan<- struct{}) ([]byte, error) {
+	c.replicas.start(hostName)
+	defer c.replicas.end(hostName)
+	begin := time.Now()
+	arrived := false
+	data, err := c.readFileBlockRange(ctx, hostName, blockMeta, offset, length, func() {
+		arrived = true
+		c.replicas.observe(hostName, time.Since(begin), true)
+		started <- struct{}{}
+	})
+	if arrived {
+		return data, err
+	}
+	if ctx.Err() != nil {
+		// the read lost to another replica, the host is at least as slow as it took
+		c.replicas.observe(hostName, time.Since(begin), false)
+	} else if err != nil {
+		c.replicas.observe(hostName, failureLatency, false)
+	}
+	return data, err
+}
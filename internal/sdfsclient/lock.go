@@ -5,128 +5,251 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
-// acquireFileReadLock gets the read lock of a file.
-func (c *Client) acquireFileReadLock(leader, fileName string) error {
-	conn, err := grpc.Dial(leader+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// fileLease is a lease of a file held by one acquire of its lock. Each acquire has its own holder at the leader, so
+// the locks taken on a file by concurrent calls of a client are separate leases which exclude each other like the
+// ones of different clients, and releasing one does not release the others.
+type fileLease struct {
+	leader   string
+	fileName string
+	holder   string
+	mode     string
+	release  func(leaderServerProto.LeaderServerClient, context.Context, *leaderServerProto.ReleaseLockRequest) error
+	done     chan bool // closed to stop renewing the lease
+	once     sync.Once
+}
+
+// leases counts the leases acquired by the clients of the process.
+var leases atomic.Int64
+
+// acquireFileReadLock gets the read lock of a file and returns the tag of the file it is granted at.
+func (c *Client) acquireFileReadLock(leader, fileName string) (*fileLease, fileTag, error) {
+	var tag fileTag
+	acquire := func(client leaderServerProto.LeaderServerClient, ctx context.Context, in *leaderServerProto.AcquireLockRequest) (*leaderServerProto.AcquireLockReply, error) {
+		r, err := client.AcquireReadLock(ctx, in)
+		if err == nil {
+			tag = fileTag{epoch: r.GetEpoch(), modIndex: r.GetModIndex()}
+		}
+		return r, err
+	}
+	release := func(client leaderServerProto.LeaderServerClient, ctx context.Context, in *leaderServerProto.ReleaseLockRequest) error {
+		_, err := client.ReleaseReadLock(ctx, in)
+		return err
+	}
+	lease, err := c.acquireFileLock(leader, fileName, "read", acquire, release)
+	return lease, tag, err
+}
+
+// acquireFileWriteLock gets the write lock of a file.
+func (c *Client) acquireFileWriteLock(leader, fileName string) (*fileLease, error) {
+	acquire := func(client leaderServerProto.LeaderServerClient, ctx context.Context, in *leaderServerProto.AcquireLockRequest) (*leaderServerProto.AcquireLockReply, error) {
+		return client.AcquireWriteLock(ctx, in)
+	}
+	release := func(client leaderServerProto.LeaderServerClient, ctx context.Context, in *leaderServerProto.ReleaseLockRequest) error {
+		_, err := client.ReleaseWriteLock(ctx, in)
+		return err
+	}
+	return c.acquireFileLock(leader, fileName, "write", acquire, release)
+}
+
+// acquireFileLock gets a lease of a file for a new holder, renews it until it is released and releases it if the
+// process is interrupted.
+func (c *Client) acquireFileLock(leader, fileName, mode string, acquire func(leaderServerProto.LeaderServerClient, context.Context, *leaderServerProto.AcquireLockRequest) (*leaderServerProto.AcquireLockReply, error), release func(leaderServerProto.LeaderServerClient, context.Context, *leaderServerProto.ReleaseLockRequest) error) (*fileLease, error) {
+	conn, err := connpool.Get(leader + ":" + c.leaderServerPort)
 	if err != nil {
-		return fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
+		return nil, fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
 	}
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
 	// TODO: acquire lock timeout
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*600)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*600)
 	defer cancel()
-	_, err = client.AcquireReadLock(ctx, &leaderServerProto.AcquireLockRequest{
+	lease := &fileLease{
+		leader:   leader,
+		fileName: fileName,
+		holder:   fmt.Sprintf("%s#%d", c.clientID, leases.Add(1)),
+		mode:     mode,
+		release:  release,
+		done:     make(chan bool),
+	}
+	r, err := acquire(client, ctx, &leaderServerProto.AcquireLockRequest{
 		FileName: fileName,
+		ClientID: lease.holder,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to acquire read lock: %v", err)
+		if isLeaderError(err) {
+			c.cache.invalidateLeader(leader)
+		}
+		return nil, fmt.Errorf("failed to acquire %s lock: %v", mode, err)
+	}
+	go c.renewLock(lease, time.Duration(r.GetLeaseDuration())*time.Millisecond)
+	releaseOnInterrupt(lease.holder, func() {
+		logrus.Infof("Release %s lock on interrupt for file %s", mode, fileName)
+		c.releaseFileLock(lease)
+	})
+	return lease, nil
+}
+
+// releaseFileLock stops renewing a lease and releases it, a lease which is already released is skipped.
+func (c *Client) releaseFileLock(lease *fileLease) error {
+	var err error
+	lease.once.Do(func() {
+		close(lease.done)
+		forgetOnInterrupt(lease.holder)
+		err = c.releaseLock(lease.leader, lease.fileName, lease.holder, lease.release)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release %s lock: %v", lease.mode, err)
 	}
-	c.fileReadLocks[leader+":"+fileName] = true
-	c.releaseFileReadLockOnInterrupt(leader, fileName)
 	return nil
 }
 
-// releaseLockOnInterrupt releases the lock on interrupt.
-func (c *Client) releaseFileReadLockOnInterrupt(leader, fileName string) {
-	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, os.Interrupt)
-	go func() {
-		<-sig
-		if _, ok := c.fileReadLocks[leader+":"+fileName]; !ok {
+// held is the release of each lock held by the clients of the process by holder. A single goroutine releases them
+// when the process is interrupted, and then exits.
+var held = struct {
+	sync.Mutex
+	once     sync.Once
+	releases map[string]func()
+}{releases: map[string]func(){}}
+
+// releaseOnInterrupt calls release if the process is interrupted before forgetOnInterrupt is called with key.
+func releaseOnInterrupt(key string, release func()) {
+	held.Lock()
+	held.releases[key] = release
+	held.Unlock()
+	held.once.Do(func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt)
+		go func() {
+			<-sig
+			held.Lock()
+			releases := []func(){}
+			for _, release := range held.releases {
+				releases = append(releases, release)
+			}
+			held.Unlock()
+			for _, release := range releases {
+				release()
+			}
 			os.Exit(1)
-		}
-		logrus.Infof("Release read lock on interrupt for file %s", fileName)
-		c.releaseFileReadLock(leader, fileName)
-		os.Exit(1)
-	}()
+		}()
+	})
 }
 
-// releaseFileReadLock gets the read lock of a file.
-func (c *Client) releaseFileReadLock(leader, fileName string) error {
-	conn, err := grpc.Dial(leader+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
-	}
-	defer conn.Close()
+// forgetOnInterrupt stops releasing the lock of key on interrupt once it is released.
+func forgetOnInterrupt(key string) {
+	held.Lock()
+	defer held.Unlock()
+	delete(held.releases, key)
+}
 
-	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
-	defer cancel()
-	_, err = client.ReleaseReadLock(ctx, &leaderServerProto.ReleaseLockRequest{
-		FileName: fileName,
+// releaseLock releases the lease of a file at the leader, or at the new leader if the leader has changed.
+func (c *Client) releaseLock(leader, fileName, holder string, release func(leaderServerProto.LeaderServerClient, context.Context, *leaderServerProto.ReleaseLockRequest) error) error {
+	err := c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		return release(client, ctx, &leaderServerProto.ReleaseLockRequest{
+			FileName: fileName,
+			ClientID: holder,
+		})
 	})
-	if err != nil {
-		return fmt.Errorf("failed to release read lock: %v", err)
+	if err == nil {
+		return nil
 	}
-	delete(c.fileReadLocks, leader+":"+fileName)
-	return nil
+	newLeader, leaderErr := c.refreshLeader()
+	if leaderErr != nil || newLeader == "" || newLeader == leader {
+		return err
+	}
+	return c.callLeader(newLeader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		return release(client, ctx, &leaderServerProto.ReleaseLockRequest{
+			FileName: fileName,
+			ClientID: holder,
+		})
+	})
 }
 
-// acquireFileWriteLock gets the read lock of a file.
-func (c *Client) acquireFileWriteLock(leader, fileName string) error {
-	conn, err := grpc.Dial(leader+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
+// renewLock renews a lease every third of the lease duration until it is released.
+// The lease is replicated, so it is renewed at the new leader if the leader has changed.
+func (c *Client) renewLock(lease *fileLease, leaseDuration time.Duration) {
+	if leaseDuration <= 0 {
+		return
 	}
-	defer conn.Close()
+	leader := lease.leader
+	ticker := time.NewTicker(leaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lease.done:
+			return
+		case <-ticker.C:
+			renew := func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+				_, err := client.RenewLock(ctx, &leaderServerProto.RenewLockRequest{
+					FileName: lease.fileName,
+					ClientID: lease.holder,
+				})
+				return err
+			}
+			err := c.callLeader(leader, renew)
+			if err != nil {
+				if newLeader, leaderErr := c.refreshLeader(); leaderErr == nil && newLeader != "" && newLeader != leader {
+					leader = newLeader
+					err = c.callLeader(leader, renew)
+				}
+			}
+			if err != nil {
+				logrus.Errorf("failed to renew lock of file %s: %v", lease.fileName, err)
+			}
+		}
+	}
+}
 
-	client := leaderServerProto.NewLeaderServerClient(conn)
-	// TODO: acquire lock timeout
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*600)
-	defer cancel()
-	_, err = client.AcquireWriteLock(ctx, &leaderServerProto.AcquireLockRequest{
-		FileName: fileName,
+// ListLocks lists the locks held on all files.
+func (c *Client) ListLocks() (string, error) {
+	leader, err := c.getLeader()
+	if err != nil {
+		return "", err
+	}
+	var r *leaderServerProto.ListLocksReply
+	err = c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		r, err = client.ListLocks(ctx, &leaderServerProto.ListLocksRequest{})
+		return err
 	})
 	if err != nil {
-		return fmt.Errorf("failed to acquire write lock: %v", err)
+		return "", fmt.Errorf("failed to list locks: %v", err)
 	}
-	c.fileWriteLocks[leader+":"+fileName] = true
-	c.releaseFileWriteLockOnInterrupt(leader, fileName)
-	return nil
-}
-
-// releaseLockOnInterrupt releases the lock on interrupt.
-func (c *Client) releaseFileWriteLockOnInterrupt(leader, fileName string) {
-	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, os.Interrupt)
-	go func() {
-		<-sig
-		if _, ok := c.fileWriteLocks[leader+":"+fileName]; !ok {
-			os.Exit(1)
+	re := ""
+	for _, lock := range r.GetLocks() {
+		expiresIn := time.Until(time.UnixMilli(lock.GetExpiresAt())).Round(time.Second)
+		holder := lock.GetClientID()
+		if lock.GetCaller() != "" {
+			holder += " (" + lock.GetCaller() + ")"
 		}
-		logrus.Infof("Release write lock on interrupt for file %s", fileName)
-		c.releaseFileWriteLock(leader, fileName)
-		os.Exit(1)
-	}()
+		re += fmt.Sprintf("%s: %s lock held by %s, expires in %v\n", lock.GetFileName(), lock.GetMode(), holder, expiresIn)
+	}
+	return re, nil
 }
 
-// releaseFileWriteLock gets the read lock of a file.
-func (c *Client) releaseFileWriteLock(leader, fileName string) error {
-	conn, err := grpc.Dial(leader+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// callLeader calls the leader server of leader through gRPC.
+func (c *Client) callLeader(leader string, call func(leaderServerProto.LeaderServerClient, context.Context) error) error {
+	conn, err := connpool.Get(leader + ":" + c.leaderServerPort)
 	if err != nil {
 		return fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
 	}
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*60)
 	defer cancel()
-	_, err = client.ReleaseWriteLock(ctx, &leaderServerProto.ReleaseLockRequest{
-		FileName: fileName,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to release write lock: %v", err)
+	err = call(client, ctx)
+	if isLeaderError(err) {
+		c.cache.invalidateLeader(leader)
 	}
-	delete(c.fileWriteLocks, leader+":"+fileName)
-	return nil
+	return err
 }
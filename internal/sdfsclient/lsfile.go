@@ -29,6 +29,9 @@ func (c *Client) LsFile(sdfsfilename string) (string, error) {
 		for _, hostName := range blockMeta.HostNames {
 			re += fmt.Sprintf("%s ", hostName)
 		}
+		if blockMeta.Compression != "" {
+			re += fmt.Sprintf("(%s, %d bytes stored as %d) ", blockMeta.Compression, blockMeta.BlockSize, blockMeta.StoredSize)
+		}
 		re += "\n"
 	}
 
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// Mkdir creates a directory and its parents in SDFS.
+func (c *Client) Mkdir(sdfsdirname string) error {
+	leader, err := c.getLeader()
+	if err != nil {
+		return err
+	}
+	err = c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		_, err := client.Mkdir(ctx, &leaderServerProto.MkdirRequest{DirName: sdfsdirname})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create directory %s: %v", sdfsdirname, err)
+	}
+	logrus.Infof("Created directory %s in SDFS", sdfsdirname)
+	return nil
+}
+
+// ListDir returns the files and directories in a directory, and whether sdfsname is a directory.
+func (c *Client) ListDir(sdfsname string) ([]*leaderServerProto.DirEntry, bool, error) {
+	leader, err := c.getLeader()
+	if err != nil {
+		return nil, false, err
+	}
+	var r *leaderServerProto.ListDirReply
+	err = c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		r, err = client.ListDir(ctx, &leaderServerProto.ListDirRequest{DirName: sdfsname})
+		return err
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot list directory %s: %v", sdfsname, err)
+	}
+	return r.GetEntries(), r.GetIsDir(), nil
+}
+
+// Stat returns the entry of a file or directory, nil if it does not exist.
+func (c *Client) Stat(sdfsname string) (*leaderServerProto.DirEntry, error) {
+	leader, err := c.getLeader()
+	if err != nil {
+		return nil, err
+	}
+	var r *leaderServerProto.StatReply
+	err = c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		r, err = client.Stat(ctx, &leaderServerProto.StatRequest{Name: sdfsname})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot stat %s: %v", sdfsname, err)
+	}
+	if !r.GetExists() {
+		return nil, nil
+	}
+	return r.GetEntry(), nil
+}
+
+// Ls lists the files and directories in a directory, or the block locations of a file.
+func (c *Client) Ls(sdfsname string) (string, error) {
+	entries, isDir, err := c.ListDir(sdfsname)
+	if err != nil {
+		return "", err
+	}
+	if !isDir {
+		re, err := c.LsFile(sdfsname)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("file %s's block location:\n%s", sdfsname, re), nil
+	}
+	re := fmt.Sprintf("directory /%s:\n", metadata.CleanPath(sdfsname))
+	for _, entry := range entries {
+		if entry.GetIsDir() {
+			re += fmt.Sprintf("%s/\n", entry.GetName())
+		} else if entry.GetStoredSize() != entry.GetSize() {
+			// a compressed file, the size of the data and the size stored for each replica
+			re += fmt.Sprintf("%s\t%d\t%d stored\n", entry.GetName(), entry.GetSize(), entry.GetStoredSize())
+		} else {
+			re += fmt.Sprintf("%s\t%d\n", entry.GetName(), entry.GetSize())
+		}
+	}
+	return re, nil
+}
+
+// ListFilesWithPrefix returns the paths of the files in a directory whose names start with the prefix, e.g. "dir/out_"
+// matches "dir/out_1" and "dir/out_2". Only the directory of the prefix is listed.
+func (c *Client) ListFilesWithPrefix(prefix string) ([]string, error) {
+	dir, base := path.Split(prefix)
+	entries, isDir, err := c.ListDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !isDir {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	fileNames := []string{}
+	for _, entry := range entries {
+		if !entry.GetIsDir() && strings.HasPrefix(entry.GetName(), base) {
+			fileNames = append(fileNames, path.Join(metadata.CleanPath(dir), entry.GetName()))
+		}
+	}
+	return fileNames, nil
+}
+
+// Rename atomically moves a file or directory in SDFS, an existing file at the new path is replaced.
+func (c *Client) Rename(sdfsname, newsdfsname string) error {
+	leader, err := c.getLeader()
+	if err != nil {
+		return err
+	}
+	err = c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		_, err := client.Rename(ctx, &leaderServerProto.RenameRequest{
+			FileName:    sdfsname,
+			NewFileName: newsdfsname,
+		})
+		return err
+	})
+	c.cache.invalidatePath(sdfsname)
+	c.cache.invalidatePath(newsdfsname)
+	if err != nil {
+		return fmt.Errorf("cannot rename %s to %s: %v", sdfsname, newsdfsname, err)
+	}
+	logrus.Infof("Renamed %s to %s in SDFS", sdfsname, newsdfsname)
+	return nil
+}
+
+// DelRecursive deletes a file, or a directory and everything in it from SDFS.
+func (c *Client) DelRecursive(sdfsname string) error {
+	_, isDir, err := c.ListDir(sdfsname)
+	if err != nil {
+		return err
+	}
+	if !isDir {
+		return c.DelFile(sdfsname)
+	}
+	leader, err := c.getLeader()
+	if err != nil {
+		return err
+	}
+	err = c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		_, err := client.DelFile(ctx, &leaderServerProto.DelFileRequest{
+			FileName:  sdfsname,
+			Recursive: true,
+		})
+		return err
+	})
+	c.cache.invalidatePath(sdfsname)
+	if err != nil {
+		return fmt.Errorf("cannot delete %s: %v", sdfsname, err)
+	}
+	logrus.Infof("Deleted %s from SDFS", sdfsname)
+	return nil
+}
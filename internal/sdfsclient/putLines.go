@@ -2,25 +2,36 @@ package client
 
 import (
 	"fmt"
-	"os"
+	"io"
+	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
+// PutLines puts the lines as a new version of a file to SDFS with retry, each line ends with a newline.
 func (c *Client) PutLines(lines []string, fileName string) error {
-	// put the lines into a temp file
-	tempFileName := fmt.Sprintf("%s.temp", fileName)
-	file, err := os.Create(tempFileName)
+	for i := 0; i < 5; i++ {
+		err := c.putLines(lines, fileName)
+		if err == nil {
+			return nil
+		}
+		logrus.Infof("Put lines to %s failed with error %v, retrying...", fileName, err)
+		time.Sleep(2 * time.Second)
+	}
+	return fmt.Errorf("put lines to %s failed", fileName)
+}
+
+// putLines streams the lines to a new version of a file.
+func (c *Client) putLines(lines []string, fileName string) error {
+	writer, err := c.Create(fileName)
 	if err != nil {
 		return err
 	}
-	defer os.Remove(tempFileName)
 	for _, line := range lines {
-		_, err = file.WriteString(line + "\n")
-		if err != nil {
+		if _, err := io.WriteString(writer, line+"\n"); err != nil {
+			writer.Close()
 			return err
 		}
 	}
-	file.Close()
-
-	// put the temp file into sdfs
-	return c.PutFileWithRetry(tempFileName, fileName)
+	return writer.Close()
 }
@@ -8,28 +8,45 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/checksum"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/compress"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	dataServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
 	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var CHUNK_SIZE = 3 * 1024 * 1024
 
+// PutOptions are the settings of a file in SDFS, the ones set for the file or the defaults in config are used if 0.
+type PutOptions struct {
+	MaxVersions int    // versions kept including the new one
+	Replication int    // replicas of each block
+	Policy      string // storage policy, "replication" or "RS-<data shards>-<parity shards>"
+	Compression string // codec of the blocks, "none", "snappy", "zstd" or "gzip"
+}
+
 // PutFile sends a file to the SDFS.
 func (c *Client) PutFile(localfilename, sdfsfilename string) error {
+	return c.PutFileWithOptions(localfilename, sdfsfilename, PutOptions{})
+}
+
+// PutFileWithVersions sends a new version of a file to the SDFS and keeps the last maxVersions versions of it,
+// the number set for the file or the default in config is used if maxVersions is 0.
+func (c *Client) PutFileWithVersions(localfilename, sdfsfilename string, maxVersions int) error {
+	return c.PutFileWithOptions(localfilename, sdfsfilename, PutOptions{MaxVersions: maxVersions})
+}
+
+// PutFileWithOptions sends a new version of a file to the SDFS with the settings of the file in options.
+func (c *Client) PutFileWithOptions(localfilename, sdfsfilename string, options PutOptions) error {
 	localfile, err := os.Open(localfilename)
 	if err != nil {
 		return fmt.Errorf("cannot open local file %s: %v", localfilename, err)
 	}
 	defer localfile.Close()
-	fileInfo, err := localfile.Stat()
-	if err != nil {
-		return fmt.Errorf("cannot get local file %s info: %v", localfilename, err)
-	}
 
 	// get leader, ask leader where to store the file, send the file to the data server
 	leader, err := c.getLeader()
@@ -39,18 +56,42 @@ func (c *Client) PutFile(localfilename, sdfsfilename string) error {
 	logrus.Infof("Leader is %s", leader)
 
 	// acquire write lock
-	err = c.acquireFileWriteLock(leader, sdfsfilename)
+	lease, err := c.acquireFileWriteLock(leader, sdfsfilename)
 	if err != nil {
 		return err
 	}
-	defer c.releaseFileWriteLock(leader, sdfsfilename)
+	defer c.releaseFileLock(lease)
 	logrus.Infof("Acquired write lock of file %s", sdfsfilename)
+	return c.putFile(leader, localfile, sdfsfilename, options)
+}
 
-	blockInfo, err := c.putBlockInfo(leader, sdfsfilename, fileInfo.Size())
+// putFile sends a local file as a new version of a file in SDFS, the caller must hold the write lock of the file.
+func (c *Client) putFile(leader string, localfile *os.File, sdfsfilename string, options PutOptions) error {
+	localfilename := localfile.Name()
+	fileInfo, err := localfile.Stat()
+	if err != nil {
+		return fmt.Errorf("cannot get local file %s info: %v", localfilename, err)
+	}
+	fileVersion, err := c.putBlockInfo(leader, sdfsfilename, fileInfo.Size(), options)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Got blockInfo %+v", fileVersion.BlockInfo)
+	policy, err := erasure.ParsePolicy(fileVersion.Policy)
 	if err != nil {
 		return err
 	}
-	logrus.Infof("Got blockInfo %+v", blockInfo)
+	if policy.IsErasureCoded() {
+		if err := c.putStripes(localfile, fileVersion, policy); err != nil {
+			return fmt.Errorf("Failed to put file %s to SDFS: %w", sdfsfilename, err)
+		}
+		if err := c.putFileOK(leader, sdfsfilename, fileVersion, options); err != nil {
+			return fmt.Errorf("Failed to put file %s OK to leader %s: %w", sdfsfilename, leader, err)
+		}
+		logrus.Infof("Put all stripes of file %s to SDFS", sdfsfilename)
+		return nil
+	}
+	blockInfo := fileVersion.BlockInfo
 
 	writeSem := semaphore.NewWeighted(10)
 	eg, _ := errgroup.WithContext(context.Background())
@@ -70,16 +111,17 @@ func (c *Client) PutFile(localfilename, sdfsfilename string) error {
 					return fmt.Errorf("cannot read local file %s: %v", localfilename, err)
 				}
 				logrus.Infof("Read block %d of file %s with size %d", blockID, localfilename, n)
-				for _, hostname := range blockInfo[blockID].HostNames {
-					// send the block to the data server
-					_, err = c.putFileBlock(hostname, sdfsfilename, blockID, block[:n])
-					if err != nil {
-						return fmt.Errorf("Failed to put block %d of file %s to data server %s with error %w", blockID, sdfsfilename, hostname, err)
-					}
-					logrus.Infof("Put block %d of file %s with size %d to data server %s", blockID, sdfsfilename, n, hostname)
-				}
 				blockMeta := blockInfo[blockID]
-				blockMeta.BlockSize = int64(n)
+				stored, err := compressBlock(&blockMeta, fileVersion.Compression, block[:n])
+				if err != nil {
+					return err
+				}
+				// send the block to the data servers
+				hostNames, err := c.putFileBlockToReplicas(blockMeta.HostNames, blockMeta.FileName, blockID, blockMeta.Generation, stored)
+				if err != nil {
+					return err
+				}
+				blockMeta.HostNames = hostNames
 				blockInfo[blockID] = blockMeta
 				return nil
 			})
@@ -88,7 +130,7 @@ func (c *Client) PutFile(localfilename, sdfsfilename string) error {
 	if err := eg.Wait(); err != nil {
 		return fmt.Errorf("Failed to put file %s to SDFS: %w", sdfsfilename, err)
 	}
-	err = c.putFileOK(leader, sdfsfilename, blockInfo)
+	err = c.putFileOK(leader, sdfsfilename, fileVersion, options)
 	if err != nil {
 		return fmt.Errorf("Failed to put file %s OK to leader %s: %w", sdfsfilename, leader, err)
 	}
@@ -96,105 +138,172 @@ func (c *Client) PutFile(localfilename, sdfsfilename string) error {
 	return nil
 }
 
-// putBlockInfo gets the block info for putting a file from the leader server.
-func (c *Client) putBlockInfo(leader, fileName string, fileSize int64) (metadata.BlockInfo, error) {
-	conn, err := grpc.Dial(leader+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// putBlockInfo gets the blocks for putting a file with the replication, policy and compression in options from the
+// leader server.
+func (c *Client) putBlockInfo(leader, fileName string, fileSize int64, options PutOptions) (metadata.FileVersion, error) {
+	conn, err := connpool.Get(leader + ":" + c.leaderServerPort)
 	if err != nil {
-		return nil, fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
+		return metadata.FileVersion{}, fmt.Errorf("cannot connect to %s leaderServer: %v", leader, err)
 	}
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*5)
 	defer cancel()
 	r, err := client.PutBlockInfo(ctx, &leaderServerProto.PutBlockInfoRequest{
-		FileName: fileName,
-		FileSize: fileSize,
+		FileName:    fileName,
+		FileSize:    fileSize,
+		Replication: int32(options.Replication),
+		Policy:      options.Policy,
+		Compression: options.Compression,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block info: %v", err)
+		return metadata.FileVersion{}, fmt.Errorf("failed to get block info: %v", err)
+	}
+	return metadata.FileVersion{
+		BlockInfo:   fromProtoBlockInfo(r.GetBlockInfo()),
+		Parity:      fromProtoBlockInfo(r.GetParity()),
+		Policy:      r.GetPolicy(),
+		Compression: r.GetCompression(),
+	}, nil
+}
+
+// compressBlock compresses the data of a block with codec into the frames stored on the data servers, and sets the
+// codec and the sizes of the data and of the frames in blockMeta. The data is stored as is if codec is empty.
+func compressBlock(blockMeta *metadata.BlockMeta, codec string, data []byte) ([]byte, error) {
+	compression, err := compress.Parse(codec)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := compression.Encode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress block %d of file %s: %v", blockMeta.BlockID, blockMeta.FileName, err)
+	}
+	blockMeta.BlockSize = int64(len(data))
+	blockMeta.Compression = compression.String()
+	blockMeta.StoredSize = 0
+	if compression.IsCompressed() {
+		blockMeta.StoredSize = int64(len(stored))
+	}
+	return stored, nil
+}
+
+// putFileBlockToReplicas sends a block to the data servers of its replicas and returns the ones which stored it.
+// In pipeline mode the block is sent to the first data server, which forwards it down the rest of hostNames.
+// A broken pipeline is rebuilt from the data servers which have not stored the block, excluding the failed one.
+func (c *Client) putFileBlockToReplicas(hostNames []string, fileName string, blockID int64, generation int64, data []byte) ([]string, error) {
+	if !c.pipelineWrite {
+		for _, hostname := range hostNames {
+			_, err := c.putFileBlock(hostname, fileName, blockID, generation, data, nil)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to put block %d of file %s to data server %s with error %w", blockID, fileName, hostname, err)
+			}
+			logrus.Infof("Put block %d of file %s with size %d to data server %s", blockID, fileName, len(data), hostname)
+		}
+		return hostNames, nil
 	}
-	putBlockInfoReplyBlockMeta := r.GetBlockInfo()
-	blockInfo := metadata.BlockInfo{}
-	for blockID, blockMeta := range putBlockInfoReplyBlockMeta {
-		blockInfo[blockID] = metadata.BlockMeta{
-			HostNames: blockMeta.HostNames,
-			FileName:  blockMeta.FileName,
-			BlockID:   blockMeta.BlockID,
-			BlockSize: blockMeta.BlockSize,
+	stored := []string{}
+	remaining := hostNames
+	for len(remaining) > 0 {
+		replicas, err := c.putFileBlock(remaining[0], fileName, blockID, generation, data, remaining[1:])
+		if err != nil {
+			logrus.Errorf("Failed to put block %d of file %s through pipeline %v with error %v", blockID, fileName, remaining, err)
+			replicas = 0
 		}
+		if int(replicas) > len(remaining) {
+			replicas = int32(len(remaining))
+		}
+		stored = append(stored, remaining[:replicas]...)
+		if int(replicas) == len(remaining) {
+			break
+		}
+		logrus.Warnf("Pipeline of block %d of file %s is broken at data server %s, excluding it", blockID, fileName, remaining[replicas])
+		remaining = remaining[replicas+1:]
+	}
+	if len(stored) == 0 {
+		return nil, fmt.Errorf("Failed to put block %d of file %s to any data server of %v", blockID, fileName, hostNames)
 	}
-	return blockInfo, nil
+	logrus.Infof("Put block %d of file %s with size %d to data servers %v", blockID, fileName, len(data), stored)
+	return stored, nil
 }
 
-// putFileBlock sends the file block to the data server.
-func (c *Client) putFileBlock(hostname, fileName string, blockID int64, data []byte) (bool, error) {
-	conn, err := grpc.Dial(hostname+":"+c.dataServerPort, []grpc.DialOption{
-		grpc.WithInitialWindowSize(1024 * 1024 * 1024),
-		grpc.WithInitialConnWindowSize(1024 * 1024 * 1024),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	}...)
+// putFileBlock sends a generation of the file block to the data server, which forwards it to the data servers in pipeline.
+// It returns the number of data servers which stored the block, starting from hostname.
+func (c *Client) putFileBlock(hostname, fileName string, blockID int64, generation int64, data []byte, pipeline []string) (int32, error) {
+	conn, err := connpool.Get(hostname + ":" + c.dataServerPort)
 
 	if err != nil {
-		return false, fmt.Errorf("cannot connect to dataServer: %v", err)
+		return 0, fmt.Errorf("cannot connect to dataServer: %v", err)
 	}
 	defer conn.Close()
 
 	client := dataServerProto.NewDataServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*60)
 	defer cancel()
 	stream, err := client.PutFileBlock(ctx)
 	if err != nil {
-		return false, err
+		return 0, err
 	}
 	var fileSize int64 = 0
+	// an empty block is sent as one empty chunk
 	for {
-		if len(data) == 0 {
-			break
-		}
 		chunk := []byte(data)
 		if len(chunk) > CHUNK_SIZE {
 			chunk = chunk[:CHUNK_SIZE]
 		}
-		if err := stream.Send(&dataServerProto.PutFileBlockRequest{
-			FileName: fileName,
-			BlockID:  blockID,
-			Chunk:    chunk,
-		}); err != nil {
-			return false, err
+		req := &dataServerProto.PutFileBlockRequest{
+			FileName:   fileName,
+			BlockID:    blockID,
+			Generation: generation,
+			Chunk:      chunk,
+			Checksums:  checksum.Compute(chunk),
+		}
+		if fileSize == 0 {
+			req.Pipeline = pipeline
+		}
+		err := stream.Send(req)
+		if err == io.EOF {
+			// the data server closed the stream, its error is returned by CloseAndRecv
+			break
+		}
+		if err != nil {
+			return 0, err
 		}
 		fileSize += int64(len(chunk))
 		data = data[len(chunk):]
+		if len(data) == 0 {
+			break
+		}
 	}
 	r, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, err
+	}
 	logrus.Debugf("sent file %s block %d with size %d", fileName, blockID, fileSize)
-	return r.GetOk(), err
+	return r.GetReplicas(), nil
 }
 
 // putFileOK tells the leader server that the client has put the file.
-func (c *Client) putFileOK(hostname, fileName string, blockInfo metadata.BlockInfo) error {
-	conn, err := grpc.Dial(hostname+":"+c.leaderServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func (c *Client) putFileOK(hostname, fileName string, fileVersion metadata.FileVersion, options PutOptions) error {
+	conn, err := connpool.Get(hostname + ":" + c.leaderServerPort)
 	if err != nil {
 		return fmt.Errorf("cannot connect to %s leaderServer: %v", hostname, err)
 	}
 	defer conn.Close()
 
 	client := leaderServerProto.NewLeaderServerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*5)
 	defer cancel()
-
-	putFileOKRequestBlockInfo := map[int64]*leaderServerProto.BlockMeta{}
-	for blockID, blockMeta := range blockInfo {
-		putFileOKRequestBlockInfo[blockID] = &leaderServerProto.BlockMeta{
-			HostNames: blockMeta.HostNames,
-			FileName:  blockMeta.FileName,
-			BlockID:   blockMeta.BlockID,
-			BlockSize: blockMeta.BlockSize,
-		}
-	}
+	// the version may be committed even if the call fails
+	defer c.cache.invalidatePath(fileName)
 	_, err = client.PutFileOK(ctx, &leaderServerProto.PutFileOKRequest{
-		FileName:  fileName,
-		BlockInfo: putFileOKRequestBlockInfo,
+		FileName:    fileName,
+		BlockInfo:   toProtoBlockInfo(fileVersion.BlockInfo),
+		MaxVersions: int32(options.MaxVersions),
+		Replication: int32(options.Replication),
+		Parity:      toProtoBlockInfo(fileVersion.Parity),
+		Policy:      fileVersion.Policy,
+		Compression: fileVersion.Compression,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to put file ok of %s: %v", fileName, err)
@@ -9,8 +9,13 @@ import (
 
 // PutFileWithRetry puts a file to SDFS with retry
 func (c *Client) PutFileWithRetry(localfilename, sdfsfilename string) error {
+	return c.PutFileWithOptionsAndRetry(localfilename, sdfsfilename, PutOptions{})
+}
+
+// PutFileWithOptionsAndRetry puts a new version of a file to SDFS with retry, see PutFileWithOptions.
+func (c *Client) PutFileWithOptionsAndRetry(localfilename, sdfsfilename string, options PutOptions) error {
 	for i := 0; i < 5; i++ {
-		err := c.PutFile(localfilename, sdfsfilename)
+		err := c.PutFileWithOptions(localfilename, sdfsfilename, options)
 		if err == nil {
 			return nil
 		}
@@ -0,0 +1,283 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+// readSize is the size of each read of head and tail while looking for the lines.
+const readSize = 64 * 1024
+
+// rangeReader reads byte ranges of the current version of a file from the data servers.
+type rangeReader struct {
+	c       *Client
+	leader  string
+	lease   *fileLease // read lease of the file, held until close
+	name    string
+	mu      sync.Mutex // guards version and cached
+	version metadata.FileVersion
+	cached  bool // whether version is from the cache, it is got again once if a block cannot be read
+	policy  erasure.Policy
+	size    int64
+}
+
+// ReadRange reads length bytes at offset of a file in SDFS, fewer if the file ends first. A negative offset counts from
+// the end of the file, and a negative length reads to the end of the file. Only the blocks in the range are fetched.
+func (c *Client) ReadRange(sdfsfilename string, offset, length int64) ([]byte, error) {
+	var data []byte
+	err := c.withRangeReader(sdfsfilename, func(r *rangeReader) error {
+		if offset < 0 {
+			offset += r.size
+			if offset < 0 {
+				offset = 0
+			}
+		}
+		if length < 0 {
+			length = r.size
+		}
+		var err error
+		data, err = r.readRange(offset, length)
+		return err
+	})
+	return data, err
+}
+
+// ReadAt reads len(p) bytes at offset of a file in SDFS into p like io.ReaderAt, it returns io.EOF if the file ends
+// before p is filled.
+func (c *Client) ReadAt(sdfsfilename string, p []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset %d", offset)
+	}
+	data, err := c.ReadRange(sdfsfilename, offset, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	n := copy(p, data)
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Head reads the first lines of a file in SDFS.
+func (c *Client) Head(sdfsfilename string, lines int) ([]byte, error) {
+	var data []byte
+	err := c.withRangeReader(sdfsfilename, func(r *rangeReader) error {
+		data = []byte{}
+		for offset := int64(0); offset < r.size && bytes.Count(data, []byte("\n")) < lines; offset += readSize {
+			piece, err := r.readRange(offset, readSize)
+			if err != nil {
+				return err
+			}
+			data = append(data, piece...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	end := 0
+	for i := 0; i < lines; i++ {
+		next := bytes.IndexByte(data[end:], '\n')
+		if next < 0 {
+			return data, nil
+		}
+		end += next + 1
+	}
+	return data[:end], nil
+}
+
+// Tail reads the last lines of a file in SDFS, a newline at the end of the file does not start a line.
+func (c *Client) Tail(sdfsfilename string, lines int) ([]byte, error) {
+	var data []byte
+	err := c.withRangeReader(sdfsfilename, func(r *rangeReader) error {
+		data = []byte{}
+		// lines newlines before the last line are needed, plus the one at the end of the file
+		for end := r.size; end > 0 && bytes.Count(data, []byte("\n")) <= lines; end -= readSize {
+			offset := end - readSize
+			if offset < 0 {
+				offset = 0
+			}
+			piece, err := r.readRange(offset, end-offset)
+			if err != nil {
+				return err
+			}
+			data = append(piece, data...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lines <= 0 {
+		return []byte{}, nil
+	}
+	start := len(data)
+	if start > 0 && data[start-1] == '\n' {
+		start--
+	}
+	for i := 0; i < lines; i++ {
+		prev := bytes.LastIndexByte(data[:start], '\n')
+		if prev < 0 {
+			return data, nil
+		}
+		start = prev
+	}
+	return data[start+1:], nil
+}
+
+// withRangeReader holds the read lock of a file while reading ranges of its current version.
+func (c *Client) withRangeReader(sdfsfilename string, read func(r *rangeReader) error) error {
+	r, err := c.openRangeReader(sdfsfilename)
+	if err != nil {
+		return err
+	}
+	defer r.close()
+	return read(r)
+}
+
+// openRangeReader acquires the read lock of a file and gets its current version, the lock is held until close.
+func (c *Client) openRangeReader(sdfsfilename string) (*rangeReader, error) {
+	leader, err := c.getLeader()
+	if err != nil {
+		return nil, err
+	}
+	lease, tag, err := c.acquireFileReadLock(leader, sdfsfilename)
+	if err != nil {
+		return nil, err
+	}
+	version, cached, err := c.getCachedBlockInfo(leader, sdfsfilename, 0, tag)
+	if err != nil {
+		c.releaseFileLock(lease)
+		return nil, err
+	}
+	policy, err := erasure.ParsePolicy(version.Policy)
+	if err != nil {
+		c.releaseFileLock(lease)
+		return nil, err
+	}
+	r := &rangeReader{c: c, leader: leader, lease: lease, name: sdfsfilename, version: version, cached: cached, policy: policy}
+	for blockID, blockMeta := range version.BlockInfo {
+		if end := blockID*c.blockSize + blockMeta.BlockSize; end > r.size {
+			r.size = end
+		}
+	}
+	return r, nil
+}
+
+// close releases the read lock of the file.
+func (r *rangeReader) close() error {
+	return r.c.releaseFileLock(r.lease)
+}
+
+// block returns the block of the version being read.
+func (r *rangeReader) block(blockID int64) metadata.BlockMeta {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.version.BlockInfo[blockID]
+}
+
+// refresh gets the version from the leader again if it is from the cache, it returns whether the version is changed.
+func (r *rangeReader) refresh() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.cached {
+		return false
+	}
+	r.cached = false
+	r.c.cache.invalidateFile(r.name, 0)
+	version, err := r.c.getBlockInfo(r.leader, r.name, 0)
+	if err != nil {
+		logrus.Errorf("failed to get blockInfo of file %s again: %v", r.name, err)
+		return false
+	}
+	r.version = version
+	return true
+}
+
+// readBlock reads length bytes at offset of a block, the version is got again if the block cannot be read with the
+// cached one.
+func (r *rangeReader) readBlock(blockID, offset, length int64) ([]byte, error) {
+	data, err := r.readBlockRange(r.block(blockID), offset, length)
+	if err != nil && r.refresh() {
+		logrus.Infof("Failed to get block %d of file %s with cached blockInfo, retrying: %v", blockID, r.name, err)
+		return r.readBlockRange(r.block(blockID), offset, length)
+	}
+	return data, err
+}
+
+// readRange reads length bytes at offset of the file from the blocks overlapping the range, fewer if the file ends first.
+func (r *rangeReader) readRange(offset, length int64) ([]byte, error) {
+	if offset >= r.size || length <= 0 {
+		return []byte{}, nil
+	}
+	if offset+length > r.size {
+		length = r.size - offset
+	}
+	data := make([]byte, length)
+	getSem := semaphore.NewWeighted(10)
+	eg, _ := errgroup.WithContext(context.Background())
+	for blockID := offset / r.c.blockSize; blockID*r.c.blockSize < offset+length; blockID++ {
+		func(blockID int64) {
+			eg.Go(func() error {
+				err := getSem.Acquire(context.Background(), 1)
+				defer getSem.Release(1)
+				if err != nil {
+					return err
+				}
+				blockStart := blockID * r.c.blockSize
+				from := offset - blockStart
+				if from < 0 {
+					from = 0
+				}
+				to := offset + length - blockStart
+				if to > r.c.blockSize {
+					to = r.c.blockSize
+				}
+				piece, err := r.readBlock(blockID, from, to-from)
+				if err != nil {
+					return err
+				}
+				copy(data[blockStart+from-offset:], piece)
+				return nil
+			})
+		}(blockID)
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// readBlockRange reads length bytes at offset of a block from its replicas with hedging, a block of an erasure-coded file
+// is reconstructed if none is available.
+func (r *rangeReader) readBlockRange(blockMeta metadata.BlockMeta, offset, length int64) ([]byte, error) {
+	data, err := r.c.readReplicas(r.leader, blockMeta, offset, length)
+	if err == nil || !r.policy.IsErasureCoded() {
+		return data, err
+	}
+	r.mu.Lock()
+	version := r.version
+	r.mu.Unlock()
+	data, err = r.c.reconstructFileBlock(r.leader, version, r.policy, blockMeta.BlockID)
+	if err != nil {
+		return nil, err
+	}
+	if offset > int64(len(data)) {
+		return []byte{}, nil
+	}
+	data = data[offset:]
+	if int64(len(data)) > length {
+		data = data[:length]
+	}
+	return data, nil
+}
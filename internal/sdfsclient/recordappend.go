@@ -0,0 +1,172 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
+	dataServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+	"golang.org/x/sync/errgroup"
+)
+
+// AppendRecord appends a record to a file in SDFS without the write lock and returns the offset of the record in the
+// file, the file is created if it does not exist. The leader assigns the offset, so the records appended by clients at
+// the same time are never interleaved. The record is at most the block size.
+func (c *Client) AppendRecord(sdfsfilename string, record []byte) (int64, error) {
+	if len(record) == 0 {
+		return 0, fmt.Errorf("cannot append an empty record to file %s", sdfsfilename)
+	}
+	leader, err := c.getLeader()
+	if err != nil {
+		return 0, err
+	}
+	var reply *leaderServerProto.AppendRecordInfoReply
+	err = c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		reply, err = client.AppendRecordInfo(ctx, &leaderServerProto.AppendRecordInfoRequest{
+			FileName: sdfsfilename,
+			Size:     int64(len(record)),
+		})
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to reserve record of file %s: %v", sdfsfilename, err)
+	}
+
+	// each piece of the record is written to every replica of its block
+	eg, _ := errgroup.WithContext(context.Background())
+	data := record
+	for _, block := range reply.GetBlocks() {
+		piece := data[:block.GetSize()]
+		data = data[block.GetSize():]
+		for _, hostName := range block.GetBlockMeta().GetHostNames() {
+			func(hostName string, block *leaderServerProto.RecordBlock) {
+				eg.Go(func() error {
+					return c.appendRecordToBlock(hostName, block, piece)
+				})
+			}(hostName, block)
+		}
+	}
+	writeErr := eg.Wait()
+	err = c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		_, err := client.AppendRecordOK(ctx, &leaderServerProto.AppendRecordOKRequest{
+			FileName: sdfsfilename,
+			Offset:   reply.GetOffset(),
+			Failed:   writeErr != nil,
+		})
+		return err
+	})
+	c.cache.invalidatePath(sdfsfilename)
+	if writeErr != nil {
+		return 0, fmt.Errorf("failed to append record to file %s: %v", sdfsfilename, writeErr)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to commit record at offset %d of file %s: %v", reply.GetOffset(), sdfsfilename, err)
+	}
+	logrus.Infof("Appended record with size %d at offset %d of file %s", len(record), reply.GetOffset(), sdfsfilename)
+	return reply.GetOffset(), nil
+}
+
+// appendRecordToBlock sends a piece of a record to a replica of its block, the data server appends it once the records
+// before it are appended.
+func (c *Client) appendRecordToBlock(hostname string, block *leaderServerProto.RecordBlock, data []byte) error {
+	conn, err := connpool.Get(hostname + ":" + c.dataServerPort)
+	if err != nil {
+		return fmt.Errorf("cannot connect to dataServer: %v", err)
+	}
+	defer conn.Close()
+
+	client := dataServerProto.NewDataServerClient(conn)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*60)
+	defer cancel()
+	stream, err := client.AppendRecord(ctx)
+	if err != nil {
+		return err
+	}
+	blockMeta := block.GetBlockMeta()
+	for first := true; first || len(data) > 0; first = false {
+		chunk := data
+		if len(chunk) > CHUNK_SIZE {
+			chunk = chunk[:CHUNK_SIZE]
+		}
+		req := &dataServerProto.AppendRecordRequest{Chunk: chunk}
+		if first {
+			req.FileName = blockMeta.GetFileName()
+			req.BlockID = blockMeta.GetBlockID()
+			req.Generation = blockMeta.GetGeneration()
+			req.Offset = block.GetOffset()
+			req.BaseGeneration = block.GetBaseGeneration()
+			req.BaseSize = block.GetBaseSize()
+		}
+		err := stream.Send(req)
+		if err == io.EOF {
+			// the data server closed the stream, its error is returned by CloseAndRecv
+			break
+		}
+		if err != nil {
+			return err
+		}
+		data = data[len(chunk):]
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return fmt.Errorf("failed to append record to block %d of file %s on data server %s: %v", blockMeta.GetBlockID(), blockMeta.GetFileName(), hostname, err)
+	}
+	return nil
+}
+
+// AppendRecordsWithRetry appends a local file to a file in SDFS as records of whole lines, nothing is appended if a line
+// is longer than the block size. Each record is retried, so a record may be appended more than once but is never
+// interleaved with the records of other clients.
+func (c *Client) AppendRecordsWithRetry(localfilename, sdfsfilename string) error {
+	data, err := os.ReadFile(localfilename)
+	if err != nil {
+		return fmt.Errorf("cannot read local file %s: %v", localfilename, err)
+	}
+	records, err := splitRecords(data, c.blockSize)
+	if err != nil {
+		return fmt.Errorf("cannot append local file %s to %s as records: %v", localfilename, sdfsfilename, err)
+	}
+	for _, record := range records {
+		if err := c.appendRecordWithRetry(sdfsfilename, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) appendRecordWithRetry(sdfsfilename string, record []byte) error {
+	for i := 0; i < 5; i++ {
+		_, err := c.AppendRecord(sdfsfilename, record)
+		if err == nil {
+			return nil
+		}
+		logrus.Errorf("append record to %s failed: %v", sdfsfilename, err)
+		time.Sleep(2 * time.Second)
+	}
+	return fmt.Errorf("append record to %s failed", sdfsfilename)
+}
+
+// splitRecords splits data into records of at most size bytes which end at a newline. A line longer than size is an
+// error, since its pieces would be separate records which the records of other clients may be appended between.
+func splitRecords(data []byte, size int64) ([][]byte, error) {
+	records := [][]byte{}
+	for offset := 0; len(data) > 0; {
+		n := len(data)
+		if int64(n) > size {
+			i := bytes.LastIndexByte(data[:size], '\n')
+			if i < 0 {
+				return nil, fmt.Errorf("line at offset %d is longer than the block size %d", offset, size)
+			}
+			n = i + 1
+		}
+		records = append(records, data[:n])
+		data = data[n:]
+		offset += n
+	}
+	return records, nil
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// SetReplication changes the number of replicas of each block of a file in SDFS, the leader adds or removes the
+// replicas in the background.
+func (c *Client) SetReplication(sdfsfilename string, replication int) error {
+	leader, err := c.getLeader()
+	if err != nil {
+		return err
+	}
+	err = c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		_, err := client.SetReplication(ctx, &leaderServerProto.SetReplicationRequest{
+			FileName:    sdfsfilename,
+			Replication: int32(replication),
+		})
+		return err
+	})
+	c.cache.invalidatePath(sdfsfilename)
+	if err != nil {
+		return fmt.Errorf("cannot set replication of file %s: %v", sdfsfilename, err)
+	}
+	logrus.Infof("Set replication of file %s to %d", sdfsfilename, replication)
+	return nil
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
+	dataServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver/proto"
+)
+
+// ScrubStatus lists the scrub progress and the corrupted or unexpected blocks found on the data servers.
+func (c *Client) ScrubStatus(hostnames []string) (string, error) {
+	re := ""
+	for _, hostname := range hostnames {
+		r, err := c.getScrubStatus(hostname)
+		if err != nil {
+			re += fmt.Sprintf("%s: %v\n", hostname, err)
+			continue
+		}
+		progress := "idle"
+		if r.GetRunning() {
+			progress = fmt.Sprintf("scrubbing %d/%d blocks", r.GetScannedBlocks(), r.GetTotalBlocks())
+		}
+		re += fmt.Sprintf("%s: %s, %d passes, %d blocks with %d bytes scanned", hostname, progress, r.GetPasses(), r.GetScannedBlocks(), r.GetScannedBytes())
+		if r.GetFinishedAt() != 0 {
+			re += fmt.Sprintf(", last pass finished at %s", time.UnixMilli(r.GetFinishedAt()).Format(time.DateTime))
+		}
+		re += "\n"
+		for _, finding := range r.GetFindings() {
+			reported := "not reported"
+			if finding.GetReported() {
+				reported = "reported to leader"
+			}
+			re += fmt.Sprintf("-- %s: %s (%s, %s)\n", finding.GetFileName(), finding.GetReason(), time.UnixMilli(finding.GetDetectedAt()).Format(time.DateTime), reported)
+		}
+	}
+	return re, nil
+}
+
+// getScrubStatus gets the scrub status of a data server.
+func (c *Client) getScrubStatus(hostname string) (*dataServerProto.GetScrubStatusReply, error) {
+	conn, err := connpool.Get(hostname + ":" + c.dataServerPort)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to dataServer: %v", err)
+	}
+	defer conn.Close()
+
+	client := dataServerProto.NewDataServerClient(conn)
+	ctx, cancel := context.WithTimeout(c.context(), time.Second*5)
+	defer cancel()
+	r, err := client.GetScrubStatus(ctx, &dataServerProto.GetScrubStatusRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scrub status: %v", err)
+	}
+	return r, nil
+}
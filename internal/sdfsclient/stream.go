@@ -0,0 +1,427 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/erasure"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/metadata"
+	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+// readAhead is the number of blocks fetched ahead of the one being read by a FileReader.
+const readAhead = 2
+
+// FileReader streams the current version of a file in SDFS, the blocks are fetched on demand. It holds the read lock
+// of the file until it is closed, and is not safe for concurrent use.
+type FileReader struct {
+	r      *rangeReader
+	offset int64
+	blocks map[int64]*blockFetch // fetched or being fetched
+	closed bool
+}
+
+// blockFetch is a block being fetched in the background, done is closed when data or err is set.
+type blockFetch struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// Open opens a file in SDFS for reading.
+func (c *Client) Open(sdfsfilename string) (*FileReader, error) {
+	r, err := c.openRangeReader(sdfsfilename)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Opened file %s of size %d", sdfsfilename, r.size)
+	return &FileReader{r: r, blocks: map[int64]*blockFetch{}}, nil
+}
+
+// Size returns the size of the file.
+func (f *FileReader) Size() int64 {
+	return f.r.size
+}
+
+// Read reads from the block at the offset and starts fetching the next readAhead blocks.
+func (f *FileReader) Read(p []byte) (int, error) {
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	if f.offset >= f.r.size {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	blockSize := f.r.c.blockSize
+	blockID := f.offset / blockSize
+	// the blocks behind the offset, or left ahead by a seek, are dropped
+	for id := range f.blocks {
+		if id < blockID || id > blockID+readAhead {
+			delete(f.blocks, id)
+		}
+	}
+	for id := blockID; id <= blockID+readAhead && id*blockSize < f.r.size; id++ {
+		f.fetch(id)
+	}
+	block := f.blocks[blockID]
+	<-block.done
+	if block.err != nil {
+		// fetched again by the next read
+		delete(f.blocks, blockID)
+		return 0, block.err
+	}
+	from := f.offset - blockID*blockSize
+	if from >= int64(len(block.data)) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	n := copy(p, block.data[from:])
+	f.offset += int64(n)
+	return n, nil
+}
+
+// fetch starts fetching a block unless it is fetched.
+func (f *FileReader) fetch(blockID int64) {
+	if _, ok := f.blocks[blockID]; ok {
+		return
+	}
+	block := &blockFetch{done: make(chan struct{})}
+	f.blocks[blockID] = block
+	go func() {
+		defer close(block.done)
+		block.data, block.err = f.r.readBlock(blockID, 0, f.r.c.blockSize)
+		blockMeta := f.r.block(blockID)
+		// the records appended after the version is read are not returned
+		if int64(len(block.data)) > blockMeta.BlockSize {
+			block.data = block.data[:blockMeta.BlockSize]
+		}
+	}()
+}
+
+// Seek sets the offset of the next Read like io.Seeker.
+func (f *FileReader) Seek(offset int64, whence int) (int64, error) {
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.r.size
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset %d", offset)
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+// Close releases the read lock of the file.
+func (f *FileReader) Close() error {
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	f.blocks = nil
+	return f.r.close()
+}
+
+// FileWriter streams a new version of a file, or an append to a file, to SDFS. A block is sent to the data servers as
+// soon as it is full, and the blocks are committed when the writer is closed. It holds the write lock of the file until
+// it is closed, and is not safe for concurrent use.
+type FileWriter struct {
+	c           *Client
+	leader      string
+	lease       *fileLease // write lease of the file, released by Close or Abort
+	name        string
+	appending   bool
+	options     PutOptions
+	policy      string
+	compression string // codec of the written blocks
+	storageName string
+	generation  int64
+	firstID     int64 // first block written
+	nextID      int64 // block being filled
+	buffer      []byte
+	written     int64
+
+	mu     sync.Mutex
+	blocks metadata.BlockInfo // placed blocks, the sizes and the hosts are set once they are sent
+	err    error              // first failure of sending a block
+
+	writeSem *semaphore.Weighted
+	eg       *errgroup.Group
+	closed   bool
+}
+
+// Create creates a new version of a file in SDFS for writing.
+func (c *Client) Create(sdfsfilename string) (*FileWriter, error) {
+	return c.CreateWithOptions(sdfsfilename, PutOptions{})
+}
+
+// CreateWithOptions creates a new version of a file in SDFS for writing with the settings of the file in options.
+// Erasure-coded files are written with PutFileWithOptions.
+func (c *Client) CreateWithOptions(sdfsfilename string, options PutOptions) (*FileWriter, error) {
+	leader, err := c.getLeader()
+	if err != nil {
+		return nil, err
+	}
+	lease, err := c.acquireFileWriteLock(leader, sdfsfilename)
+	if err != nil {
+		return nil, err
+	}
+	// one block is placed, the others are added as the data arrives
+	fileVersion, err := c.putBlockInfo(leader, sdfsfilename, 1, options)
+	if err != nil {
+		c.releaseFileLock(lease)
+		return nil, err
+	}
+	policy, err := erasure.ParsePolicy(fileVersion.Policy)
+	if err != nil {
+		c.releaseFileLock(lease)
+		return nil, err
+	}
+	if policy.IsErasureCoded() {
+		c.releaseFileLock(lease)
+		return nil, fmt.Errorf("cannot stream file %s with policy %s, put it as a local file instead", sdfsfilename, policy)
+	}
+	first := fileVersion.BlockInfo[0]
+	logrus.Infof("Created file %s", sdfsfilename)
+	return c.newFileWriter(lease, sdfsfilename, false, options, fileVersion.Policy, fileVersion.Compression, fileVersion.BlockInfo, first.FileName, first.Generation, 0, nil), nil
+}
+
+// Append opens a file in SDFS for appending, the file is created if it does not exist.
+func (c *Client) Append(sdfsfilename string) (*FileWriter, error) {
+	leader, err := c.getLeader()
+	if err != nil {
+		return nil, err
+	}
+	lease, err := c.acquireFileWriteLock(leader, sdfsfilename)
+	if err != nil {
+		return nil, err
+	}
+	// the last block if it is not full, or a new block
+	blockInfo, generation, compression, err := c.appendBlockInfo(leader, sdfsfilename, 1, AppendOptions{})
+	if err != nil {
+		c.releaseFileLock(lease)
+		return nil, err
+	}
+	firstID := int64(-1)
+	for blockID := range blockInfo {
+		if firstID < 0 || blockID < firstID {
+			firstID = blockID
+		}
+	}
+	first := blockInfo[firstID]
+	// the data of the last block is written again with the new generation
+	data := []byte{}
+	if first.BlockSize > 0 {
+		data, err = (&rangeReader{c: c, leader: leader}).readBlockRange(first, 0, first.BlockSize)
+		if err != nil {
+			c.releaseFileLock(lease)
+			return nil, err
+		}
+	}
+	logrus.Infof("Opened file %s for appending at block %d", sdfsfilename, firstID)
+	return c.newFileWriter(lease, sdfsfilename, true, PutOptions{}, "", compression, blockInfo, first.FileName, generation, firstID, data), nil
+}
+
+// newFileWriter creates a writer of the blocks from firstID of a version which holds the write lease of the file, data
+// is the start of block firstID.
+func (c *Client) newFileWriter(lease *fileLease, name string, appending bool, options PutOptions, policy string, compression string, blocks metadata.BlockInfo, storageName string, generation int64, firstID int64, data []byte) *FileWriter {
+	eg, _ := errgroup.WithContext(context.Background())
+	return &FileWriter{
+		c:           c,
+		leader:      lease.leader,
+		lease:       lease,
+		name:        name,
+		appending:   appending,
+		options:     options,
+		policy:      policy,
+		compression: compression,
+		storageName: storageName,
+		generation:  generation,
+		firstID:     firstID,
+		nextID:      firstID,
+		buffer:      data,
+		blocks:      blocks,
+		// each block being sent is held in memory
+		writeSem: semaphore.NewWeighted(2),
+		eg:       eg,
+	}
+}
+
+// Write buffers p and sends each block filled by it to the data servers. It fails if any block failed to be sent.
+func (w *FileWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	written := 0
+	for len(p) > 0 {
+		if err := w.failure(); err != nil {
+			return written, err
+		}
+		if cap(w.buffer) < int(w.c.blockSize) {
+			w.buffer = append(make([]byte, 0, w.c.blockSize), w.buffer...)
+		}
+		n := int(w.c.blockSize) - len(w.buffer)
+		if n > len(p) {
+			n = len(p)
+		}
+		w.buffer = append(w.buffer, p[:n]...)
+		p = p[n:]
+		written += n
+		w.written += int64(n)
+		if int64(len(w.buffer)) == w.c.blockSize {
+			if err := w.cutBlock(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// cutBlock sends the buffered block to the data servers in the background.
+func (w *FileWriter) cutBlock() error {
+	data := w.buffer
+	w.buffer = nil
+	blockID := w.nextID
+	w.nextID++
+	w.mu.Lock()
+	blockMeta, ok := w.blocks[blockID]
+	w.mu.Unlock()
+	if !ok {
+		var err error
+		blockMeta, err = w.c.addBlock(w.leader, w.name, w.storageName, blockID, w.generation, w.options.Replication)
+		if err != nil {
+			w.fail(err)
+			return err
+		}
+	}
+	if err := w.writeSem.Acquire(context.Background(), 1); err != nil {
+		return fmt.Errorf("failed to acquire semaphore: %v", err)
+	}
+	w.eg.Go(func() error {
+		defer w.writeSem.Release(1)
+		stored, err := compressBlock(&blockMeta, w.compression, data)
+		if err != nil {
+			w.fail(err)
+			return err
+		}
+		hostNames, err := w.c.putFileBlockToReplicas(blockMeta.HostNames, blockMeta.FileName, blockID, w.generation, stored)
+		if err != nil {
+			w.fail(err)
+			return err
+		}
+		blockMeta.HostNames = hostNames
+		blockMeta.Generation = w.generation
+		w.mu.Lock()
+		w.blocks[blockID] = blockMeta
+		w.mu.Unlock()
+		return nil
+	})
+	return nil
+}
+
+// fail records the first failure of sending a block.
+func (w *FileWriter) fail(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// failure returns the first failure of sending a block.
+func (w *FileWriter) failure() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Close sends the last block and commits the blocks to the leader, then releases the write lock of the file. Nothing
+// is committed if any block failed to be sent.
+func (w *FileWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	defer w.c.releaseFileLock(w.lease)
+	if w.appending && w.written == 0 {
+		logrus.Info("Append nothing to file ", w.name)
+		return nil
+	}
+	if len(w.buffer) > 0 && w.failure() == nil {
+		// a failure is recorded and returned below
+		w.cutBlock()
+	}
+	if err := w.eg.Wait(); err != nil {
+		return fmt.Errorf("Failed to put file %s to SDFS: %w", w.name, err)
+	}
+	if err := w.failure(); err != nil {
+		return fmt.Errorf("Failed to put file %s to SDFS: %w", w.name, err)
+	}
+	// the blocks placed ahead of the data are not committed
+	blockInfo := metadata.BlockInfo{}
+	for blockID := w.firstID; blockID < w.nextID; blockID++ {
+		blockInfo[blockID] = w.blocks[blockID]
+	}
+	if w.appending {
+		if err := w.c.appendFileOK(w.leader, w.name, blockInfo, 0, w.compression); err != nil {
+			return fmt.Errorf("Failed to append file %s OK to leader %s: %w", w.name, w.leader, err)
+		}
+		logrus.Infof("Appended %d bytes to file %s", w.written, w.name)
+		return nil
+	}
+	fileVersion := metadata.FileVersion{BlockInfo: blockInfo, Policy: w.policy, Compression: w.compression}
+	if err := w.c.putFileOK(w.leader, w.name, fileVersion, w.options); err != nil {
+		return fmt.Errorf("Failed to put file %s OK to leader %s: %w", w.name, w.leader, err)
+	}
+	logrus.Infof("Put file %s of size %d to SDFS", w.name, w.written)
+	return nil
+}
+
+// Abort releases the write lock of the file without committing the blocks, the blocks sent by the writer are removed
+// from the data servers as orphans.
+func (w *FileWriter) Abort() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	w.eg.Wait()
+	logrus.Infof("Aborted writing file %s", w.name)
+	return w.c.releaseFileLock(w.lease)
+}
+
+// addBlock gets the hosts of one more block of a version being written from the leader server.
+func (c *Client) addBlock(leader, fileName, storageName string, blockID int64, generation int64, replication int) (metadata.BlockMeta, error) {
+	var blockMeta metadata.BlockMeta
+	err := c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		r, err := client.AddBlock(ctx, &leaderServerProto.AddBlockRequest{
+			FileName:    fileName,
+			StorageName: storageName,
+			BlockID:     blockID,
+			Generation:  generation,
+			Replication: int32(replication),
+		})
+		if err != nil {
+			return err
+		}
+		blockMeta = fromProtoBlockMeta(r.GetBlockMeta())
+		return nil
+	})
+	if err != nil {
+		return metadata.BlockMeta{}, fmt.Errorf("failed to add block %d of file %s: %v", blockID, fileName, err)
+	}
+	return blockMeta, nil
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	leaderServerProto "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver/proto"
+)
+
+// ListVersions returns the versions of a file which are kept in SDFS, the newest first.
+func (c *Client) ListVersions(sdfsfilename string) ([]int64, error) {
+	leader, err := c.getLeader()
+	if err != nil {
+		return nil, err
+	}
+	var r *leaderServerProto.GetVersionsReply
+	err = c.callLeader(leader, func(client leaderServerProto.LeaderServerClient, ctx context.Context) error {
+		r, err = client.GetVersions(ctx, &leaderServerProto.GetVersionsRequest{FileName: sdfsfilename})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get versions of file %s: %v", sdfsfilename, err)
+	}
+	return r.GetVersions(), nil
+}
+
+// GetVersions gets the last num versions of a file from SDFS into a local file, the newest first.
+// Each version starts with a line "==== <sdfsfilename> version <version> ====".
+func (c *Client) GetVersions(sdfsfilename string, num int, localfilename string) error {
+	if num <= 0 {
+		return fmt.Errorf("number of versions %d is not positive", num)
+	}
+	versions, err := c.ListVersions(sdfsfilename)
+	if err != nil {
+		return err
+	}
+	if num < len(versions) {
+		versions = versions[:num]
+	}
+	tempFileName := fmt.Sprintf("%s.versions.temp", localfilename)
+	file, err := os.Create(tempFileName)
+	if err != nil {
+		return fmt.Errorf("failed to create temp file %s: %v", tempFileName, err)
+	}
+	defer os.Remove(tempFileName)
+	defer file.Close()
+	for _, version := range versions {
+		versionFileName := fmt.Sprintf("%s.v%d", localfilename, version)
+		if err := c.GetFileVersion(sdfsfilename, versionFileName, version); err != nil {
+			return err
+		}
+		err := appendVersion(file, versionFileName, fmt.Sprintf("==== %s version %d ====\n", sdfsfilename, version))
+		os.Remove(versionFileName)
+		if err != nil {
+			return err
+		}
+	}
+	file.Close()
+	if err := os.Rename(tempFileName, localfilename); err != nil {
+		return fmt.Errorf("failed to rename temp file %s to local file %s: %v", tempFileName, localfilename, err)
+	}
+	logrus.Infof("Got %d versions of file %s from SDFS to %s", len(versions), sdfsfilename, localfilename)
+	return nil
+}
+
+// appendVersion writes the delimiter and the content of a version to file.
+func appendVersion(file *os.File, versionFileName, delimiter string) error {
+	version, err := os.Open(versionFileName)
+	if err != nil {
+		return fmt.Errorf("cannot open local file %s: %v", versionFileName, err)
+	}
+	defer version.Close()
+	if _, err := file.WriteString(delimiter); err != nil {
+		return fmt.Errorf("failed to write file %s: %v", file.Name(), err)
+	}
+	if _, err := io.Copy(file, version); err != nil {
+		return fmt.Errorf("failed to write file %s: %v", file.Name(), err)
+	}
+	return nil
+}
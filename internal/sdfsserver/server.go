@@ -1,13 +1,17 @@
 package sdfsserver
 
 import (
+	"os"
 	"sync"
 
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/command"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/connpool"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/dataserver"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/leaderserver"
 	memberserver "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/memberserver/command/server"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/memberserver/membership"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/scheduler"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/taskmanager"
 )
@@ -28,8 +32,18 @@ func NewServer(configPath string) (*SDFSServer, error) {
 	if err != nil {
 		return nil, err
 	}
-	leaderServer := leaderserver.NewLeaderServer(config.LeaderServerPort, config.DataServerPort, config.BlockSize, config.RelicationFactor)
-	dataServer := dataserver.NewDataServer(config.DataServerPort, config.BlocksDir)
+	// the servers and the clients in them use the certificate of the node
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.Init(config.TLS, hostname); err != nil {
+		return nil, err
+	}
+	// the calls of the clients in this node to a failed member are not waited for
+	membership.OnFailure(connpool.CloseHost)
+	leaderServer := leaderserver.NewLeaderServer(config)
+	dataServer := dataserver.NewDataServer(config)
 	memberServer := memberserver.NewMemberServer(config.MemberServerPort)
 	commandServer := command.NewCommandServer(config.CommandServerPort, configPath)
 	scheduler := scheduler.NewScheduler(config, configPath)
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/enums"
 	client "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/sdfsclient"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/taskmanager/proto"
@@ -20,6 +21,7 @@ type Task struct {
 	exeFilename    string
 	inputFilenames []string
 	params         []string
+	caller         string // identity the task is run for
 	stream         pb.TaskManager_PutTaskServer
 	finished       chan<- bool
 	err            chan<- error
@@ -47,6 +49,7 @@ func (t *TaskManager) PutTask(in *pb.PutTaskRequest, stream pb.TaskManager_PutTa
 		exeFilename:    in.GetExeFilename(),
 		inputFilenames: in.GetInputFilenames(),
 		params:         in.GetParams(),
+		caller:         auth.Caller(stream.Context()),
 		stream:         stream,
 		finished:       fin,
 		err:            err,
@@ -100,7 +103,7 @@ func (t *TaskManager) processMapleTask(task *Task) error {
 	defer utils.DeleteLocalFolder(foldername)
 
 	// Step1: Download executable from SDFS
-	sdfsClient, err := client.NewClient(t.configPath)
+	sdfsClient, err := client.NewClientAs(t.configPath, task.caller)
 	if err != nil {
 		return err
 	}
@@ -126,12 +129,12 @@ func (t *TaskManager) processMapleTask(task *Task) error {
 
 	sdfsIntermediateFilenamePrefix := task.params[0]
 	args := []string{
-		"./" + task.exeFilename,
 		inputFilename,
 		sdfsIntermediateFilenamePrefix,
 	}
 	args = append(args, task.params[1:]...)
-	if err := execCommand(foldername, "bash", "-c", strings.Join(args, " ")); err != nil {
+	// the params are passed as they are without a shell
+	if err := execCommand(foldername, "./"+task.exeFilename, args...); err != nil {
 		return err
 	}
 
@@ -144,7 +147,7 @@ func (t *TaskManager) processMapleTask(task *Task) error {
 	for _, filename := range intermediateFiles {
 		func(filename string) {
 			eg.Go(func() error {
-				err := sdfsClient.AppendFileWithRetry(foldername+"/"+filename, filename)
+				err := sdfsClient.AppendRecordsWithRetry(foldername+"/"+filename, filename)
 				if err != nil {
 					return err
 				}
@@ -194,7 +197,7 @@ func (t *TaskManager) processJuiceTask(task *Task) error {
 	defer utils.DeleteLocalFolder(foldername)
 
 	// Step1: Download executable from SDFS
-	sdfsClient, err := client.NewClient(t.configPath)
+	sdfsClient, err := client.NewClientAs(t.configPath, task.caller)
 	if err != nil {
 		return err
 	}
@@ -220,17 +223,17 @@ func (t *TaskManager) processJuiceTask(task *Task) error {
 	sdfsDestFilename := task.params[0]
 	sdfsIntermediateFilenamePrefix := task.params[1]
 	args := []string{
-		"./" + task.exeFilename,
 		sdfsIntermediateFilenamePrefix,
 		sdfsDestFilename,
 	}
 	args = append(args, task.params[2:]...)
-	if err := execCommand(foldername, "bash", "-c", strings.Join(args, " ")); err != nil {
+	// the params are passed as they are without a shell
+	if err := execCommand(foldername, "./"+task.exeFilename, args...); err != nil {
 		return err
 	}
 
 	// Step4: Upload output file to SDFS
-	err = sdfsClient.AppendFileWithRetry(foldername+"/"+sdfsDestFilename, sdfsDestFilename)
+	err = sdfsClient.AppendRecordsWithRetry(foldername+"/"+sdfsDestFilename, sdfsDestFilename)
 	if err != nil {
 		return err
 	}
@@ -6,10 +6,9 @@ import (
 	"os"
 
 	"github.com/sirupsen/logrus"
+	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/auth"
 	"gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/config"
 	pb "gitlab.engr.illinois.edu/ckchu2/cs425-mp4/internal/taskmanager/proto"
-
-	"google.golang.org/grpc"
 )
 
 type TaskManager struct {
@@ -44,7 +43,14 @@ func (t *TaskManager) Run() {
 		return
 	}
 	defer listen.Close()
-	grpcServer := grpc.NewServer()
+	// only the nodes may call the methods of the cluster itself
+	grpcServer, err := auth.NewServer(
+		auth.Method(pb.TaskManager_ServiceDesc, "PutTask"),
+	)
+	if err != nil {
+		logrus.Fatalf("failed to create gRPC server: %v", err)
+		return
+	}
 	pb.RegisterTaskManagerServer(grpcServer, t)
 	logrus.Infof("TaskManager listening on port %s", t.port)
 	if err := grpcServer.Serve(listen); err != nil {
@@ -57,17 +57,7 @@ func ListLocalFilesWithPrefix(foldername, prefix string) ([]string, error) {
 }
 
 func ListSDFSFilesWithPrefix(sdfsClient *client.Client, prefix string) ([]string, error) {
-	var filenames []string
-	metadata, err := sdfsClient.GetMetadata()
-	if err != nil {
-		return nil, err
-	}
-	for filename := range metadata.GetFileInfo() {
-		if len(filename) >= len(prefix) && filename[0:len(prefix)] == prefix {
-			filenames = append(filenames, filename)
-		}
-	}
-	return filenames, nil
+	return sdfsClient.ListFilesWithPrefix(prefix)
 }
 
 func DeleteSDFSFiles(sdfsClient *client.Client, filenames []string) error {